@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jdonohoo/vern-bot/go/internal/compare"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   `compare --personas a,b --llms x,y "<prompt>"`,
+	Short: "Run one prompt across personas and LLMs and compare the answers",
+	Long: `Compare: run the same prompt for every persona × LLM combination in
+parallel and report the answers side by side.
+
+Each answer is written to NN-<persona>-<llm>.md in the output directory, along
+with compare.md, compare.html (side-by-side columns, one row per persona), and
+compare.json. The report lists each answer's length and latency.
+
+Use "none" as a persona to include the bare LLM. With --judge, a judge LLM
+scores every answer 1-5 on relevance, depth, clarity, and actionability
+without being told which persona or LLM wrote it.`,
+	Example: `  vern compare --personas great,mediocre --llms claude,gemini "Should we add a cache?"
+  vern compare --personas architect --llms claude,codex,gemini --judge claude "Design a rate limiter"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCompare,
+}
+
+var (
+	comparePersonas  []string
+	compareLLMs      []string
+	compareOutputDir string
+	compareJudge     string
+	compareTimeout   int
+)
+
+func init() {
+	compareCmd.Flags().StringSliceVar(&comparePersonas, "personas", nil, "Persona IDs to compare (comma-separated; \"none\" for the bare LLM)")
+	compareCmd.Flags().StringSliceVar(&compareLLMs, "llms", []string{"claude"}, "LLMs to run each persona on (comma-separated)")
+	compareCmd.Flags().StringVarP(&compareOutputDir, "output-dir", "d", "compare", "Output directory for answers and the report")
+	compareCmd.Flags().StringVar(&compareJudge, "judge", "", "Score each answer with this LLM against the rubric")
+	compareCmd.Flags().IntVarP(&compareTimeout, "timeout", "t", 0, "Timeout in seconds per answer (default: VERN_TIMEOUT, the persona's timeout, or 1200)")
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	timeout := 0 // the persona's timeout, or 20 minutes
+	if envTimeout := os.Getenv("VERN_TIMEOUT"); envTimeout != "" {
+		fmt.Sscanf(envTimeout, "%d", &timeout)
+	}
+	if compareTimeout > 0 {
+		timeout = compareTimeout
+	}
+
+	report, err := compare.Run(compare.Options{
+		Prompt:    args[0],
+		Personas:  comparePersonas,
+		LLMs:      compareLLMs,
+		OutputDir: compareOutputDir,
+		AgentsDir: resolveAgentsDir(),
+		Timeout:   timeout,
+		JudgeLLM:  compareJudge,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, c := range report.Cells {
+		if c.Succeeded {
+			return nil
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Error: every comparison run failed")
+	os.Exit(1)
+	return nil
+}
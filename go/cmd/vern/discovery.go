@@ -21,6 +21,8 @@ Flags:
   --skip-historian     Skip the Historian pre-step
   --vernhole N         Run VernHole with N verns after pipeline
   --vernhole-council   Use a named council tier
+  --vernhole-rounds N  Run VernHole as an N-round debate
+  --vernhole-seed N    Seed VernHole council selection (reproduce a council)
   --oracle             Run Oracle Vern after VernHole
   --oracle-apply       Auto-apply Oracle's vision via Architect Vern
   --expanded           Use expanded pipeline
@@ -28,7 +30,9 @@ Flags:
   --resume-from N      Resume pipeline from step N
   --max-retries N      Max retry attempts per step
   --llm-mode MODE      LLM fallback mode (mixed_claude_fallback, mixed_codex_fallback, etc.)
-  --single-llm LLM     Use a single LLM for all steps`,
+  --single-llm LLM     Use a single LLM for all steps
+  --evaluate           Score each step, the VTS set, and the VernHole synthesis with a judge LLM
+  --eval-threshold N   Re-run steps the judge scores below N (1-5; implies --evaluate)`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runDiscovery,
 }
@@ -39,6 +43,8 @@ var (
 	discSkipHistorian bool
 	discVernhole      int
 	discCouncil       string
+	discRounds        int
+	discSeed          int64
 	discOracle        bool
 	discOracleApply   bool
 	discExpanded      bool
@@ -47,6 +53,8 @@ var (
 	discMaxRetries    int
 	discLLMMode       string
 	discSingleLLM     string
+	discEvaluate      bool
+	discEvalThreshold float64
 )
 
 func init() {
@@ -55,6 +63,8 @@ func init() {
 	discoveryCmd.Flags().BoolVar(&discSkipHistorian, "skip-historian", false, "Skip the Historian pre-step (indexing input files)")
 	discoveryCmd.Flags().IntVar(&discVernhole, "vernhole", 0, "Run VernHole with N verns after pipeline")
 	discoveryCmd.Flags().StringVar(&discCouncil, "vernhole-council", "", "Use a named VernHole council tier")
+	discoveryCmd.Flags().IntVar(&discRounds, "vernhole-rounds", 1, "VernHole debate rounds (>1 makes Verns respond to each other)")
+	discoveryCmd.Flags().Int64Var(&discSeed, "vernhole-seed", 0, "Seed VernHole council selection (default: random, recorded in vernhole/council.json)")
 	discoveryCmd.Flags().BoolVar(&discOracle, "oracle", false, "Run Oracle Vern after VernHole")
 	discoveryCmd.Flags().BoolVar(&discOracleApply, "oracle-apply", false, "Auto-apply Oracle's vision")
 	discoveryCmd.Flags().BoolVar(&discExpanded, "expanded", false, "Use expanded pipeline")
@@ -63,6 +73,8 @@ func init() {
 	discoveryCmd.Flags().IntVar(&discMaxRetries, "max-retries", 0, "Max retry attempts per step")
 	discoveryCmd.Flags().StringVar(&discLLMMode, "llm-mode", "", "LLM fallback mode (mixed_claude_fallback, mixed_codex_fallback, mixed_gemini_fallback, mixed_copilot_fallback, single_llm)")
 	discoveryCmd.Flags().StringVar(&discSingleLLM, "single-llm", "", "Use a single LLM for all steps (shorthand for --llm-mode single_llm)")
+	discoveryCmd.Flags().BoolVar(&discEvaluate, "evaluate", false, "Score outputs against the evaluation rubric with a judge LLM")
+	discoveryCmd.Flags().Float64Var(&discEvalThreshold, "eval-threshold", 0, "Re-run steps scoring below this (1-5; default: evaluation.threshold from config)")
 	rootCmd.AddCommand(discoveryCmd)
 }
 
@@ -116,6 +128,8 @@ func runDiscovery(cmd *cobra.Command, args []string) error {
 		MaxRetries:        discMaxRetries,
 		VernHoleCount:     discVernhole,
 		VernHoleCouncil:   discCouncil,
+		VernHoleRounds:    discRounds,
+		VernHoleSeed:      discSeed,
 		OracleFlag:        discOracle,
 		OracleApplyFlag:   discOracleApply,
 		ExtraContextFiles: discExtraContext,
@@ -124,6 +138,8 @@ func runDiscovery(cmd *cobra.Command, args []string) error {
 		Timeout:           timeout,
 		LLMMode:           discLLMMode,
 		SingleLLM:         discSingleLLM,
+		Evaluate:          discEvaluate,
+		EvalThreshold:     discEvalThreshold,
 	}
 
 	return pipeline.Run(opts)
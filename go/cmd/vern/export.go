@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jdonohoo/vern-bot/go/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var exportFormat string
+var exportOutputDir string
+
+var exportCmd = &cobra.Command{
+	Use:   "export <vts-directory>",
+	Short: "Export VTS tasks to GitHub Issues, Jira, or Linear import files",
+	Long: `Export VTS task files to issue tracker import formats. Runs the same
+preflight validation as tobeads, then writes files — nothing is sent anywhere.
+
+Formats:
+  github  github-issues.sh (gh CLI batch script) + github-issues.json
+  jira    jira-import.csv (Jira external system import)
+  linear  linear-import.csv (Linear CSV import)
+
+Dependencies become "blocked by" links, complexity becomes labels and
+story points (XS=1, S=2, M=3, L=5, XL=8).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		absVTSDir, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("resolve VTS path: %w", err)
+		}
+		info, err := os.Stat(absVTSDir)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("VTS directory not found: %s", args[0])
+		}
+
+		exp, err := export.New(exportFormat)
+		if err != nil {
+			return err
+		}
+
+		outDir := exportOutputDir
+		if outDir == "" {
+			outDir = filepath.Join(filepath.Dir(absVTSDir), "export")
+		}
+
+		opts := export.Options{
+			VTSDir:    absVTSDir,
+			OutputDir: outDir,
+			Format:    exportFormat,
+		}
+
+		result, err := export.Run(opts, exp)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if result != nil && result.Failed > 0 {
+			os.Exit(2)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "github", "Export format (github, jira, linear)")
+	exportCmd.Flags().StringVarP(&exportOutputDir, "output-dir", "d", "", "Output directory (default: <vts-dir>/../export)")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/jdonohoo/vern-bot/go/internal/generate"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +22,11 @@ Creates 3 files and updates 4 registration files:
   commands/v.md             - Router aliases
   commands/help.md          - Help text
   embedded_test.go          - Test expectations
-  council/selection.go      - Hardcoded roster`,
+  council/selection.go      - Hardcoded roster
+
+Outside a vern-bot checkout (no agents/ + go/ dirs above the working
+directory and no VERN_ROOT), only the agent file is written, to
+~/.config/vern/agents/{name}.md, where every vern command picks it up.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runGenerate,
 }
@@ -49,9 +54,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	repoRoot, err := generate.DetectRepoRoot()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Set VERN_ROOT env var or run from within the vern-bot repo.\n")
-		os.Exit(1)
+		repoRoot = ""
+		fmt.Fprintf(os.Stderr, "No vern-bot checkout found; writing the persona to %s\n", persona.UserDir())
+		fmt.Fprintf(os.Stderr, "(Set VERN_ROOT or run from within the repo to register it with the plugin.)\n")
 	}
 
 	opts := generate.Options{
@@ -85,9 +85,11 @@ func runHistorian(cmd *cobra.Command, args []string) error {
 		AgentsDir:  agentsDir,
 		Timeout:    historianTimeout,
 		LLMName:    historianLLM,
-		OnLog: func(msg string) {
-			fmt.Printf("    %s\n", msg)
-		},
+		Events: pipeline.FuncHandler(func(e pipeline.Event) {
+			for _, line := range pipeline.EventLines(e) {
+				fmt.Printf("    %s\n", line)
+			}
+		}),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
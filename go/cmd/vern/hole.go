@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jdonohoo/vern-bot/go/internal/config"
 	"github.com/jdonohoo/vern-bot/go/internal/pipeline"
@@ -10,7 +11,7 @@ import (
 )
 
 var holeCmd = &cobra.Command{
-	Use:   "hole <idea>",
+	Use:   "hole <idea> | --retry-failed <dir> [idea]",
 	Short: "Summon random Vern personas for chaotic discovery",
 	Long: `VernHole: summon a council of Vern personas to analyze your idea in parallel.
 
@@ -21,11 +22,53 @@ Council tiers:
   round    - The Round Table (mighty, yolo, startup, academic, enterprise + random fill)
   war      - The War Room (all round table + ux, retro, optimist, nyquil + random fill)
   full     - The Full Vern Experience (all summonable personas)
-  random   - Fate's Hand (random count, random selection)`,
-	Args: cobra.ExactArgs(1),
+  smart    - The Smart Council (4-6 Verns matched to the idea, always with a contrarian)
+  random   - Fate's Hand (random count, random selection)
+
+Random picks (Fate's Hand, fill slots, bare counts) are seeded. The seed and
+the chosen roster are recorded in council.json in the output directory; pass
+--seed to summon the same council again.
+
+Custom councils can be declared under "vernhole.councils" in config or as
+.vern/councils/<name>.json files, and are summoned by name with --council.
+
+Debate mode (--rounds N, N > 1): round 1 collects opening positions; in each
+later round every Vern sees a digest of the others' latest positions and must
+rebut, concede, or refine. Rounds are written to round-01/, round-02/, ...
+and the synthesis covers how positions shifted. Pairs well with --council conflict.
+
+Retrying (--retry-failed <dir>): re-run only the Verns whose NN-id.md output
+is missing or failed, then regenerate synthesis.md and votes.json from every
+available output. The idea, council, and context are read from council.json
+(pass the idea as an argument for older runs without one). --add summons
+extra Verns into the same run. Debate runs retry their last round.
+
+Follow-ups (vern hole ask <dir> "question"): put a question to the council
+of a finished run, or to one Vern with --vern.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if holeRetryFailed != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		if len(holeAdd) > 0 {
+			return fmt.Errorf("--add requires --retry-failed")
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runHole,
 }
 
+var holeAskCmd = &cobra.Command{
+	Use:   "ask <dir> <question>",
+	Short: "Ask a finished VernHole council a follow-up question",
+	Long: `Put a follow-up question to the council of a finished VernHole run, or to
+one Vern with --vern. Each Vern answers in character with its own earlier
+analysis, the synthesis, and earlier follow-ups as context. Questions and
+answers are appended to followups/transcript.md in the run dir. Runs that
+predate council.json need the original idea passed with --idea.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runHoleAsk,
+}
+
 var (
 	holeOutputDir string
 	holeCouncil   string
@@ -33,6 +76,15 @@ var (
 	holeCount     int
 	holeLLMMode   string
 	holeSingleLLM string
+	holeRounds    int
+	holeSeed      int64
+
+	holeRetryFailed string
+	holeAdd         []string
+
+	holeAskVern string
+	holeAskLLM  string
+	holeAskIdea string
 )
 
 func init() {
@@ -42,11 +94,22 @@ func init() {
 	holeCmd.Flags().IntVarP(&holeCount, "count", "n", 0, "Number of Verns to summon (min 3)")
 	holeCmd.Flags().StringVar(&holeLLMMode, "llm-mode", "", "LLM fallback mode (mixed_claude_fallback, mixed_codex_fallback, etc.)")
 	holeCmd.Flags().StringVar(&holeSingleLLM, "single-llm", "", "Use a single LLM for all Verns and synthesis")
+	holeCmd.Flags().IntVar(&holeRounds, "rounds", 1, "Debate rounds; each round after the first shows every Vern the others' positions")
+	holeCmd.Flags().Int64Var(&holeSeed, "seed", 0, "Council selection seed, to reproduce a run (default: random, recorded in council.json)")
+	holeCmd.Flags().StringVar(&holeRetryFailed, "retry-failed", "", "Re-run failed Verns in a previous VernHole output dir and re-synthesize")
+	holeCmd.Flags().StringSliceVar(&holeAdd, "add", nil, "With --retry-failed: extra Vern IDs to add to the council (comma-separated)")
+	holeAskCmd.Flags().StringVar(&holeAskVern, "vern", "", "Ask only this Vern ID (default: the whole council)")
+	holeAskCmd.Flags().StringVar(&holeAskLLM, "single-llm", "", "Use a single LLM for every answer (default: the LLMs recorded in council.json)")
+	holeAskCmd.Flags().StringVar(&holeAskIdea, "idea", "", "The run's idea, for runs without a council.json")
+	holeCmd.AddCommand(holeAskCmd)
 	rootCmd.AddCommand(holeCmd)
 }
 
 func runHole(cmd *cobra.Command, args []string) error {
-	idea := args[0]
+	idea := ""
+	if len(args) > 0 {
+		idea = args[0]
+	}
 	agentsDir := resolveAgentsDir()
 
 	// Find project root
@@ -61,6 +124,7 @@ func runHole(cmd *cobra.Command, args []string) error {
 	// Apply LLM mode overrides
 	synthesisLLM := cfg.GetSynthesisLLM()
 	overrideLLM := cfg.GetOverrideLLM()
+	llmFlagged := holeSingleLLM != "" || holeLLMMode != ""
 
 	if holeSingleLLM != "" {
 		overrideLLM = holeSingleLLM
@@ -71,28 +135,88 @@ func runHole(cmd *cobra.Command, args []string) error {
 		overrideLLM = cfg.GetOverrideLLM()
 	}
 
-	// Resolve timeout
-	timeout := 1200
-	if envTimeout := os.Getenv("VERN_TIMEOUT"); envTimeout != "" {
-		var t int
-		if _, err := fmt.Sscanf(envTimeout, "%d", &t); err == nil {
-			timeout = t
+	timeout := resolveHoleTimeout()
+
+	if holeRetryFailed != "" {
+		// Keep the original run's LLM override unless one was asked for
+		if !llmFlagged {
+			overrideLLM = ""
+		}
+		err := pipeline.RetryVernHole(pipeline.VernHoleRetryOptions{
+			Dir:          holeRetryFailed,
+			Idea:         idea,
+			Extra:        holeAdd,
+			Context:      holeContext,
+			AgentsDir:    agentsDir,
+			Timeout:      timeout,
+			SynthesisLLM: synthesisLLM,
+			OverrideLLM:  overrideLLM,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		return nil
 	}
 
 	err := pipeline.RunVernHole(pipeline.VernHoleOptions{
 		Idea:         idea,
 		OutputDir:    holeOutputDir,
 		Council:      holeCouncil,
+		Councils:     cfg.VernHole.Councils,
 		Count:        holeCount,
 		Context:      holeContext,
 		AgentsDir:    agentsDir,
 		Timeout:      timeout,
 		SynthesisLLM: synthesisLLM,
 		OverrideLLM:  overrideLLM,
+		Rounds:       holeRounds,
+		Seed:         holeSeed,
 	})
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 	return nil
 }
+
+func runHoleAsk(cmd *cobra.Command, args []string) error {
+	answers, transcript, err := pipeline.AskVernHole(pipeline.FollowUpOptions{
+		Dir:         args[0],
+		Vern:        holeAskVern,
+		Question:    args[1],
+		Idea:        holeAskIdea,
+		AgentsDir:   resolveAgentsDir(),
+		Timeout:     resolveHoleTimeout(),
+		OverrideLLM: holeAskLLM,
+	})
+	for _, a := range answers {
+		fmt.Printf("\n=== %s (%s) ===\n\n", a.Vern.Name, a.LLM)
+		if a.Succeeded {
+			fmt.Println(a.Answer)
+		} else {
+			fmt.Printf("No answer: %s\n", a.Error)
+		}
+	}
+	if transcript != "" && len(answers) > 0 {
+		fmt.Printf("\n%s\nTranscript: %s\n", strings.Repeat("-", 40), transcript)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return nil
+}
+
+// resolveHoleTimeout returns the per-Vern timeout from VERN_TIMEOUT, or 0
+// to use each persona's timeout (default 1200s).
+func resolveHoleTimeout() int {
+	timeout := 0
+	if envTimeout := os.Getenv("VERN_TIMEOUT"); envTimeout != "" {
+		var t int
+		if _, err := fmt.Sscanf(envTimeout, "%d", &t); err == nil {
+			timeout = t
+		}
+	}
+	return timeout
+}
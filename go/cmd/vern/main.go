@@ -3,7 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,25 @@ var rootCmd = &cobra.Command{
 	Short: "Vern-Bot CLI — multi-LLM discovery pipeline",
 	Long:  "Vern CLI orchestrates multi-LLM discovery pipelines, VernHole councils, and task management.",
 	Version: version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		applyConfig()
+		llm.SetCommand(strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())))
+	},
+}
+
+// applyConfig installs the persona search path (the project's
+// .vern/agents/, config persona_dirs, then ~/.config/vern/agents/, all
+// ahead of the agents dir and the embedded personas) and the configured
+// prompt wrappers for llm.Run.
+func applyConfig() {
+	agentsDir := resolveAgentsDir()
+	projectRoot := ""
+	if agentsDir != "agents" && len(agentsDir) > len("/agents") {
+		projectRoot = agentsDir[:len(agentsDir)-len("/agents")]
+	}
+	cfg := config.Load(projectRoot)
+	persona.SetSearchDirs(persona.DefaultSearchDirs(cfg.PersonaDirs)...)
+	llm.SetWrappers(cfg.Wrappers)
 }
 
 func main() {
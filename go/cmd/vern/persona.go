@@ -0,0 +1,650 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/embedded"
+	"github.com/jdonohoo/vern-bot/go/internal/generate"
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+	"github.com/jdonohoo/vern-bot/go/internal/personapack"
+	"github.com/jdonohoo/vern-bot/go/internal/personatest"
+	"github.com/spf13/cobra"
+)
+
+var personaCmd = &cobra.Command{
+	Use:   "persona",
+	Short: "Inspect and manage Vern personas",
+	Long: `Persona utilities.
+
+Personas are found in .vern/agents/, config persona_dirs, ~/.config/vern/agents/,
+the vern-bot agents/ directory, and the binary's embedded agents, in that order.
+
+Subcommands:
+  list    List every persona with its source, LLM, and councils
+  show    Print a persona as written, or resolved with --resolved
+  lint    Check frontmatter, required sections, and the sign-off
+  diff    Compare two personas, or one against the definition it overrides
+  remove  Delete a persona and undo its registrations
+  test    Run a persona against probe prompts and check its answers
+  export  Bundle personas and councils into a shareable pack
+  import  Install a pack into ~/.config/vern`,
+}
+
+var personaShowResolved bool
+
+var personaShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print a persona file, or the flattened persona with --resolved",
+	Long: `Print a persona as written, with the path it was loaded from.
+
+With --resolved, follow its extends chain and mixins and print the run
+settings plus the flattened persona text placed ahead of every prompt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		id := args[0]
+
+		if !personaShowResolved {
+			_, e, err := persona.LoadUnresolved(agentsDir, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			content, err := entryContent(e)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("# %s\n\n", entryLocation(e))
+			fmt.Print(content)
+			return nil
+		}
+
+		p, err := persona.Load(agentsDir, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(resolvedSummary(id, p))
+		fmt.Println()
+		fmt.Print(llm.PersonaContext(p))
+		return nil
+	},
+}
+
+var personaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every persona with its source, LLM, and councils",
+	Long: `List every persona on the search path, merged with the embedded ones.
+
+SOURCE is where the winning definition lives (project, config, user, repo,
+or embedded). COUNCILS lists the councils that always summon the persona:
+those naming it as a core member plus "full"-style councils that take the
+whole roster. Random and smart councils may draw anyone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		tiers, err := loadPersonaTiers(agentsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		roster := map[string]council.Vern{}
+		for _, v := range council.ScanRoster(agentsDir) {
+			roster[v.ID] = v
+		}
+
+		fmt.Printf("%-24s %-28s %-9s %-8s %s\n", "ID", "NAME", "SOURCE", "LLM", "COUNCILS")
+		for _, e := range persona.List(agentsDir) {
+			name, llmName, councils := "", "", ""
+			if v, ok := roster[e.ID]; ok {
+				name, llmName = v.Name, v.LLM
+				councils = strings.Join(council.CouncilsFor(e.ID, tiers), ", ")
+			} else if p, err := persona.Load(agentsDir, e.ID); err != nil {
+				name, llmName = "(broken: run vern persona lint)", "-"
+			} else {
+				name, llmName = persona.DisplayName(p.Description), persona.ModelToLLM(p.Model)
+				if persona.PipelineOnly[e.ID] {
+					councils = "(pipeline only)"
+				}
+			}
+			if councils == "" {
+				councils = "-"
+			}
+			fmt.Printf("%-24s %-28s %-9s %-8s %s\n", e.ID, name, e.Source, llmName, councils)
+		}
+		return nil
+	},
+}
+
+var personaLintCmd = &cobra.Command{
+	Use:   "lint [id...]",
+	Short: "Check personas for frontmatter, section, and sign-off problems",
+	Long: `Lint personas (all of them when no IDs are given).
+
+Errors cover broken frontmatter (a name that doesn't match the file, bad
+timeout or read_files values, the unsupported temperature and tools keys,
+unresolvable extends/mixins), a missing description, missing PERSONALITY:,
+CATCHPHRASES:, or SIGN-OFF: sections, and a sign-off that doesn't ask for a
+dad joke. Warnings cover unknown keys and models, a missing color or YOUR
+TASK: section, and descriptions without a "Name - tagline" separator. Exits 1 if any persona has errors.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		ids := args
+		if len(ids) == 0 {
+			for _, e := range persona.List(agentsDir) {
+				ids = append(ids, e.ID)
+			}
+		}
+
+		failed, warned := 0, 0
+		for _, id := range ids {
+			issues := persona.Lint(agentsDir, id)
+			if len(issues) == 0 {
+				continue
+			}
+			if persona.HasErrors(issues) {
+				failed++
+			} else {
+				warned++
+			}
+			fmt.Println(id + ":")
+			for _, i := range issues {
+				fmt.Printf("  %s\n", i)
+			}
+		}
+		fmt.Printf("%d persona(s) checked: %d with errors, %d with warnings only\n", len(ids), failed, warned)
+		if failed > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var personaDiffResolved bool
+
+var personaDiffCmd = &cobra.Command{
+	Use:   "diff <id> [other-id]",
+	Short: "Compare two personas, or one against the definition it overrides",
+	Long: `With two IDs, diff the two personas. With one, diff the winning
+definition against the next one down the search path (e.g. your
+~/.config/vern/agents/paranoid.md against the built-in paranoid).
+
+Files are compared as written; --resolved compares the flattened personas
+after following extends and mixins.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+
+		var a, b persona.Entry
+		if len(args) == 1 {
+			cands := persona.Candidates(agentsDir, args[0])
+			switch len(cands) {
+			case 0:
+				fmt.Fprintf(os.Stderr, "Error: persona %q not found\n", args[0])
+				os.Exit(1)
+			case 1:
+				fmt.Printf("%s is only defined once (%s); nothing to compare.\n", args[0], entryLocation(cands[0]))
+				return nil
+			}
+			a, b = cands[1], cands[0]
+		} else {
+			for i, id := range args {
+				_, e, err := persona.LoadUnresolved(agentsDir, id)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				if i == 0 {
+					a = e
+				} else {
+					b = e
+				}
+			}
+		}
+
+		aText, err := personaDiffText(agentsDir, a)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		bText, err := personaDiffText(agentsDir, b)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		d := persona.Diff(entryLocation(a), aText, entryLocation(b), bText)
+		if d == "" {
+			fmt.Println("No differences.")
+			return nil
+		}
+		fmt.Print(d)
+		return nil
+	},
+}
+
+var (
+	personaRemoveDryRun bool
+	personaRemoveForce  bool
+)
+
+var personaRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Delete a persona and undo what vern generate registered",
+	Long: `Remove the winning definition of a persona.
+
+A persona in the vern-bot agents/ directory is removed the way vern generate
+added it: its agent, command, and skill files are deleted, its entries in
+commands/v.md, commands/help.md, embedded_test.go, and the council fallback
+roster are dropped, and embedded assets are regenerated. A persona in a
+project, config, or user directory is just deleted, so any definition it
+overrode takes over again. Embedded-only personas can't be removed.
+
+Core members of built-in councils need --force. If no definition is left,
+personas that extend or mix in the removed one are listed so they can be
+fixed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		id := args[0]
+		if !persona.ValidID(id) {
+			fmt.Fprintf(os.Stderr, "Error: invalid persona id %q (lowercase letters, digits, and hyphens)\n", id)
+			os.Exit(1)
+		}
+
+		cands := persona.Candidates(agentsDir, id)
+		if len(cands) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: persona %q not found\n", id)
+			os.Exit(1)
+		}
+		e := cands[0]
+		if e.Path == "" {
+			fmt.Fprintf(os.Stderr, "Error: %s is built into the binary; there is no file to remove\n", id)
+			os.Exit(1)
+		}
+		if e.Source == persona.SourceAgents && !personaRemoveForce {
+			builtins := council.AllTiers()
+			for _, name := range council.TierNames(builtins) {
+				for _, c := range builtins[name].Core {
+					if c == id {
+						fmt.Fprintf(os.Stderr, "Error: %s is a core member of the built-in %q council; use --force to remove it anyway\n", id, name)
+						os.Exit(1)
+					}
+				}
+			}
+		}
+
+		// What takes over once e is gone; a repo persona's embedded copy
+		// goes away with the regenerated assets
+		var next *persona.Entry
+		if len(cands) > 1 && !(e.Source == persona.SourceAgents && cands[1].Path == "") {
+			next = &cands[1]
+		}
+		if next == nil {
+			for _, dep := range personaDependents(agentsDir, id) {
+				fmt.Fprintf(os.Stderr, "Warning: %s, which will no longer exist\n", dep)
+			}
+		}
+
+		if e.Source == persona.SourceAgents {
+			opts := generate.Options{
+				Name:     id,
+				RepoRoot: filepath.Dir(filepath.Dir(e.Path)),
+				DryRun:   personaRemoveDryRun,
+			}
+			if err := generate.Remove(opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else if personaRemoveDryRun {
+			fmt.Printf("DRY RUN: would remove %s\n", e.Path)
+			return nil
+		} else {
+			if err := os.Remove(e.Path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Removed %s\n", entryLocation(e))
+		}
+
+		if !personaRemoveDryRun && next != nil {
+			fmt.Printf("%s now resolves to %s\n", id, entryLocation(*next))
+		}
+		return nil
+	},
+}
+
+var (
+	personaTestLLM       string
+	personaTestProbesDir string
+	personaTestReplay    bool
+	personaTestRecord    bool
+	personaTestTimeout   int
+)
+
+var personaTestCmd = &cobra.Command{
+	Use:   "test <id>",
+	Short: "Run a persona against probe prompts and check its answers",
+	Long: `Run a persona against stored probe prompts and check each answer.
+
+Probes are read from <probes-dir>/<id>.json, a JSON list of
+{"name", "prompt", "min_words", "max_words", "contains", "not_contains"};
+without one, a few generic prompts are used. Every answer must have the
+headings the persona's OUTPUT FORMAT asks for, end with the "-- Name"
+attribution line, and fall within the length bounds (80-4000 words by
+default).
+
+With --record each answer is saved as <probes-dir>/<id>/<probe>.golden.md.
+--replay checks those recordings instead of calling an LLM, so tests run
+offline. The report is written to <probes-dir>/<id>/report.md and
+report.json. Exits 1 if any probe fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		id := args[0]
+		if !persona.ValidID(id) {
+			fmt.Fprintf(os.Stderr, "Error: invalid persona id %q (lowercase letters, digits, and hyphens)\n", id)
+			os.Exit(1)
+		}
+		p, err := persona.Load(agentsDir, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if personaTestReplay && personaTestRecord {
+			fmt.Fprintln(os.Stderr, "Error: --replay and --record can't be combined")
+			os.Exit(1)
+		}
+
+		probes, err := personatest.LoadProbes(personaTestProbesDir, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		outDir := filepath.Join(personaTestProbesDir, id)
+
+		var backend personatest.Backend
+		if personaTestReplay {
+			backend = personatest.ReplayBackend{Dir: outDir}
+		} else {
+			llmName := personaTestLLM
+			if llmName == "" {
+				llmName = persona.ModelToLLM(p.Model)
+			}
+			timeout := 0 // the persona's timeout, or 20 minutes
+			if envTimeout := os.Getenv("VERN_TIMEOUT"); envTimeout != "" {
+				fmt.Sscanf(envTimeout, "%d", &timeout)
+			}
+			if personaTestTimeout > 0 {
+				timeout = personaTestTimeout
+			}
+			backend = personatest.LiveBackend{
+				LLM:       llmName,
+				Persona:   id,
+				AgentsDir: agentsDir,
+				Timeout:   time.Duration(timeout) * time.Second,
+			}
+		}
+
+		fmt.Printf("Testing %s against %d probe(s) (%s)...\n", id, len(probes), backend.Name())
+		report, err := personatest.Run(personatest.Options{
+			Persona:   id,
+			AgentsDir: agentsDir,
+			Probes:    probes,
+			Backend:   backend,
+			OutputDir: outDir,
+			Record:    personaTestRecord,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n%d/%d probes passed. Report: %s\n", len(report.Results)-report.Failed(), len(report.Results),
+			filepath.Join(outDir, personatest.MarkdownFile))
+		if !report.Passed() {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var (
+	personaExportOutput      string
+	personaExportName        string
+	personaExportVersion     string
+	personaExportDescription string
+	personaExportCouncils    []string
+)
+
+var personaExportCmd = &cobra.Command{
+	Use:   "export [id...] -o pack.tar.gz",
+	Short: "Bundle personas and councils into a shareable pack",
+	Long: `Bundle personas into a .tar.gz pack for vern persona import.
+
+Each persona's agent markdown is included as it's currently resolved on the
+search path, along with its plugin command (commands/<id>.md) and skill
+(skills/<id>/SKILL.md) when they exist in the vern-bot checkout or
+~/.config/vern. --council adds a custom council's definition and its core
+members that aren't built in. A manifest records the pack's name, version,
+and contents.
+
+Every persona the pack extends, mixes in, or seats on a council must be in
+the pack or built in.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentsDir := resolveAgentsDir()
+		tiers, err := loadPersonaTiers(agentsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		name := personaExportName
+		if name == "" {
+			name = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(personaExportOutput), ".tar.gz"), ".tgz")
+		}
+		repoRoot := filepath.Dir(agentsDir)
+		if !generate.IsRepoRoot(repoRoot) {
+			repoRoot = ""
+		}
+
+		fmt.Printf("Exporting pack %s %s...\n", name, personaExportVersion)
+		pack, err := personapack.Build(personapack.ExportOptions{
+			Name:        name,
+			Version:     personaExportVersion,
+			Description: personaExportDescription,
+			VernVersion: version,
+			Personas:    args,
+			Councils:    personaExportCouncils,
+			Tiers:       tiers,
+			AgentsDir:   agentsDir,
+			RepoRoot:    repoRoot,
+		})
+		if err == nil {
+			err = pack.Write(personaExportOutput)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s (%d persona(s), %d council(s))\n", personaExportOutput, len(pack.Manifest.Personas), len(pack.Manifest.Councils))
+		return nil
+	},
+}
+
+var (
+	personaImportForce  bool
+	personaImportDryRun bool
+)
+
+var personaImportCmd = &cobra.Command{
+	Use:   "import <pack.tar.gz>",
+	Short: "Install a persona pack into ~/.config/vern",
+	Long: `Install a pack made by vern persona export.
+
+Agents go to ~/.config/vern/agents/, councils to ~/.config/vern/councils/,
+and command and skill files to ~/.config/vern/commands/ and skills/. A file
+that already exists with different content is a conflict and nothing is
+installed, unless --force is given. Files written by an earlier version of
+the same pack and not edited since are replaced without asking.
+
+Personas the pack overrides, and project or config personas that will keep
+overriding the pack's, are noted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pack, err := personapack.Read(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		m := pack.Manifest
+		fmt.Printf("Pack %s %s: %s\n", m.Name, m.Version, strings.Join(m.Personas, ", "))
+		if m.Description != "" {
+			fmt.Printf("  %s\n", m.Description)
+		}
+
+		actions, err := personapack.Install(pack, personapack.InstallOptions{
+			AgentsDir: resolveAgentsDir(),
+			Force:     personaImportForce,
+			DryRun:    personaImportDryRun,
+		})
+		for _, a := range actions {
+			fmt.Printf("  %-9s %s\n", a.Status, a.Path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if personaImportDryRun {
+			fmt.Println("DRY RUN: nothing was written")
+		} else {
+			fmt.Printf("Installed %s %s\n", m.Name, m.Version)
+		}
+		return nil
+	},
+}
+
+func init() {
+	personaExportCmd.Flags().StringVarP(&personaExportOutput, "output", "o", "", "Pack file to write (e.g. fintech.tar.gz)")
+	personaExportCmd.Flags().StringVar(&personaExportName, "name", "", "Pack name (default: the output file name)")
+	personaExportCmd.Flags().StringVar(&personaExportVersion, "version", "1.0.0", "Pack version")
+	personaExportCmd.Flags().StringVar(&personaExportDescription, "description", "", "One-line pack description")
+	personaExportCmd.Flags().StringSliceVar(&personaExportCouncils, "council", nil, "Custom councils to include, with their core members")
+	personaExportCmd.MarkFlagRequired("output")
+	personaImportCmd.Flags().BoolVar(&personaImportForce, "force", false, "Overwrite conflicting files")
+	personaImportCmd.Flags().BoolVar(&personaImportDryRun, "dry-run", false, "Show what would be installed without writing")
+	personaTestCmd.Flags().StringVar(&personaTestLLM, "llm", "", "LLM to run the persona on (default: the persona's own)")
+	personaTestCmd.Flags().StringVar(&personaTestProbesDir, "probes-dir", personatest.ProbeDir, "Directory with probe files, recordings, and reports")
+	personaTestCmd.Flags().BoolVar(&personaTestReplay, "replay", false, "Check recorded answers instead of calling an LLM")
+	personaTestCmd.Flags().BoolVar(&personaTestRecord, "record", false, "Save each answer as the probe's golden recording")
+	personaTestCmd.Flags().IntVarP(&personaTestTimeout, "timeout", "t", 0, "Timeout in seconds per probe (default: VERN_TIMEOUT, the persona's timeout, or 1200)")
+	personaShowCmd.Flags().BoolVar(&personaShowResolved, "resolved", false, "Follow extends/mixins and print the flattened persona")
+	personaDiffCmd.Flags().BoolVar(&personaDiffResolved, "resolved", false, "Compare flattened personas instead of the files")
+	personaRemoveCmd.Flags().BoolVar(&personaRemoveDryRun, "dry-run", false, "Show what would be removed without changing anything")
+	personaRemoveCmd.Flags().BoolVar(&personaRemoveForce, "force", false, "Allow removing a core member of a built-in council")
+	personaCmd.AddCommand(personaListCmd)
+	personaCmd.AddCommand(personaShowCmd)
+	personaCmd.AddCommand(personaLintCmd)
+	personaCmd.AddCommand(personaDiffCmd)
+	personaCmd.AddCommand(personaRemoveCmd)
+	personaCmd.AddCommand(personaTestCmd)
+	personaCmd.AddCommand(personaExportCmd)
+	personaCmd.AddCommand(personaImportCmd)
+	rootCmd.AddCommand(personaCmd)
+}
+
+// loadPersonaTiers returns the built-in councils plus those declared in
+// config, ~/.config/vern/councils/, and .vern/councils/.
+func loadPersonaTiers(agentsDir string) (map[string]council.Tier, error) {
+	projectRoot := ""
+	if agentsDir != "agents" && len(agentsDir) > len("/agents") {
+		projectRoot = agentsDir[:len(agentsDir)-len("/agents")]
+	}
+	cfg := config.Load(projectRoot)
+	return council.LoadTiers(cfg.VernHole.Councils, council.UserCouncilDir(), council.ProjectCouncilDir)
+}
+
+// personaDiffText returns what diff compares for an entry: the file as
+// written, or with --resolved the flattened persona.
+func personaDiffText(agentsDir string, e persona.Entry) (string, error) {
+	if !personaDiffResolved {
+		return entryContent(e)
+	}
+	p, err := persona.LoadEntry(agentsDir, e)
+	if err != nil {
+		return "", err
+	}
+	return resolvedSummary(e.ID, p) + "\n" + llm.PersonaContext(p), nil
+}
+
+// personaDependents describes personas that extend or mix in id.
+func personaDependents(agentsDir, id string) []string {
+	var out []string
+	for _, e := range persona.List(agentsDir) {
+		if e.ID == id {
+			continue
+		}
+		p, _, err := persona.LoadUnresolved(agentsDir, e.ID)
+		if err != nil {
+			continue
+		}
+		if p.Extends == id {
+			out = append(out, fmt.Sprintf("%s extends %s", entryLocation(e), id))
+		}
+		for _, m := range p.Mixins {
+			if m == id {
+				out = append(out, fmt.Sprintf("%s mixes in %s", entryLocation(e), id))
+			}
+		}
+	}
+	return out
+}
+
+// entryContent returns a persona's markdown as written.
+func entryContent(e persona.Entry) (string, error) {
+	if e.Path == "" {
+		content, _ := embedded.GetAgent(e.ID)
+		return content, nil
+	}
+	data, err := os.ReadFile(e.Path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// entryLocation describes where a persona was loaded from.
+func entryLocation(e persona.Entry) string {
+	if e.Path == "" {
+		return e.ID + " (embedded)"
+	}
+	return fmt.Sprintf("%s (%s: %s)", e.ID, e.Source, e.Path)
+}
+
+// resolvedSummary lists a resolved persona's chain and run settings.
+func resolvedSummary(id string, p *persona.Persona) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s (resolved)\n", id)
+	if len(p.Chain) > 1 {
+		fmt.Fprintf(&b, "Chain:       %s\n", strings.Join(p.Chain, " -> "))
+	}
+	fmt.Fprintf(&b, "Description: %s\n", p.Description)
+	fmt.Fprintf(&b, "Model:       %s (runs on %s)\n", p.Model, persona.ModelToLLM(p.Model))
+	if p.LLMModel != "" {
+		fmt.Fprintf(&b, "LLM model:   %s\n", p.LLMModel)
+	}
+	if p.Timeout > 0 {
+		fmt.Fprintf(&b, "Timeout:     %s\n", p.Timeout)
+	}
+	if p.ReadFiles != nil {
+		fmt.Fprintf(&b, "Read files:  %v\n", *p.ReadFiles)
+	}
+	return b.String()
+}
@@ -29,12 +29,16 @@ var (
 	runOutputFile string
 	runPersona    string
 	runTimeout    int
+	runPreamble   string
+	runPostamble  string
 )
 
 func init() {
 	runCmd.Flags().StringVarP(&runOutputFile, "output", "o", "", "File to save output to")
-	runCmd.Flags().StringVarP(&runPersona, "persona", "p", "", "Persona name (loads agents/{persona}.md)")
-	runCmd.Flags().IntVarP(&runTimeout, "timeout", "t", 0, "Timeout in seconds (default: VERN_TIMEOUT or 1200)")
+	runCmd.Flags().StringVarP(&runPersona, "persona", "p", "", "Persona ID (from .vern/agents, ~/.config/vern/agents, agents/, or built in)")
+	runCmd.Flags().IntVarP(&runTimeout, "timeout", "t", 0, "Timeout in seconds (default: VERN_TIMEOUT, the persona's timeout, or 1200)")
+	runCmd.Flags().StringVar(&runPreamble, "preamble", "", "Preamble template: text-only, planning, none, or one from config wrappers.templates")
+	runCmd.Flags().StringVar(&runPostamble, "postamble", "", "Postamble template: sign-off, none, or one from config wrappers.templates")
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -42,8 +46,8 @@ func runRun(cmd *cobra.Command, args []string) error {
 	llmName := args[0]
 	prompt := args[1]
 
-	// Resolve timeout: flag > env > default
-	timeout := 1200
+	// Resolve timeout: flag > env > the persona's timeout > 20 minutes (0 here)
+	timeout := 0
 	if envTimeout := os.Getenv("VERN_TIMEOUT"); envTimeout != "" {
 		fmt.Sscanf(envTimeout, "%d", &timeout)
 	}
@@ -62,6 +66,9 @@ func runRun(cmd *cobra.Command, args []string) error {
 		Timeout:    time.Duration(timeout) * time.Second,
 		WorkingDir: os.Getenv("VERN_WORKING_DIR"),
 		AgentsDir:  agentsDir,
+		Step:       "run",
+		Preamble:   runPreamble,
+		Postamble:  runPostamble,
 	}
 
 	result, err := llm.Run(opts)
@@ -71,7 +78,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 	// Print output to stdout (tee behavior when output file is set)
 	if result.Output != "" {
-		fmt.Print(result.Output)
+		fmt.Print(result.Text())
 	}
 
 	if result.ExitCode != 0 {
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize LLM runs from the run log by persona, LLM, and command",
+	Long: `Stats: aggregate ~/.config/vern/logs/vern.log, the log every LLM run
+appends to, into usage statistics.
+
+For each persona, LLM, and vern command (or step, with --by step) it reports
+the number of runs, success rate, timeout rate, how often the LLM fell back
+to another one, and p50/p95 run duration and output size. Runs are grouped
+by the LLM asked for, so fallbacks count against it. Runs logged before
+persona, step, and command were recorded show as "(none)".
+
+--since and --until take a date (2026-10-01), an RFC 3339 time, or an age
+such as 7d or 12h. A date given to --until includes that whole day.
+
+Formats:
+  table  Aligned text tables, one per dimension (default)
+  json   The full report
+  csv    One row per group, for spreadsheets`,
+	Example: `  vern stats
+  vern stats --since 7d --by persona
+  vern stats --since 2026-10-01 --until 2026-10-15 -f csv -o stats.csv`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+var (
+	statsSince  string
+	statsUntil  string
+	statsBy     []string
+	statsFormat string
+	statsOutput string
+	statsLog    string
+)
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "Only runs at or after this date, time, or age (e.g. 2026-10-01, 7d)")
+	statsCmd.Flags().StringVar(&statsUntil, "until", "", "Only runs before this date, time, or age (a date includes that day)")
+	statsCmd.Flags().StringSliceVar(&statsBy, "by", stats.DefaultDimensions, "Group by (comma-separated: persona, llm, command, step)")
+	statsCmd.Flags().StringVarP(&statsFormat, "format", "f", "table", "Output format (table, json, csv)")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	statsCmd.Flags().StringVar(&statsLog, "log", "", "Run log to read (default: ~/.config/vern/logs/vern.log)")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if statsFormat != "table" && statsFormat != "json" && statsFormat != "csv" {
+		return fmt.Errorf("unknown format %q (valid: table, json, csv)", statsFormat)
+	}
+
+	now := time.Now()
+	var opts stats.Options
+	opts.By = statsBy
+	if statsSince != "" {
+		t, err := stats.ParseTime(statsSince, now, false)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		opts.Since = t
+	}
+	if statsUntil != "" {
+		t, err := stats.ParseTime(statsUntil, now, true)
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+		opts.Until = t
+	}
+
+	logPath := statsLog
+	if logPath == "" {
+		logPath = llm.LogPath()
+	}
+	entries, skipped, err := stats.ReadLog(logPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no run log at %s yet (runs are logged unless VERN_LOG=0)", logPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	report, err := stats.Build(entries, opts)
+	if err != nil {
+		return err
+	}
+	report.Skipped = skipped
+
+	out := os.Stdout
+	if statsOutput != "" {
+		f, err := os.Create(statsOutput)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", statsOutput, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	switch statsFormat {
+	case "csv":
+		err = report.WriteCSV(out)
+	case "json":
+		var data []byte
+		if data, err = report.JSON(); err == nil {
+			_, err = out.Write(data)
+		}
+	default:
+		_, err = fmt.Fprint(out, report.Table())
+	}
+	if err != nil {
+		return fmt.Errorf("write stats: %w", err)
+	}
+	if statsOutput != "" {
+		fmt.Printf("%d runs → %s\n", report.Runs, statsOutput)
+	}
+	return nil
+}
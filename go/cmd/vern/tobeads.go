@@ -13,12 +13,28 @@ import (
 var tobeadsApply bool
 var tobeadsSync bool
 var tobeadsBeadsDir string
+var tobeadsSyncBack bool
+var tobeadsForce bool
 
 var tobeadsCmd = &cobra.Command{
 	Use:   "tobeads <vts-directory>",
 	Short: "Import VTS tasks into Beads",
-	Long:  "One-way importer: reads VTS task files and creates Beads issues via br CLI. Dry-run by default.",
-	Args:  cobra.ExactArgs(1),
+	Long: `Reads VTS task files and creates Beads issues via br CLI. Dry-run by default.
+
+Re-running after the VTS set changes reconciles against the beads recorded
+in vts-br-map.json: the dry-run lists a create/update/close/dep-add/dep-remove
+plan, and --apply executes it. Beads whose task was removed are closed.
+Beads whose title, description, or dependencies were edited in Beads since
+the last sync are reported as conflicts and not updated; --force
+overwrites them with the VTS version.
+
+With --sync-back, compares previously imported tasks against their beads
+using the vts-br-map.json written on import. Title, description and
+dependency edits made in VTS are pushed to Beads; status and assignee
+changes made in Beads are pulled back into the VTS frontmatter. Fields
+edited on both sides since the last sync are reported as conflicts and
+left untouched. Sync state is kept in vts-br-sync.json.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vtsDir := args[0]
 
@@ -65,6 +81,19 @@ var tobeadsCmd = &cobra.Command{
 			BeadsDir: beadsDir,
 			Apply:    tobeadsApply,
 			Sync:     tobeadsSync,
+			Force:    tobeadsForce,
+		}
+
+		if tobeadsSyncBack {
+			result, err := tobeads.SyncBack(opts, runner)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(result.Errors) > 0 || len(result.Conflicts) > 0 {
+				os.Exit(2)
+			}
+			return nil
 		}
 
 		result, err := tobeads.Run(opts, runner)
@@ -74,7 +103,7 @@ var tobeadsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		if result != nil && result.Failed > 0 {
+		if result != nil && (result.Failed > 0 || len(result.Conflicts) > 0) {
 			os.Exit(2)
 		}
 
@@ -102,5 +131,7 @@ func init() {
 	tobeadsCmd.Flags().BoolVar(&tobeadsApply, "apply", false, "Actually create issues (default: dry-run)")
 	tobeadsCmd.Flags().BoolVar(&tobeadsSync, "sync", false, "Run br sync --flush-only after apply")
 	tobeadsCmd.Flags().StringVar(&tobeadsBeadsDir, "beads-dir", "", "Target Beads repo directory (auto-detected from VTS dir if omitted)")
+	tobeadsCmd.Flags().BoolVar(&tobeadsSyncBack, "sync-back", false, "Two-way sync: push VTS edits to Beads, pull status/assignee back into VTS")
+	tobeadsCmd.Flags().BoolVar(&tobeadsForce, "force", false, "Re-import: overwrite title/description/dependency edits made in Beads since the last sync")
 	rootCmd.AddCommand(tobeadsCmd)
 }
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/schedule"
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+	"github.com/spf13/cobra"
+)
+
+var vtsCmd = &cobra.Command{
+	Use:   "vts",
+	Short: "Inspect VTS task sets",
+	Long: `VTS utilities.
+
+Subcommands:
+  diff      Compare two VTS directories (e.g. two discovery runs or oracle revisions)
+  schedule  Estimate effort and build a phased schedule for a team`,
+}
+
+var vtsDiffOutput string
+
+var vtsDiffCmd = &cobra.Command{
+	Use:   "diff <dirA> <dirB>",
+	Short: "Compare two VTS directories",
+	Long: `Compare two VTS task sets and print a Markdown changelog of added, removed,
+retitled, and renumbered tasks, plus changed dependencies, acceptance criteria,
+and complexity.
+
+Tasks are matched by ID; when IDs have shifted (a task was inserted or removed
+above them) they are matched by title similarity instead.
+
+oracle-apply writes the same report automatically as vts-changelog.md.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldTasks, err := vts.ReadDir(args[0])
+		if err != nil {
+			return fmt.Errorf("read %s: %w", args[0], err)
+		}
+		newTasks, err := vts.ReadDir(args[1])
+		if err != nil {
+			return fmt.Errorf("read %s: %w", args[1], err)
+		}
+
+		if vtsDiffOutput != "" {
+			d, err := vts.WriteChangelog(oldTasks, newTasks, vtsDiffOutput, args[0], args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("%d added, %d removed, %d changed → %s\n", len(d.Added), len(d.Removed), len(d.Changed), vtsDiffOutput)
+			return nil
+		}
+
+		fmt.Print(vts.DiffTasks(oldTasks, newTasks).Markdown(args[0], args[1]))
+		return nil
+	},
+}
+
+var (
+	vtsScheduleTeam   int
+	vtsScheduleFormat string
+	vtsScheduleOutput string
+	vtsScheduleEffort string
+)
+
+var vtsScheduleCmd = &cobra.Command{
+	Use:   "schedule <vts-directory>",
+	Short: "Estimate effort and build a phased schedule from VTS tasks",
+	Long: `Turn VTS complexity and dependencies into a schedule.
+
+Each complexity maps to an effort range in days (defaults: XS 0.5-1, S 1-2,
+M 2-4, L 4-8, XL 8-15), configurable under "estimation.effort" in config or
+with --effort. Tasks are grouped into waves whose dependencies are satisfied
+by earlier waves, then critical-path analysis gives earliest/latest start and
+slack, and tasks are assigned across the team to produce a timeline.
+
+Formats:
+  md   Markdown report with per-wave tables and a text timeline (default)
+  csv  One row per task, for spreadsheets`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if vtsScheduleFormat != "md" && vtsScheduleFormat != "csv" {
+			return fmt.Errorf("unknown format %q (valid: md, csv)", vtsScheduleFormat)
+		}
+
+		tasks, err := vts.ReadDir(args[0])
+		if err != nil {
+			return fmt.Errorf("read %s: %w", args[0], err)
+		}
+
+		cfg := resolveOracleConfig()
+		effort := cfg.GetEffortRanges()
+		if vtsScheduleEffort != "" {
+			overrides, err := parseEffortFlag(vtsScheduleEffort)
+			if err != nil {
+				return err
+			}
+			for code, r := range overrides {
+				effort[code] = r
+			}
+		}
+		team := cfg.GetTeamSize()
+		if vtsScheduleTeam > 0 {
+			team = vtsScheduleTeam
+		}
+
+		sched, err := schedule.Build(tasks, schedule.Options{TeamSize: team, Effort: effort})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		out := os.Stdout
+		if vtsScheduleOutput != "" {
+			f, err := os.Create(vtsScheduleOutput)
+			if err != nil {
+				return fmt.Errorf("create %s: %w", vtsScheduleOutput, err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if vtsScheduleFormat == "csv" {
+			err = sched.WriteCSV(out)
+		} else {
+			_, err = fmt.Fprint(out, sched.Markdown())
+		}
+		if err != nil {
+			return fmt.Errorf("write schedule: %w", err)
+		}
+		if vtsScheduleOutput != "" {
+			fmt.Printf("%d tasks, %d waves, %.1f days with %d developers → %s\n",
+				len(sched.Entries), sched.Waves, sched.Duration, sched.TeamSize, vtsScheduleOutput)
+		}
+		return nil
+	},
+}
+
+// parseEffortFlag parses "XS=0.5-1,M=2-5" into complexity -> [min, max] days.
+func parseEffortFlag(val string) (map[string][2]float64, error) {
+	ranges := map[string][2]float64{}
+	for _, part := range strings.Split(val, ",") {
+		code, span, ok := strings.Cut(strings.TrimSpace(part), "=")
+		lo, hi, ok2 := strings.Cut(span, "-")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid --effort entry %q (want CODE=MIN-MAX)", part)
+		}
+		minDays, err1 := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+		maxDays, err2 := strconv.ParseFloat(strings.TrimSpace(hi), 64)
+		if err1 != nil || err2 != nil || minDays < 0 || maxDays < minDays {
+			return nil, fmt.Errorf("invalid --effort range %q", part)
+		}
+		ranges[strings.ToUpper(strings.TrimSpace(code))] = [2]float64{minDays, maxDays}
+	}
+	return ranges, nil
+}
+
+func init() {
+	vtsScheduleCmd.Flags().IntVarP(&vtsScheduleTeam, "team", "t", 0, "Team size (default: estimation.team_size from config, or 1)")
+	vtsScheduleCmd.Flags().StringVarP(&vtsScheduleFormat, "format", "f", "md", "Output format (md, csv)")
+	vtsScheduleCmd.Flags().StringVarP(&vtsScheduleOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	vtsScheduleCmd.Flags().StringVar(&vtsScheduleEffort, "effort", "", "Override effort ranges in days, e.g. \"S=1-3,M=3-5\"")
+	vtsCmd.AddCommand(vtsScheduleCmd)
+	vtsDiffCmd.Flags().StringVarP(&vtsDiffOutput, "output", "o", "", "Write the changelog to a file instead of stdout")
+	vtsCmd.AddCommand(vtsDiffCmd)
+	rootCmd.AddCommand(vtsCmd)
+}
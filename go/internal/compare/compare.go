@@ -0,0 +1,282 @@
+// Package compare runs one prompt across a persona × LLM matrix and reports
+// the answers side by side.
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+)
+
+// Report file names written to the output directory.
+const (
+	MarkdownFile = "compare.md"
+	HTMLFile     = "compare.html"
+	JSONFile     = "compare.json"
+)
+
+// Options configures a comparison run.
+type Options struct {
+	Ctx       context.Context // optional: cancelled on TUI quit
+	Prompt    string
+	Personas  []string // persona IDs; "none" runs the bare LLM
+	LLMs      []string
+	OutputDir string
+	AgentsDir string
+	Timeout   int    // seconds per cell (0: llm.RunOptions' default)
+	JudgeLLM  string // optional: score each answer with this LLM
+	Rubric    []judge.Criterion
+	LogFunc   func(string) // optional callback for progress logs
+}
+
+func (o Options) log(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if o.LogFunc != nil {
+		o.LogFunc(msg)
+	} else {
+		fmt.Println(msg)
+	}
+}
+
+// Cell is one persona × LLM answer.
+type Cell struct {
+	Index      int          `json:"index"` // 1-based, matches the NN- file prefix
+	Persona    string       `json:"persona"`
+	LLM        string       `json:"llm"`      // requested
+	LLMUsed    string       `json:"llm_used"` // after CLI availability fallback
+	OutputFile string       `json:"output_file"`
+	Output     string       `json:"-"`
+	Succeeded  bool         `json:"succeeded"`
+	ExitCode   int          `json:"exit_code"`
+	Error      string       `json:"error,omitempty"`
+	LatencyMS  int64        `json:"latency_ms"`
+	Bytes      int          `json:"bytes"`
+	Words      int          `json:"words"`
+	Lines      int          `json:"lines"`
+	Score      *judge.Score `json:"score,omitempty"`
+	JudgeError string       `json:"judge_error,omitempty"`
+}
+
+// Report is the result of a comparison, written as compare.json.
+type Report struct {
+	Prompt   string            `json:"prompt"`
+	Personas []string          `json:"personas"`
+	LLMs     []string          `json:"llms"`
+	JudgeLLM string            `json:"judge_llm,omitempty"`
+	Rubric   []judge.Criterion `json:"rubric,omitempty"`
+	Started  time.Time         `json:"started"`
+	Cells    []Cell            `json:"cells"`
+}
+
+// Run executes every persona × LLM cell in parallel, optionally has the judge
+// score each answer, and writes the cell outputs plus compare.md,
+// compare.html, and compare.json to the output directory.
+func Run(opts Options) (*Report, error) {
+	if strings.TrimSpace(opts.Prompt) == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+	personas := cleanList(opts.Personas)
+	llms := cleanList(opts.LLMs)
+	if len(personas) == 0 {
+		personas = []string{"none"}
+	}
+	if len(llms) == 0 {
+		llms = []string{"claude"}
+	}
+	if len(personas)*len(llms) < 2 {
+		return nil, fmt.Errorf("nothing to compare: give at least two personas or two LLMs")
+	}
+	for _, id := range personas {
+		if id == "none" {
+			continue
+		}
+		if _, err := persona.Load(opts.AgentsDir, id); errors.Is(err, persona.ErrNotFound) {
+			return nil, fmt.Errorf("unknown persona %q", id)
+		} else if err != nil {
+			return nil, fmt.Errorf("persona %q: %w", id, err)
+		}
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = "."
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+	if opts.JudgeLLM != "" && len(opts.Rubric) == 0 {
+		opts.Rubric = judge.CompareRubric
+	}
+
+	report := &Report{
+		Prompt:   opts.Prompt,
+		Personas: personas,
+		LLMs:     llms,
+		JudgeLLM: opts.JudgeLLM,
+		Started:  time.Now(),
+	}
+	if opts.JudgeLLM != "" {
+		report.Rubric = opts.Rubric
+	}
+	for _, p := range personas {
+		for _, l := range llms {
+			idx := len(report.Cells) + 1
+			report.Cells = append(report.Cells, Cell{
+				Index:      idx,
+				Persona:    p,
+				LLM:        l,
+				OutputFile: filepath.Join(opts.OutputDir, fmt.Sprintf("%02d-%s-%s.md", idx, p, l)),
+			})
+		}
+	}
+
+	opts.log("=== VERN COMPARE ===")
+	opts.log("Personas: %s", strings.Join(personas, ", "))
+	opts.log("LLMs: %s", strings.Join(llms, ", "))
+	opts.log("Output: %s\n", opts.OutputDir)
+
+	var wg sync.WaitGroup
+	for i := range report.Cells {
+		wg.Add(1)
+		go func(c *Cell) {
+			defer wg.Done()
+			runCell(opts, c)
+		}(&report.Cells[i])
+	}
+	wg.Wait()
+
+	if opts.JudgeLLM != "" {
+		opts.log("\n>>> Judging with %s...", opts.JudgeLLM)
+		for i := range report.Cells {
+			if !report.Cells[i].Succeeded {
+				continue
+			}
+			wg.Add(1)
+			go func(c *Cell) {
+				defer wg.Done()
+				judgeCell(opts, c)
+			}(&report.Cells[i])
+		}
+		wg.Wait()
+	}
+
+	if err := report.Write(opts.OutputDir); err != nil {
+		return report, err
+	}
+	opts.log("\nReport: %s", filepath.Join(opts.OutputDir, MarkdownFile))
+	return report, nil
+}
+
+func runCell(opts Options, c *Cell) {
+	personaID := c.Persona
+	if personaID == "none" {
+		personaID = ""
+	}
+	opts.log(">>> %02d %s on %s", c.Index, c.Persona, c.LLM)
+
+	result, err := llm.Run(llm.RunOptions{
+		Ctx:        opts.Ctx,
+		LLM:        c.LLM,
+		Prompt:     opts.Prompt,
+		OutputFile: c.OutputFile,
+		Persona:    personaID,
+		Timeout:    time.Duration(opts.Timeout) * time.Second,
+		AgentsDir:  opts.AgentsDir,
+		Step:       "compare",
+	})
+
+	c.ExitCode = 1
+	if result != nil {
+		c.LLMUsed = result.LLMUsed
+		c.ExitCode = result.ExitCode
+		c.LatencyMS = result.Duration.Milliseconds()
+	}
+	switch {
+	case err == nil && result.ExitCode == 0 && result.Output != "":
+		c.Succeeded = true
+		c.Output = result.Output
+		c.Bytes = len(result.Output)
+		c.Words = len(strings.Fields(result.Output))
+		c.Lines = strings.Count(strings.TrimRight(result.Output, "\n"), "\n") + 1
+		opts.log("    OK (%02d %s on %s, %dB, %s)", c.Index, c.Persona, c.LLMUsed, c.Bytes, formatLatency(c.LatencyMS))
+		return
+	case result != nil && result.Stderr != "":
+		c.Error = llm.FirstLine(result.Stderr)
+	case err != nil:
+		c.Error = err.Error()
+	default:
+		c.Error = "empty output"
+	}
+	opts.log("    FAILED (%02d %s on %s, exit %d): %s", c.Index, c.Persona, c.LLM, c.ExitCode, c.Error)
+}
+
+func judgeCell(opts Options, c *Cell) {
+	score, err := judge.Evaluate(judge.Options{
+		Ctx:    opts.Ctx,
+		LLM:    opts.JudgeLLM,
+		Rubric: opts.Rubric,
+		Task:   opts.Prompt,
+		Output: c.Output,
+	})
+	if err != nil {
+		c.JudgeError = err.Error()
+		opts.log("    Judge failed for %02d: %v", c.Index, err)
+		return
+	}
+	c.Score = score
+	opts.log("    %02d %s on %s: %.1f / 5", c.Index, c.Persona, c.LLMUsed, score.Overall)
+}
+
+// Write saves compare.md, compare.html, and compare.json to dir.
+func (r *Report) Write(dir string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, JSONFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", JSONFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MarkdownFile), []byte(r.Markdown()), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", MarkdownFile, err)
+	}
+	html, err := r.HTML()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, HTMLFile), []byte(html), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", HTMLFile, err)
+	}
+	return nil
+}
+
+// cleanList trims entries and drops blanks and duplicates.
+func cleanList(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+// formatLatency renders milliseconds as e.g. "1.2s" or "2m05s".
+func formatLatency(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
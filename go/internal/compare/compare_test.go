@@ -0,0 +1,91 @@
+package compare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
+)
+
+func sampleReport() *Report {
+	return &Report{
+		Prompt:   "Should we add a cache?",
+		Personas: []string{"great", "mediocre"},
+		LLMs:     []string{"claude", "gemini"},
+		JudgeLLM: "claude",
+		Rubric:   []judge.Criterion{{Name: "relevance"}, {Name: "depth"}},
+		Cells: []Cell{
+			{Index: 1, Persona: "great", LLM: "claude", LLMUsed: "claude", Succeeded: true, Output: "Measure first.", Words: 2, Bytes: 14, LatencyMS: 1200,
+				Score: &judge.Score{Scores: []judge.CriterionScore{{Name: "relevance", Score: 5}, {Name: "depth", Score: 4}}, Overall: 4.5, Rationale: "Grounded."}},
+			{Index: 2, Persona: "great", LLM: "gemini", LLMUsed: "claude", Succeeded: true, Output: "Use Redis <now>.", Words: 3, Bytes: 16, LatencyMS: 65000,
+				Score: &judge.Score{Scores: []judge.CriterionScore{{Name: "relevance", Score: 3}, {Name: "depth", Score: 2}}, Overall: 2.5}},
+			{Index: 3, Persona: "mediocre", LLM: "claude", LLMUsed: "claude", Succeeded: true, Output: "Ship it.", Words: 2, Bytes: 8, LatencyMS: 500},
+			{Index: 4, Persona: "mediocre", LLM: "gemini", ExitCode: 124, Error: "timeout"},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	md := sampleReport().Markdown()
+	for _, want := range []string{
+		"| # | Persona | LLM | Status | Words | Bytes | Latency | relevance | depth | Overall |",
+		"| 01 | great | claude | ok | 2 | 14 | 1.2s | 5 | 4 | 4.5 |",
+		"| 02 | great | gemini→claude | ok | 3 | 16 | 1m05s | 3 | 2 | 2.5 |",
+		"| 04 | mediocre | gemini | failed | 0 | 0 | 0.0s | – | – | – |",
+		"**Top score:** 01 great on claude (4.5 / 5)",
+		"> **Judge:** Grounded.",
+		"_Failed (exit 124): timeout_",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	r := sampleReport()
+	html, err := r.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "Use Redis &lt;now&gt;.") {
+		t.Error("cell output should be HTML-escaped")
+	}
+	if strings.Count(html, `<div class="grid">`) != 2 {
+		t.Error("want one grid row per persona")
+	}
+	if !strings.Contains(html, "grid-template-columns: repeat(2,") {
+		t.Error("want one column per LLM")
+	}
+	rows := r.Rows()
+	if len(rows) != 2 || rows[1][0].Persona != "mediocre" || rows[1][1].LLM != "gemini" {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestRunValidation(t *testing.T) {
+	agents := t.TempDir()
+	os.WriteFile(filepath.Join(agents, "great.md"), []byte("---\nname: great\n---\nBe great."), 0644)
+	os.WriteFile(filepath.Join(agents, "orphan.md"), []byte("---\nname: orphan\nextends: ghost\n---\nAlone."), 0644)
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"no prompt", Options{Personas: []string{"great"}, LLMs: []string{"claude", "gemini"}}, "prompt is required"},
+		{"single cell", Options{Prompt: "x", Personas: []string{"great"}, LLMs: []string{"claude", " claude "}}, "nothing to compare"},
+		{"unknown persona", Options{Prompt: "x", Personas: []string{"great", "nope"}}, `unknown persona "nope"`},
+		{"broken persona", Options{Prompt: "x", Personas: []string{"great", "orphan"}}, `extends unknown persona "ghost"`},
+	}
+	for _, tt := range tests {
+		tt.opts.AgentsDir = agents
+		tt.opts.LogFunc = func(string) {}
+		_, err := Run(tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package compare
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// Markdown renders the summary table followed by each answer.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Vern Compare\n\n")
+	fmt.Fprintf(&b, "**Prompt:** %s\n\n", tableCell(r.Prompt))
+	if r.JudgeLLM != "" {
+		fmt.Fprintf(&b, "**Judge:** %s (scores 1-5, judged blind)\n\n", r.JudgeLLM)
+	}
+
+	b.WriteString("| # | Persona | LLM | Status | Words | Bytes | Latency |")
+	sep := "|---|---------|-----|--------|-------|-------|---------|"
+	if r.JudgeLLM != "" {
+		for _, c := range r.Rubric {
+			fmt.Fprintf(&b, " %s |", c.Name)
+			sep += strings.Repeat("-", len(c.Name)+2) + "|"
+		}
+		b.WriteString(" Overall |")
+		sep += "---------|"
+	}
+	b.WriteString("\n" + sep + "\n")
+	for _, c := range r.Cells {
+		fmt.Fprintf(&b, "| %02d | %s | %s | %s | %d | %d | %s |",
+			c.Index, c.Persona, c.llmLabel(), c.status(), c.Words, c.Bytes, formatLatency(c.LatencyMS))
+		if r.JudgeLLM != "" {
+			for _, crit := range r.Rubric {
+				fmt.Fprintf(&b, " %s |", c.criterionScore(crit.Name))
+			}
+			fmt.Fprintf(&b, " %s |", c.overall())
+		}
+		b.WriteString("\n")
+	}
+	if best := r.Best(); best != nil {
+		fmt.Fprintf(&b, "\n**Top score:** %02d %s on %s (%s / 5)\n", best.Index, best.Persona, best.llmLabel(), best.overall())
+	}
+
+	for _, c := range r.Cells {
+		fmt.Fprintf(&b, "\n---\n\n## %02d. %s on %s\n\n", c.Index, c.Persona, c.llmLabel())
+		if c.Score != nil && c.Score.Rationale != "" {
+			fmt.Fprintf(&b, "> **Judge:** %s\n\n", c.Score.Rationale)
+		}
+		if !c.Succeeded {
+			fmt.Fprintf(&b, "_Failed (exit %d): %s_\n", c.ExitCode, c.Error)
+			continue
+		}
+		b.WriteString(strings.TrimSpace(c.Output))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Best returns the highest-scoring judged cell, or nil if nothing was judged.
+func (r *Report) Best() *Cell {
+	var best *Cell
+	for i := range r.Cells {
+		c := &r.Cells[i]
+		if c.Score != nil && (best == nil || c.Score.Overall > best.Score.Overall) {
+			best = c
+		}
+	}
+	return best
+}
+
+// HTML renders the answers in side-by-side columns, one row per persona.
+func (r *Report) HTML() (string, error) {
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, r); err != nil {
+		return "", fmt.Errorf("render %s: %w", HTMLFile, err)
+	}
+	return b.String(), nil
+}
+
+// Rows groups cells by persona so each HTML row compares the LLMs.
+func (r *Report) Rows() [][]Cell {
+	rows := make([][]Cell, 0, len(r.Personas))
+	for i := range r.Personas {
+		start := i * len(r.LLMs)
+		rows = append(rows, r.Cells[start:start+len(r.LLMs)])
+	}
+	return rows
+}
+
+func (c Cell) llmLabel() string {
+	if c.LLMUsed != "" && c.LLMUsed != c.LLM {
+		return c.LLM + "→" + c.LLMUsed
+	}
+	return c.LLM
+}
+
+func (c Cell) status() string {
+	if c.Succeeded {
+		return "ok"
+	}
+	return "failed"
+}
+
+func (c Cell) criterionScore(name string) string {
+	if c.Score == nil {
+		return "–"
+	}
+	return strconv.Itoa(c.Score.Get(name))
+}
+
+func (c Cell) overall() string {
+	if c.Score == nil {
+		return "–"
+	}
+	return strconv.FormatFloat(c.Score.Overall, 'f', 1, 64)
+}
+
+// tableCell flattens a value onto one Markdown line.
+func tableCell(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", " ")
+}
+
+var htmlTemplate = template.Must(template.New(HTMLFile).Funcs(template.FuncMap{
+	"label":   Cell.llmLabel,
+	"status":  Cell.status,
+	"latency": formatLatency,
+	"score":   Cell.criterionScore,
+	"overall": Cell.overall,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Vern Compare</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border: 1px solid #ccc; padding: 0.3rem 0.6rem; text-align: left; }
+th { background: #f4f4f4; }
+.grid { display: grid; grid-template-columns: repeat({{len .LLMs}}, minmax(0, 1fr)); gap: 1rem; margin-bottom: 2rem; }
+.cell { border: 1px solid #ccc; border-radius: 6px; padding: 0.8rem; overflow-x: auto; }
+.cell h3 { margin-top: 0; }
+.meta { color: #666; font-size: 0.85rem; }
+.failed { background: #fff0f0; }
+pre { white-space: pre-wrap; word-wrap: break-word; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<h1>Vern Compare</h1>
+<p><strong>Prompt:</strong> {{.Prompt}}</p>
+{{- if .JudgeLLM}}
+<p><strong>Judge:</strong> {{.JudgeLLM}} (scores 1-5, judged blind)</p>
+{{- end}}
+<table>
+<tr><th>#</th><th>Persona</th><th>LLM</th><th>Status</th><th>Words</th><th>Bytes</th><th>Latency</th>
+{{- if .JudgeLLM}}{{range .Rubric}}<th>{{.Name}}</th>{{end}}<th>Overall</th>{{end}}</tr>
+{{- $r := .}}
+{{- range .Cells}}
+<tr><td>{{printf "%02d" .Index}}</td><td>{{.Persona}}</td><td>{{label .}}</td><td>{{status .}}</td><td>{{.Words}}</td><td>{{.Bytes}}</td><td>{{latency .LatencyMS}}</td>
+{{- $c := .}}{{if $r.JudgeLLM}}{{range $r.Rubric}}<td>{{score $c .Name}}</td>{{end}}<td>{{overall $c}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- range .Rows}}
+<div class="grid">
+{{- range .}}
+<div class="cell{{if not .Succeeded}} failed{{end}}">
+<h3>{{printf "%02d" .Index}}. {{.Persona}} on {{label .}}</h3>
+<div class="meta">{{.Words}} words · {{.Bytes}} bytes · {{latency .LatencyMS}}{{if .Score}} · score {{overall .}} / 5{{end}}</div>
+{{- if .Score}}{{if .Score.Rationale}}
+<p class="meta"><em>Judge: {{.Score.Rationale}}</em></p>
+{{- end}}{{end}}
+{{- if .Succeeded}}
+<pre>{{.Output}}</pre>
+{{- else}}
+<p><em>Failed (exit {{.ExitCode}}): {{.Error}}</em></p>
+{{- end}}
+</div>
+{{- end}}
+</div>
+{{- end}}
+</body>
+</html>
+`))
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/jdonohoo/vern-bot/go/internal/embedded"
 )
@@ -21,6 +22,13 @@ type Config struct {
 	LLMModes       map[string]LLMModeConfig    `json:"llm_modes"`
 	VernHole       VernHoleConfig              `json:"vernhole"`
 	Timeouts       TimeoutConfig               `json:"timeouts"`
+	Estimation     EstimationConfig            `json:"estimation,omitempty"`
+	Evaluation     EvaluationConfig            `json:"evaluation,omitempty"`
+	Wrappers       WrapperConfig               `json:"wrappers,omitempty"`
+
+	// PersonaDirs are extra persona directories, searched after the project's
+	// .vern/agents/ and before ~/.config/vern/agents/ (first listed wins).
+	PersonaDirs []string `json:"persona_dirs,omitempty"`
 
 	// User preferences (persisted across sessions)
 	DefaultDiscoveryPath string               `json:"default_discovery_path,omitempty"`
@@ -58,10 +66,65 @@ type TimeoutConfig struct {
 	OracleApply  int `json:"oracle_apply"`  // architect applying oracle vision
 }
 
+// EstimationConfig holds settings for vern vts schedule.
+type EstimationConfig struct {
+	TeamSize int                   `json:"team_size,omitempty"`
+	Effort   map[string][2]float64 `json:"effort,omitempty"` // complexity -> [min, max] days
+}
+
+// EvaluationConfig holds settings for the optional judge pass that scores
+// pipeline step outputs, the VernHole synthesis, and the VTS set.
+type EvaluationConfig struct {
+	Enabled   bool              `json:"enabled,omitempty"`
+	LLM       string            `json:"llm,omitempty"`        // judge LLM (default: synthesis LLM)
+	Rubric    []RubricCriterion `json:"rubric,omitempty"`     // default: completeness, actionability, consistency
+	Threshold float64           `json:"threshold,omitempty"`  // re-run steps scoring below this (1-5; 0 = never)
+	MaxReruns int               `json:"max_reruns,omitempty"` // re-runs per step (default: 1)
+}
+
+// WrapperConfig chooses the preamble and postamble llm.Run wraps around
+// every prompt, by template name ("none" omits it). An explicit choice for
+// the call (such as vern run --postamble) beats step choices, which beat
+// a step's built-in default, which beats backend choices, which beat the
+// global ones.
+type WrapperConfig struct {
+	Preamble  string                   `json:"preamble,omitempty"`  // default: the backend's (text-only, or planning for codex)
+	Postamble string                   `json:"postamble,omitempty"` // default: sign-off
+	Backends  map[string]WrapperChoice `json:"backends,omitempty"`  // LLM name -> choice
+	Steps     map[string]WrapperChoice `json:"steps,omitempty"`     // llm.RunOptions.Step -> choice
+	Templates map[string]string        `json:"templates,omitempty"` // custom templates by name, used verbatim
+}
+
+// WrapperChoice names a preamble and postamble template; empty keeps the
+// next choice down.
+type WrapperChoice struct {
+	Preamble  string `json:"preamble,omitempty"`
+	Postamble string `json:"postamble,omitempty"`
+}
+
+// RubricCriterion is one dimension the judge scores from 1 to 5.
+type RubricCriterion struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // VernHoleConfig holds VernHole-specific settings.
 type VernHoleConfig struct {
-	DefaultCouncil string `json:"default_council"`
-	Min            int    `json:"min"`
+	DefaultCouncil string                   `json:"default_council"`
+	Min            int                      `json:"min"`
+	Councils       map[string]CouncilConfig `json:"councils,omitempty"` // custom councils by name
+}
+
+// CouncilConfig declares a custom VernHole council. Project councils in
+// .vern/councils/*.json use the same shape, named after the file.
+type CouncilConfig struct {
+	Display string            `json:"display"`
+	Core    []string          `json:"core,omitempty"`    // always summoned
+	Min     int               `json:"min,omitempty"`     // total council size range;
+	Max     int               `json:"max,omitempty"`     // slots beyond core are filled randomly
+	Fixed   bool              `json:"fixed,omitempty"`   // exactly the core members
+	Exclude []string          `json:"exclude,omitempty"` // never summoned, even as fill
+	LLMs    map[string]string `json:"llms,omitempty"`    // persona ID -> LLM override
 }
 
 // Load reads configuration using the 4-tier chain:
@@ -262,6 +325,55 @@ func (c *Config) GetOracleApplyTimeout() int {
 	return 1200
 }
 
+// DefaultEffortRanges maps VTS complexity codes to effort ranges in days.
+func DefaultEffortRanges() map[string][2]float64 {
+	return map[string][2]float64{
+		"XS": {0.5, 1},
+		"S":  {1, 2},
+		"M":  {2, 4},
+		"L":  {4, 8},
+		"XL": {8, 15},
+	}
+}
+
+// GetEffortRanges returns the default effort ranges overlaid with any
+// configured ones (keys are matched case-insensitively).
+func (c *Config) GetEffortRanges() map[string][2]float64 {
+	ranges := DefaultEffortRanges()
+	for code, r := range c.Estimation.Effort {
+		ranges[strings.ToUpper(code)] = r
+	}
+	return ranges
+}
+
+// GetTeamSize returns the number of developers to schedule VTS tasks across.
+func (c *Config) GetTeamSize() int {
+	if c.Estimation.TeamSize > 0 {
+		return c.Estimation.TeamSize
+	}
+	return 1
+}
+
+// GetEvaluationLLM returns the judge LLM: the single_llm override if active,
+// then evaluation.llm, then the synthesis LLM.
+func (c *Config) GetEvaluationLLM() string {
+	if override := c.GetOverrideLLM(); override != "" {
+		return override
+	}
+	if c.Evaluation.LLM != "" {
+		return c.Evaluation.LLM
+	}
+	return c.GetSynthesisLLM()
+}
+
+// GetMaxReruns returns how many times a low-scoring step may be re-run.
+func (c *Config) GetMaxReruns() int {
+	if c.Evaluation.MaxReruns > 0 {
+		return c.Evaluation.MaxReruns
+	}
+	return 1
+}
+
 func (c *Config) getActiveMode() *LLMModeConfig {
 	if c.LLMMode == "" || c.LLMModes == nil {
 		return nil
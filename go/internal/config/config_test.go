@@ -130,3 +130,57 @@ func TestLoadWithProjectRoot(t *testing.T) {
 		t.Errorf("expected at least 3 default steps, got %d", len(defaultSteps))
 	}
 }
+
+func TestEstimationConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	os.WriteFile(path, []byte(`{"estimation": {"team_size": 3, "effort": {"m": [3, 5]}}}`), 0644)
+
+	cfg, err := loadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetTeamSize() != 3 {
+		t.Errorf("team size: got %d, want 3", cfg.GetTeamSize())
+	}
+	ranges := cfg.GetEffortRanges()
+	if ranges["M"] != [2]float64{3, 5} {
+		t.Errorf("M range: got %v, want [3 5]", ranges["M"])
+	}
+	if ranges["XL"] != [2]float64{8, 15} {
+		t.Errorf("XL range should keep default, got %v", ranges["XL"])
+	}
+
+	if hardcodedDefaults().GetTeamSize() != 1 {
+		t.Error("default team size should be 1")
+	}
+}
+
+func TestEvaluationConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	os.WriteFile(path, []byte(`{"evaluation": {"enabled": true, "llm": "gemini", "threshold": 3.5, "rubric": [{"name": "novelty", "description": "Not obvious"}]}}`), 0644)
+
+	cfg, err := loadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Evaluation.Enabled || cfg.Evaluation.Threshold != 3.5 || len(cfg.Evaluation.Rubric) != 1 {
+		t.Errorf("evaluation = %+v", cfg.Evaluation)
+	}
+	if cfg.GetEvaluationLLM() != "gemini" {
+		t.Errorf("evaluation LLM: got %s, want gemini", cfg.GetEvaluationLLM())
+	}
+	if cfg.GetMaxReruns() != 1 {
+		t.Errorf("max reruns: got %d, want default 1", cfg.GetMaxReruns())
+	}
+
+	// single_llm wins over the configured judge
+	cfg.LLMMode = "single_llm"
+	mode := cfg.LLMModes["single_llm"]
+	mode.OverrideLLM = "codex"
+	cfg.LLMModes["single_llm"] = mode
+	if cfg.GetEvaluationLLM() != "codex" {
+		t.Errorf("evaluation LLM under single_llm: got %s, want codex", cfg.GetEvaluationLLM())
+	}
+}
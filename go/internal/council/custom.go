@@ -0,0 +1,185 @@
+package council
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+)
+
+// ProjectCouncilDir holds per-project council definitions, one JSON file per
+// council, named after the file (e.g. .vern/councils/security.json -> "security").
+var ProjectCouncilDir = filepath.Join(".vern", "councils")
+
+// UserCouncilDir returns the per-user council directory,
+// ~/.config/vern/councils. Project councils override it.
+func UserCouncilDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "vern", "councils")
+}
+
+// TierFromConfig converts a declared council into a Tier. A council with core
+// members and no size range is fixed; a min without a max is an exact size.
+func TierFromConfig(name string, c config.CouncilConfig) Tier {
+	t := Tier{
+		Name:    name,
+		Display: c.Display,
+		Core:    c.Core,
+		MinFill: c.Min,
+		MaxFill: c.Max,
+		Fixed:   c.Fixed,
+		Exclude: c.Exclude,
+		LLMs:    c.LLMs,
+	}
+	if t.Display == "" {
+		t.Display = name
+	}
+	if !t.Fixed && len(t.Core) > 0 && t.MinFill == 0 && t.MaxFill == 0 {
+		t.Fixed = true
+	}
+	if t.MaxFill == 0 {
+		t.MaxFill = t.MinFill
+	}
+	return t
+}
+
+// ConfigFromTier converts a Tier back into the form it's declared in, the
+// inverse of TierFromConfig.
+func ConfigFromTier(t Tier) config.CouncilConfig {
+	c := config.CouncilConfig{
+		Display: t.Display,
+		Core:    t.Core,
+		Fixed:   t.Fixed,
+		Exclude: t.Exclude,
+		LLMs:    t.LLMs,
+	}
+	if !t.Fixed {
+		c.Min, c.Max = t.MinFill, t.MaxFill
+	}
+	return c
+}
+
+// Validate reports inconsistent council definitions.
+func (t Tier) Validate() error {
+	if t.MinFill < 0 || t.MaxFill < 0 {
+		return fmt.Errorf("council %q: min/max cannot be negative", t.Name)
+	}
+	if t.MaxFill < t.MinFill {
+		return fmt.Errorf("council %q: max (%d) is less than min (%d)", t.Name, t.MaxFill, t.MinFill)
+	}
+	if t.Fixed && (t.MinFill > 0 || t.MaxFill > 0) {
+		return fmt.Errorf("council %q: fixed councils take no min/max range", t.Name)
+	}
+	excluded := map[string]bool{}
+	for _, id := range t.Exclude {
+		excluded[id] = true
+	}
+	for _, id := range t.Core {
+		if excluded[id] {
+			return fmt.Errorf("council %q: %s is both a core member and excluded", t.Name, id)
+		}
+	}
+	return nil
+}
+
+// LoadTierFile reads a single council definition from a JSON file.
+func LoadTierFile(path string) (Tier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Tier{}, err
+	}
+	var c config.CouncilConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Tier{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	t := TierFromConfig(name, c)
+	t.Source = path
+	if err := t.Validate(); err != nil {
+		return Tier{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// LoadTiers returns the built-in tiers overlaid with councils declared in
+// config, then with *.json files from each dir (later sources win on name
+// clashes). Missing dirs are skipped; invalid definitions are errors.
+func LoadTiers(declared map[string]config.CouncilConfig, dirs ...string) (map[string]Tier, error) {
+	tiers := AllTiers()
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := TierFromConfig(name, declared[name])
+		t.Source = "config"
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+		tiers[name] = t
+	}
+
+	for _, dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		sort.Strings(files)
+		for _, f := range files {
+			t, err := LoadTierFile(f)
+			if err != nil {
+				return nil, err
+			}
+			tiers[t.Name] = t
+		}
+	}
+	return tiers, nil
+}
+
+// TierNames lists tier names with the built-ins first, in menu order,
+// followed by custom councils alphabetically.
+func TierNames(tiers map[string]Tier) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range builtinOrder {
+		if _, ok := tiers[name]; ok {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	var custom []string
+	for name := range tiers {
+		if !seen[name] {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	return append(names, custom...)
+}
+
+// CouncilsFor lists the councils that always summon id, in TierNames order:
+// those with id as a core member, and fixed councils with no core list
+// (which take the whole roster) that don't exclude it.
+func CouncilsFor(id string, tiers map[string]Tier) []string {
+	var out []string
+	for _, name := range TierNames(tiers) {
+		t := tiers[name]
+		member := t.Fixed && len(t.Core) == 0
+		for _, c := range t.Core {
+			if c == id {
+				member = true
+			}
+		}
+		for _, x := range t.Exclude {
+			if x == id {
+				member = false
+			}
+		}
+		if member {
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -3,13 +3,9 @@ package council
 import (
 	"fmt"
 	"math/rand"
-	"os"
-	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/jdonohoo/vern-bot/go/internal/embedded"
 	"github.com/jdonohoo/vern-bot/go/internal/persona"
 )
 
@@ -19,82 +15,36 @@ type Vern struct {
 	Name string // display name (e.g. "Ketamine Vern")
 	LLM  string
 	Desc string // tagline (e.g. "Good vibes only")
+
+	// Description is the full persona description, used by smart selection.
+	Description string
 }
 
-// ScanRoster builds the roster by scanning agents/*.md files.
-// Falls back to embedded agent data if the directory isn't available.
-// Skips vernhole-orchestrator and oracle (pipeline-only personas).
+// ScanRoster builds the roster from every persona on the search path
+// (project, configured, and user persona dirs, then agents/*.md) merged with
+// the embedded agents; see persona.List for precedence. Personas are
+// resolved, so one that extends another inherits its description and model.
+// Personas whose extends chain is broken are left out, as are the
+// pipeline-only personas in persona.PipelineOnly.
 func ScanRoster(agentsDir string) []Vern {
-	skip := map[string]bool{
-		"vernhole-orchestrator": true,
-		"oracle":               true,
-		"historian":            true,
-	}
-
-	entries, err := os.ReadDir(agentsDir)
-	if err != nil {
-		// No agents dir on disk — use embedded data
-		return scanEmbeddedRoster(skip)
-	}
-
 	var roster []Vern
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+	for _, e := range persona.List(agentsDir) {
+		if persona.PipelineOnly[e.ID] {
 			continue
 		}
-		id := strings.TrimSuffix(entry.Name(), ".md")
-		if skip[id] {
-			continue
-		}
-
-		p, err := persona.LoadFile(filepath.Join(agentsDir, entry.Name()))
+		p, err := persona.Load(agentsDir, e.ID)
 		if err != nil {
 			continue
 		}
-
-		llm := persona.ModelToLLM(p.Model)
-		name := persona.DisplayName(p.Description)
-		desc := persona.ShortDescription(p.Description)
-
 		roster = append(roster, Vern{
-			ID:   id,
-			Name: name,
-			LLM:  llm,
-			Desc: desc,
+			ID:          e.ID,
+			Name:        persona.DisplayName(p.Description),
+			LLM:         persona.ModelToLLM(p.Model),
+			Desc:        persona.ShortDescription(p.Description),
+			Description: p.Description,
 		})
 	}
 
-	sort.Slice(roster, func(i, j int) bool { return roster[i].ID < roster[j].ID })
-
-	if len(roster) == 0 {
-		return scanEmbeddedRoster(skip)
-	}
-	return roster
-}
-
-// scanEmbeddedRoster builds the roster from compiled-in agent data.
-func scanEmbeddedRoster(skip map[string]bool) []Vern {
-	names := embedded.ListAgents()
-	var roster []Vern
-
-	for _, name := range names {
-		if skip[name] {
-			continue
-		}
-		content, ok := embedded.GetAgent(name)
-		if !ok {
-			continue
-		}
-		p, err := persona.ParseString(content)
-		if err != nil {
-			continue
-		}
-		llm := persona.ModelToLLM(p.Model)
-		displayName := persona.DisplayName(p.Description)
-		desc := persona.ShortDescription(p.Description)
-		roster = append(roster, Vern{ID: name, Name: displayName, LLM: llm, Desc: desc})
-	}
-
 	if len(roster) == 0 {
 		return hardcodedRoster()
 	}
@@ -102,69 +52,104 @@ func scanEmbeddedRoster(skip map[string]bool) []Vern {
 }
 
 // ResolveCouncil selects Verns based on a council tier name (or bare number).
+// tiers is the set of known councils (nil means the built-ins; see LoadTiers).
+// All random choices draw from rng, so a seeded rng reproduces the council;
+// nil uses an unseeded source.
 // Returns the selected Verns and the council display name.
-func ResolveCouncil(tierName string, roster []Vern, minVerns int) (selected []Vern, displayName string) {
+func ResolveCouncil(tierName string, tiers map[string]Tier, roster []Vern, minVerns int, rng *rand.Rand) (selected []Vern, displayName string, err error) {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
 	if minVerns <= 0 {
 		minVerns = 3
 	}
-
-	tiers := AllTiers()
+	if tiers == nil {
+		tiers = AllTiers()
+	}
+	if tierName == "" {
+		tierName = "random"
+	}
 
 	// Handle bare number
 	if n, err := strconv.Atoi(tierName); err == nil {
 		count := clamp(n, minVerns, len(roster))
-		return randomSelect(roster, count), ""
+		return randomSelect(rng, roster, count), "", nil
 	}
 
 	tier, ok := tiers[tierName]
 	if !ok {
-		fmt.Fprintf(os.Stderr, "Unknown council: %s\nValid councils: hammers, conflict, inner, round, war, full, random\n", tierName)
-		os.Exit(1)
+		return nil, "", fmt.Errorf("unknown council: %s (valid: %s)", tierName, strings.Join(TierNames(tiers), ", "))
+	}
+	if tier.Smart {
+		return nil, "", fmt.Errorf("council %s picks Verns from the idea; use SelectSmart", tierName)
 	}
 
-	displayName = tier.Display
+	selected = selectTier(rng, tier, roster, minVerns)
+	if len(selected) == 0 {
+		return nil, "", fmt.Errorf("council %s: none of its members are in the roster", tierName)
+	}
+	for i, v := range selected {
+		if llm, ok := tier.LLMs[v.ID]; ok && llm != "" {
+			selected[i].LLM = llm
+		}
+	}
+	return selected, tier.Display, nil
+}
 
-	switch tierName {
-	case "full":
-		return append([]Vern{}, roster...), displayName
+// selectTier picks a tier's members from the roster: every available Vern
+// for a fixed tier with no core ("full"), exactly the core for other fixed
+// tiers, and otherwise the core plus a random fill up to a random size in
+// [MinFill, MaxFill] — or [minVerns, roster size] when no range is set
+// ("random").
+func selectTier(rng *rand.Rand, tier Tier, roster []Vern, minVerns int) []Vern {
+	excluded := make(map[string]bool)
+	for _, id := range tier.Exclude {
+		excluded[id] = true
+	}
+	pool := filterOut(roster, excluded)
 
-	case "random", "":
-		count := rand.Intn(len(roster)-minVerns+1) + minVerns
-		return randomSelect(roster, count), displayName
+	if tier.Fixed && len(tier.Core) == 0 {
+		return append([]Vern{}, pool...)
 	}
 
 	// Build from core members
 	var coreVerns []Vern
 	coreSet := make(map[string]bool)
 	for _, id := range tier.Core {
-		if v, ok := findVern(roster, id); ok {
+		if v, ok := FindVern(pool, id); ok {
 			coreVerns = append(coreVerns, v)
 			coreSet[id] = true
 		}
 	}
 
 	if tier.Fixed {
-		return coreVerns, displayName
+		return coreVerns
 	}
 
 	// Fill remaining slots randomly
-	target := rand.Intn(tier.MaxFill-tier.MinFill+1) + tier.MinFill
+	lo, hi := tier.MinFill, tier.MaxFill
+	if lo == 0 && hi == 0 {
+		lo, hi = minVerns, len(pool)
+	}
+	hi = min(hi, len(pool))
+	lo = min(lo, hi)
+	target := rng.Intn(hi-lo+1) + lo
 	if target <= len(coreVerns) {
-		return coreVerns, displayName
+		return coreVerns
 	}
 
-	remaining := filterOut(roster, coreSet)
-	shuffled := randomSelect(remaining, len(remaining))
+	remaining := filterOut(pool, coreSet)
+	shuffled := randomSelect(rng, remaining, len(remaining))
 	fillCount := target - len(coreVerns)
 	if fillCount > len(shuffled) {
 		fillCount = len(shuffled)
 	}
 
-	selected = append(coreVerns, shuffled[:fillCount]...)
-	return selected, displayName
+	return append(coreVerns, shuffled[:fillCount]...)
 }
 
-func findVern(roster []Vern, id string) (Vern, bool) {
+// FindVern looks up a Vern by persona ID.
+func FindVern(roster []Vern, id string) (Vern, bool) {
 	for _, v := range roster {
 		if v.ID == id {
 			return v, true
@@ -183,14 +168,14 @@ func filterOut(roster []Vern, exclude map[string]bool) []Vern {
 	return result
 }
 
-func randomSelect(roster []Vern, count int) []Vern {
+func randomSelect(rng *rand.Rand, roster []Vern, count int) []Vern {
 	if count >= len(roster) {
 		shuffled := make([]Vern, len(roster))
 		copy(shuffled, roster)
-		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
 		return shuffled
 	}
-	indices := rand.Perm(len(roster))[:count]
+	indices := rng.Perm(len(roster))[:count]
 	var result []Vern
 	for _, i := range indices {
 		result = append(result, roster[i])
@@ -1,15 +1,30 @@
 package council
 
 import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
 )
 
 func testRoster() []Vern {
 	return hardcodedRoster()
 }
 
+// testRNG returns a fixed-seed source so tier fills are deterministic.
+func testRNG() *rand.Rand {
+	return rand.New(rand.NewSource(42))
+}
+
 func TestResolveCouncilHammers(t *testing.T) {
-	selected, name := ResolveCouncil("hammers", testRoster(), 3)
+	selected, name, err := ResolveCouncil("hammers", nil, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "Council of the Three Hammers" {
 		t.Errorf("name: got %q", name)
 	}
@@ -28,7 +43,10 @@ func TestResolveCouncilHammers(t *testing.T) {
 }
 
 func TestResolveCouncilConflict(t *testing.T) {
-	selected, name := ResolveCouncil("conflict", testRoster(), 3)
+	selected, name, err := ResolveCouncil("conflict", nil, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "Max Conflict" {
 		t.Errorf("name: got %q", name)
 	}
@@ -39,7 +57,10 @@ func TestResolveCouncilConflict(t *testing.T) {
 
 func TestResolveCouncilFull(t *testing.T) {
 	roster := testRoster()
-	selected, name := ResolveCouncil("full", roster, 3)
+	selected, name, err := ResolveCouncil("full", nil, roster, 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "The Full Vern Experience" {
 		t.Errorf("name: got %q", name)
 	}
@@ -50,7 +71,10 @@ func TestResolveCouncilFull(t *testing.T) {
 
 func TestResolveCouncilRandom(t *testing.T) {
 	roster := testRoster()
-	selected, name := ResolveCouncil("random", roster, 3)
+	selected, name, err := ResolveCouncil("random", nil, roster, 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "Fate's Hand" {
 		t.Errorf("name: got %q", name)
 	}
@@ -60,7 +84,10 @@ func TestResolveCouncilRandom(t *testing.T) {
 }
 
 func TestResolveCouncilInner(t *testing.T) {
-	selected, name := ResolveCouncil("inner", testRoster(), 3)
+	selected, name, err := ResolveCouncil("inner", nil, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "The Inner Circle" {
 		t.Errorf("name: got %q", name)
 	}
@@ -80,7 +107,10 @@ func TestResolveCouncilInner(t *testing.T) {
 }
 
 func TestResolveCouncilBareNumber(t *testing.T) {
-	selected, name := ResolveCouncil("5", testRoster(), 3)
+	selected, name, err := ResolveCouncil("5", nil, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if name != "" {
 		t.Errorf("name should be empty for bare number, got %q", name)
 	}
@@ -91,7 +121,10 @@ func TestResolveCouncilBareNumber(t *testing.T) {
 
 func TestResolveCouncilBareNumberClamped(t *testing.T) {
 	// Too low: should clamp to min
-	selected, _ := ResolveCouncil("1", testRoster(), 3)
+	selected, _, err := ResolveCouncil("1", nil, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if len(selected) != 3 {
 		t.Errorf("count: got %d, want 3 (clamped)", len(selected))
 	}
@@ -110,3 +143,178 @@ func TestScanRoster(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveCouncilUnknown(t *testing.T) {
+	_, _, err := ResolveCouncil("nope", nil, testRoster(), 3, testRNG())
+	if err == nil || !strings.Contains(err.Error(), "unknown council: nope") || !strings.Contains(err.Error(), "hammers") {
+		t.Errorf("expected unknown council error listing valid tiers, got %v", err)
+	}
+}
+
+func TestResolveCouncilCustom(t *testing.T) {
+	tiers, err := LoadTiers(map[string]config.CouncilConfig{
+		"security": {
+			Display: "Security Review",
+			Core:    []string{"paranoid", "enterprise"},
+			Min:     4,
+			Max:     5,
+			Exclude: []string{"yolo", "optimist"},
+			LLMs:    map[string]string{"paranoid": "codex"},
+		},
+		"duo": {Core: []string{"startup", "retro"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := testRNG()
+	for i := 0; i < 20; i++ {
+		selected, name, err := ResolveCouncil("security", tiers, testRoster(), 3, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "Security Review" {
+			t.Errorf("name: got %q", name)
+		}
+		if len(selected) < 4 || len(selected) > 5 {
+			t.Fatalf("count: got %d, want 4-5", len(selected))
+		}
+		if selected[0].ID != "paranoid" || selected[0].LLM != "codex" || selected[1].ID != "enterprise" {
+			t.Errorf("core: got %s (%s), %s", selected[0].ID, selected[0].LLM, selected[1].ID)
+		}
+		for _, v := range selected {
+			if v.ID == "yolo" || v.ID == "optimist" {
+				t.Errorf("excluded persona %s was summoned", v.ID)
+			}
+		}
+	}
+
+	selected, name, err := ResolveCouncil("duo", tiers, testRoster(), 3, testRNG())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "duo" || len(selected) != 2 {
+		t.Errorf("duo: got %q with %d members, want fixed pair", name, len(selected))
+	}
+}
+
+func TestLoadTiers(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "frontend.json"), []byte(`{"display": "Frontend Crew", "core": ["ux", "startup"], "min": 3, "max": 4}`), 0644)
+	os.WriteFile(filepath.Join(dir, "hammers.json"), []byte(`{"display": "Custom Hammers", "core": ["great"], "fixed": true}`), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`ignored`), 0644)
+
+	tiers, err := LoadTiers(nil, dir, filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fe, ok := tiers["frontend"]
+	if !ok || fe.Display != "Frontend Crew" || fe.MinFill != 3 || fe.MaxFill != 4 || fe.Source != filepath.Join(dir, "frontend.json") {
+		t.Errorf("frontend = %+v", fe)
+	}
+	if tiers["hammers"].Display != "Custom Hammers" {
+		t.Errorf("project file should override built-in tier, got %q", tiers["hammers"].Display)
+	}
+	if _, ok := tiers["war"]; !ok {
+		t.Error("built-in tiers should still be present")
+	}
+
+	names := TierNames(tiers)
+	if names[0] != "full" || names[len(names)-1] != "frontend" {
+		t.Errorf("names = %v", names)
+	}
+}
+
+func TestConfigFromTier(t *testing.T) {
+	for _, c := range []config.CouncilConfig{
+		{Display: "Frontend", Core: []string{"ux"}, Min: 3, Max: 4, LLMs: map[string]string{"ux": "gemini"}},
+		{Display: "Fixed", Core: []string{"great", "mighty"}},
+		{Display: "Sized", Min: 5, Exclude: []string{"yolo"}},
+	} {
+		want := TierFromConfig("x", c)
+		got := TierFromConfig("x", ConfigFromTier(want))
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("round trip of %+v: got %+v, want %+v", c, got, want)
+		}
+	}
+}
+
+func TestLoadTiersInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		council config.CouncilConfig
+		want    string
+	}{
+		{"range", config.CouncilConfig{Min: 5, Max: 3}, "max (3) is less than min (5)"},
+		{"fixed range", config.CouncilConfig{Core: []string{"ux"}, Fixed: true, Min: 2}, "fixed councils take no min/max"},
+		{"core excluded", config.CouncilConfig{Core: []string{"ux"}, Exclude: []string{"ux"}}, "both a core member and excluded"},
+	}
+	for _, tt := range tests {
+		_, err := LoadTiers(map[string]config.CouncilConfig{"bad": tt.council})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{not json`), 0644)
+	if _, err := LoadTiers(nil, dir); err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("expected parse error naming the file, got %v", err)
+	}
+}
+
+func TestResolveCouncilSeeded(t *testing.T) {
+	ids := func(vs []Vern) string {
+		var out []string
+		for _, v := range vs {
+			out = append(out, v.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	for _, tier := range []string{"random", "inner", "war", "7"} {
+		first, _, err := ResolveCouncil(tier, nil, testRoster(), 3, rand.New(rand.NewSource(1234)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 5; i++ {
+			again, _, _ := ResolveCouncil(tier, nil, testRoster(), 3, rand.New(rand.NewSource(1234)))
+			if ids(again) != ids(first) {
+				t.Errorf("%s: seed 1234 gave %s, then %s", tier, ids(first), ids(again))
+			}
+		}
+	}
+
+	// Different seeds should not all collapse onto one selection
+	seen := map[string]bool{}
+	for seed := int64(1); seed <= 10; seed++ {
+		selected, _, _ := ResolveCouncil("random", nil, testRoster(), 3, rand.New(rand.NewSource(seed)))
+		seen[ids(selected)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("ten different seeds produced the same random council")
+	}
+}
+
+func TestCouncilsFor(t *testing.T) {
+	tiers, err := LoadTiers(map[string]config.CouncilConfig{
+		"security": {Core: []string{"paranoid", "enterprise"}},
+		"calm":     {Fixed: true, Exclude: []string{"yolo"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"paranoid", "full,conflict,inner,calm,security"},
+		{"yolo", "full,war,round,conflict"},
+		{"hipaa", "full,calm"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(CouncilsFor(tt.id, tiers), ","); got != tt.want {
+			t.Errorf("CouncilsFor(%s) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package council
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// topic links words that may appear in an idea to words that mark a persona
+// (in its description) as relevant to that kind of idea.
+type topic struct {
+	Name     string
+	Triggers []string // idea words; matched as prefixes, or exactly when 3 letters or fewer
+	Persona  []string // persona description phrases
+}
+
+// smartTopics drives keyword-based smart selection.
+var smartTopics = []topic{
+	{
+		Name:     "user experience",
+		Triggers: []string{"frontend", "front-end", "ui", "ux", "user", "design", "mobile", "app", "onboard", "accessib", "dashboard", "button", "screen", "web", "customer"},
+		Persona:  []string{"user experience", "design thinking", "journey", "human", "button"},
+	},
+	{
+		Name:     "security & compliance",
+		Triggers: []string{"secur", "complian", "gdpr", "hipaa", "soc2", "pci", "audit", "auth", "sso", "privacy", "pii", "encrypt", "regulat", "bank", "payment", "health", "medical", "legal", "fraud"},
+		Persona:  []string{"security", "risk", "compliance", "governance", "failure modes"},
+	},
+	{
+		Name:     "architecture & scale",
+		Triggers: []string{"architect", "scal", "microservice", "monolith", "distributed", "database", "infra", "platform", "api", "backend", "back-end", "performance", "latency", "queue", "cloud", "kubernetes"},
+		Persona:  []string{"architecture", "system design", "scalable", "production-grade", "refactoring"},
+	},
+	{
+		Name:     "speed to market",
+		Triggers: []string{"mvp", "prototype", "startup", "launch", "ship", "hackathon", "weekend", "poc", "validate", "quick", "fast", "experiment"},
+		Persona:  []string{"mvp", "prototyping", "move fast", "quick solutions", "fast action", "lean"},
+	},
+	{
+		Name:     "research & evidence",
+		Triggers: []string{"research", "evaluat", "compar", "study", "evidence", "benchmark", "ml", "ai", "llm", "model", "algorithm", "data"},
+		Persona:  []string{"research", "evidence", "prior art", "analysis", "peer review"},
+	},
+	{
+		Name:     "legacy & simplicity",
+		Triggers: []string{"legacy", "rewrite", "moderniz", "migrat", "cron", "batch", "csv", "script", "simple", "simplif", "overengineer", "replace"},
+		Persona:  []string{"historical", "cutting through complexity", "cron", "hype cycle", "refactoring"},
+	},
+	{
+		Name:     "process & organization",
+		Triggers: []string{"enterprise", "process", "governance", "team", "organization", "stakeholder", "rollout", "policy", "vendor", "procurement", "budget"},
+		Persona:  []string{"process", "governance", "enterprise", "committee", "meetings"},
+	},
+	{
+		Name:     "big-picture exploration",
+		Triggers: []string{"vision", "strategy", "brainstorm", "explore", "future", "reimagin", "novel", "creative"},
+		Persona:  []string{"unconventional", "deep exploration", "pattern recognition", "encouragement", "positive framing"},
+	},
+}
+
+// contrarianMarkers identify personas whose job is to push back. Smart
+// councils always include at least one.
+var contrarianMarkers = []string{"contrarian", "devil's advocate", "stress-test", "what could possibly go wrong", "failure modes"}
+
+// SmartPick is one Vern chosen by SelectSmart and why.
+type SmartPick struct {
+	Vern   Vern
+	Score  float64
+	Reason string
+}
+
+// SelectSmart picks the Verns most relevant to the idea by matching idea
+// keywords against topic lexicons and each persona's description. The
+// council size is the number of relevant Verns, clamped to the tier's
+// [MinFill, MaxFill]. At least one contrarian is always kept so the council
+// doesn't just agree with itself. rng breaks ties and fills the council when
+// the idea gives no signal.
+func SelectSmart(tier Tier, idea string, roster []Vern, rng *rand.Rand) ([]Vern, []SmartPick) {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	excluded := make(map[string]bool)
+	for _, id := range tier.Exclude {
+		excluded[id] = true
+	}
+	pool := filterOut(roster, excluded)
+	if len(pool) == 0 {
+		return nil, nil
+	}
+
+	hits := topicHits(idea)
+
+	// Shuffle first so equal scores are ordered by the seeded rng, not by ID
+	scored := make([]SmartPick, len(pool))
+	for i, idx := range rng.Perm(len(pool)) {
+		v := pool[idx]
+		desc := strings.ToLower(v.Description + " " + v.Desc)
+		pick := SmartPick{Vern: v}
+		var matched []string
+		for _, t := range smartTopics {
+			if hits[t.Name] == 0 {
+				continue
+			}
+			n := 0
+			for _, kw := range t.Persona {
+				if strings.Contains(desc, kw) {
+					n++
+				}
+			}
+			if n > 0 {
+				pick.Score += float64(hits[t.Name] * n)
+				matched = append(matched, t.Name)
+			}
+		}
+		if len(matched) > 0 {
+			pick.Reason = "relevant to " + strings.Join(matched, ", ")
+		}
+		scored[i] = pick
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+
+	relevant := 0
+	for _, p := range scored {
+		if p.Score > 0 {
+			relevant++
+		}
+	}
+	size := relevant
+	if tier.MinFill > 0 {
+		size = max(size, tier.MinFill)
+	}
+	if tier.MaxFill > 0 {
+		size = min(size, tier.MaxFill)
+	}
+	size = max(min(size, len(scored)), 1)
+
+	picks := append([]SmartPick{}, scored[:size]...)
+	for i := range picks {
+		if picks[i].Reason == "" {
+			picks[i].Reason = "random fill (no topical match)"
+		}
+	}
+
+	// Guarantee a contrarian: swap out the weakest pick if none made the cut
+	if !hasContrarian(picks) {
+		for _, p := range scored[size:] {
+			if isContrarian(p.Vern) {
+				p.Reason = "contrarian (every smart council keeps a dissenting voice)"
+				picks[len(picks)-1] = p
+				break
+			}
+		}
+	} else {
+		for i := range picks {
+			if isContrarian(picks[i].Vern) {
+				picks[i].Reason += "; contrarian"
+				break
+			}
+		}
+	}
+
+	selected := make([]Vern, len(picks))
+	for i := range picks {
+		if llm, ok := tier.LLMs[picks[i].Vern.ID]; ok && llm != "" {
+			picks[i].Vern.LLM = llm
+		}
+		selected[i] = picks[i].Vern
+	}
+	return selected, picks
+}
+
+// SmartRationale summarizes which topics the idea triggered.
+func SmartRationale(idea string) string {
+	hits := topicHits(idea)
+	var found []string
+	for _, t := range smartTopics {
+		if n := hits[t.Name]; n > 0 {
+			found = append(found, fmt.Sprintf("%s (%d)", t.Name, n))
+		}
+	}
+	if len(found) == 0 {
+		return "no topical keywords in the idea; council filled at random around a contrarian"
+	}
+	return "idea keywords matched " + strings.Join(found, ", ")
+}
+
+// topicHits counts, per topic, how many of its triggers appear in the idea.
+func topicHits(idea string) map[string]int {
+	words := ideaWords(idea)
+	hits := map[string]int{}
+	for _, t := range smartTopics {
+		for _, trig := range t.Triggers {
+			for _, w := range words {
+				if w == trig || (len(trig) > 3 && strings.HasPrefix(w, trig)) {
+					hits[t.Name]++
+					break
+				}
+			}
+		}
+	}
+	return hits
+}
+
+func hasContrarian(picks []SmartPick) bool {
+	for _, p := range picks {
+		if isContrarian(p.Vern) {
+			return true
+		}
+	}
+	return false
+}
+
+func isContrarian(v Vern) bool {
+	desc := strings.ToLower(v.Description + " " + v.Desc)
+	for _, m := range contrarianMarkers {
+		if strings.Contains(desc, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// ideaWords lowercases the idea and splits it into words, keeping hyphens.
+func ideaWords(idea string) []string {
+	return strings.FieldsFunc(strings.ToLower(idea), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-')
+	})
+}
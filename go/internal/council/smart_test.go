@@ -0,0 +1,120 @@
+package council
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// embeddedRoster uses the compiled-in personas so full descriptions are available.
+func embeddedRoster() []Vern {
+	return ScanRoster("") // no search dirs in tests, so only embedded personas
+}
+
+func pickIDs(picks []SmartPick) map[string]string {
+	ids := map[string]string{}
+	for _, p := range picks {
+		ids[p.Vern.ID] = p.Reason
+	}
+	return ids
+}
+
+func TestSelectSmart_Topics(t *testing.T) {
+	smart := AllTiers()["smart"]
+	tests := []struct {
+		idea string
+		want []string
+	}{
+		{"Redesign the onboarding flow of our mobile app so users find the upgrade button", []string{"ux"}},
+		{"HIPAA compliant audit logging for patient records with SSO", []string{"paranoid", "enterprise"}},
+		{"Weekend hackathon MVP to validate a startup idea fast", []string{"startup"}},
+	}
+	for _, tt := range tests {
+		selected, picks := SelectSmart(smart, tt.idea, embeddedRoster(), rand.New(rand.NewSource(7)))
+		if len(selected) < smart.MinFill || len(selected) > smart.MaxFill {
+			t.Errorf("%q: size %d, want %d-%d", tt.idea, len(selected), smart.MinFill, smart.MaxFill)
+		}
+		ids := pickIDs(picks)
+		for _, id := range tt.want {
+			reason, ok := ids[id]
+			if !ok {
+				t.Errorf("%q: expected %s in council, got %v", tt.idea, id, ids)
+				continue
+			}
+			if !strings.HasPrefix(reason, "relevant to ") {
+				t.Errorf("%q: %s reason = %q", tt.idea, id, reason)
+			}
+		}
+		if !hasContrarian(picks) {
+			t.Errorf("%q: no contrarian in %v", tt.idea, ids)
+		}
+	}
+}
+
+func TestSelectSmart_KeepsContrarian(t *testing.T) {
+	// A pure UX idea scores no contrarian highly; one must still be added
+	tier := Tier{Name: "smart", MinFill: 2, MaxFill: 2, Smart: true}
+	selected, picks := SelectSmart(tier, "frontend dashboard design for mobile users", embeddedRoster(), rand.New(rand.NewSource(1)))
+	if len(selected) != 2 {
+		t.Fatalf("size = %d, want 2", len(selected))
+	}
+	if !hasContrarian(picks) {
+		t.Fatalf("no contrarian in %v", pickIDs(picks))
+	}
+	if pickIDs(picks)["ux"] == "" {
+		t.Errorf("ux should survive the contrarian swap: %v", pickIDs(picks))
+	}
+}
+
+func TestSelectSmart_NoSignal(t *testing.T) {
+	tier := AllTiers()["smart"]
+	_, picks := SelectSmart(tier, "zzz qqq", embeddedRoster(), rand.New(rand.NewSource(3)))
+	if len(picks) != tier.MinFill {
+		t.Errorf("size = %d, want min %d", len(picks), tier.MinFill)
+	}
+	if !hasContrarian(picks) {
+		t.Error("no contrarian in no-signal council")
+	}
+	if got := SmartRationale("zzz qqq"); !strings.HasPrefix(got, "no topical keywords") {
+		t.Errorf("rationale = %q", got)
+	}
+}
+
+func TestSelectSmart_ExcludeAndLLMs(t *testing.T) {
+	tier := Tier{Name: "smart", MinFill: 3, MaxFill: 4, Smart: true, Exclude: []string{"paranoid"}, LLMs: map[string]string{"enterprise": "gemini"}}
+	selected, _ := SelectSmart(tier, "SOC2 compliance and governance review", embeddedRoster(), rand.New(rand.NewSource(5)))
+	for _, v := range selected {
+		if v.ID == "paranoid" {
+			t.Error("excluded persona selected")
+		}
+		if v.ID == "enterprise" && v.LLM != "gemini" {
+			t.Errorf("enterprise LLM = %s, want gemini", v.LLM)
+		}
+	}
+}
+
+func TestSelectSmart_Seeded(t *testing.T) {
+	tier := AllTiers()["smart"]
+	a, _ := SelectSmart(tier, "zzz", embeddedRoster(), rand.New(rand.NewSource(11)))
+	b, _ := SelectSmart(tier, "zzz", embeddedRoster(), rand.New(rand.NewSource(11)))
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			t.Fatalf("same seed gave different councils")
+		}
+	}
+}
+
+func TestSmartRationale(t *testing.T) {
+	got := SmartRationale("Add SSO and audit logs to the admin dashboard")
+	for _, want := range []string{"security & compliance (2)", "user experience (1)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rationale %q missing %q", got, want)
+		}
+	}
+}
+
+func TestResolveCouncilSmartNeedsIdea(t *testing.T) {
+	if _, _, err := ResolveCouncil("smart", nil, testRoster(), 3, testRNG()); err == nil {
+		t.Error("expected ResolveCouncil to refuse the smart tier")
+	}
+}
@@ -2,14 +2,21 @@ package council
 
 // Tier defines a named council configuration.
 type Tier struct {
-	Name     string
-	Display  string   // Human-readable display name
-	Core     []string // Required persona IDs
-	MinFill  int      // Min total count (fill remaining randomly)
-	MaxFill  int      // Max total count (fill remaining randomly)
-	Fixed    bool     // If true, no random fill (exact core members)
+	Name    string
+	Display string            // Human-readable display name
+	Core    []string          // Required persona IDs
+	MinFill int               // Min total count (fill remaining randomly)
+	MaxFill int               // Max total count (fill remaining randomly)
+	Fixed   bool              // If true, no random fill (exact core members)
+	Exclude []string          // Persona IDs never summoned, even as fill
+	LLMs    map[string]string // Per-member LLM overrides (persona ID -> LLM)
+	Smart   bool              // Pick the Verns most relevant to the idea (see SelectSmart)
+	Source  string            // Where a custom tier was declared (config or file path); empty for built-ins
 }
 
+// builtinOrder lists the predefined tiers in menu order.
+var builtinOrder = []string{"full", "smart", "random", "war", "round", "conflict", "inner", "hammers"}
+
 // AllTiers returns the predefined council tier configurations.
 func AllTiers() map[string]Tier {
 	return map[string]Tier{
@@ -52,6 +59,14 @@ func AllTiers() map[string]Tier {
 			Fixed:   true,
 			// Core is empty — means ALL available
 		},
+		"smart": {
+			Name:    "smart",
+			Display: "The Smart Council",
+			MinFill: 4,
+			MaxFill: 6,
+			Smart:   true,
+			// Members scored against the idea; see SelectSmart
+		},
 		"random": {
 			Name:    "random",
 			Display: "Fate's Hand",
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/")
+
+// goldenVTSDir is the shared VTS fixture set used by the tobeads tests.
+const goldenVTSDir = "../tobeads/testdata/golden"
+
+func TestRun_GoldenFiles(t *testing.T) {
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			exp, err := New(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			outDir := t.TempDir()
+			result, err := Run(Options{VTSDir: goldenVTSDir, OutputDir: outDir, Format: format}, exp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Exported != 5 {
+				t.Errorf("exported = %d, want 5", result.Exported)
+			}
+			if result.DepsOK != 6 {
+				t.Errorf("deps = %d, want 6", result.DepsOK)
+			}
+			for _, path := range result.Files {
+				compareGolden(t, "testdata", path)
+			}
+		})
+	}
+}
+
+// A description line matching the heredoc delimiter must not end the issue
+// body early in the gh script.
+func TestGitHubExporter_HeredocDelimiter(t *testing.T) {
+	outDir := t.TempDir()
+	result, err := Run(Options{VTSDir: "testdata/heredoc", OutputDir: outDir, Format: "github"}, NewGitHubExporter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range result.Files {
+		if strings.HasSuffix(path, ".sh") {
+			compareGolden(t, "testdata/heredoc", path)
+		}
+	}
+}
+
+func TestRun_PreflightFailure(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"cycle-a.md", "cycle-b.md"} {
+		data, err := os.ReadFile(filepath.Join("../tobeads/testdata/edge-cases", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(dir, name), data, 0644)
+	}
+
+	outDir := t.TempDir()
+	_, err := Run(Options{VTSDir: dir, OutputDir: outDir, Format: "jira"}, NewJiraExporter())
+	if err == nil || !strings.Contains(err.Error(), "preflight") {
+		t.Fatalf("expected preflight error, got %v", err)
+	}
+	entries, _ := os.ReadDir(outDir)
+	if len(entries) != 0 {
+		t.Errorf("expected no files written, got %d", len(entries))
+	}
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	if _, err := New("trello"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestJiraExporter_DepAddUnknownRef(t *testing.T) {
+	j := NewJiraExporter()
+	ref, _ := j.Create(tobeads.BeadSpec{ExternalRef: "VTS-001", Title: "A", Status: "open"})
+	if err := j.DepAdd(ref, "99"); err == nil {
+		t.Fatal("expected error for unknown ref")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"plain", "'plain'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.input); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestShellVar(t *testing.T) {
+	if got := shellVar("VTS-001"); got != "VTS_001" {
+		t.Errorf("shellVar = %q, want VTS_001", got)
+	}
+	if got := shellVar("1-x"); got != "_1_x" {
+		t.Errorf("shellVar = %q, want _1_x", got)
+	}
+}
+
+func compareGolden(t *testing.T, dir, path string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(dir, filepath.Base(path))
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create): %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from golden %s\n--- got ---\n%s", filepath.Base(path), golden, got)
+	}
+}
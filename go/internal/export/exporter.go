@@ -0,0 +1,158 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+// Exporter abstracts a file-based issue tracker target.
+// Modeled on tobeads.BrRunner: issues are created first, dependency edges are
+// wired second, and Flush writes the accumulated output files.
+type Exporter interface {
+	Create(spec tobeads.BeadSpec) (ref string, err error)
+	DepAdd(fromRef, toRef string) error // fromRef is blocked by toRef
+	Flush(outDir string) (files []string, err error)
+}
+
+// Options configures an export run.
+type Options struct {
+	VTSDir    string
+	OutputDir string
+	Format    string // github, jira, linear
+}
+
+// Result holds the outcome of an export run.
+type Result struct {
+	Exported int
+	Failed   int
+	DepsOK   int
+	DepsFail int
+	Files    []string
+	Errors   []string
+}
+
+// Formats lists the supported export formats.
+var Formats = []string{"github", "jira", "linear"}
+
+// New returns the exporter for a format name.
+func New(format string) (Exporter, error) {
+	switch strings.ToLower(format) {
+	case "github", "gh":
+		return NewGitHubExporter(), nil
+	case "jira":
+		return NewJiraExporter(), nil
+	case "linear":
+		return NewLinearExporter(), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q (valid: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Run executes the export pipeline: parse → preflight → normalize → create → deps → flush.
+func Run(opts Options, exp Exporter) (*Result, error) {
+	result := &Result{}
+
+	// 1. Parse VTS files
+	tasks, err := vts.ReadDir(opts.VTSDir)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	fmt.Printf("Parsed %d VTS tasks from %s\n", len(tasks), opts.VTSDir)
+
+	// 2. Preflight validation (same checks as tobeads)
+	report := tobeads.Preflight(tasks)
+	if !report.OK() {
+		fmt.Print(report.String())
+		return nil, fmt.Errorf("preflight failed with %d errors", len(report.Errors))
+	}
+
+	// 3. Normalize
+	specs, normErrs := tobeads.Normalize(tasks)
+	for _, e := range normErrs {
+		fmt.Printf("  WARN: %s\n", e)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no valid tasks after normalization")
+	}
+
+	// 4. Create issues
+	refMap := map[string]string{} // VTS ID -> exporter ref
+	for _, spec := range specs {
+		ref, err := exp.Create(spec)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("create %s: %v", spec.ExternalRef, err))
+			continue
+		}
+		refMap[spec.ExternalRef] = ref
+		result.Exported++
+	}
+
+	// 5. Wire dependencies
+	for _, spec := range specs {
+		fromRef, ok := refMap[spec.ExternalRef]
+		if !ok {
+			continue // failed create, skip
+		}
+		for _, depVTS := range spec.Dependencies {
+			toRef, ok := refMap[depVTS]
+			if !ok {
+				result.DepsFail++
+				result.Errors = append(result.Errors, fmt.Sprintf("dep %s→%s: target not exported", spec.ExternalRef, depVTS))
+				continue
+			}
+			if err := exp.DepAdd(fromRef, toRef); err != nil {
+				result.DepsFail++
+				result.Errors = append(result.Errors, fmt.Sprintf("dep %s→%s: %v", spec.ExternalRef, depVTS, err))
+				continue
+			}
+			result.DepsOK++
+		}
+	}
+
+	// 6. Write files
+	files, err := exp.Flush(opts.OutputDir)
+	if err != nil {
+		return nil, fmt.Errorf("write %s export: %w", opts.Format, err)
+	}
+	result.Files = files
+
+	printSummary(result)
+	return result, nil
+}
+
+// storyPoints maps a normalized complexity code to a Fibonacci estimate.
+// Returns 0 when complexity is unset.
+func storyPoints(complexity string) int {
+	return StoryPoints[complexity]
+}
+
+// sortedLabels returns a copy of labels in stable order for deterministic output.
+func sortedLabels(labels []string) []string {
+	out := append([]string{}, labels...)
+	sort.Strings(out)
+	return out
+}
+
+func printSummary(r *Result) {
+	fmt.Println("\n=== SUMMARY ===")
+	fmt.Printf("Exported: %d\n", r.Exported)
+	fmt.Printf("Failed:   %d\n", r.Failed)
+	fmt.Printf("Links:    %d\n", r.DepsOK)
+	if r.DepsFail > 0 {
+		fmt.Printf("Links Failed: %d\n", r.DepsFail)
+	}
+	for _, f := range r.Files {
+		fmt.Printf("Wrote %s\n", f)
+	}
+	if len(r.Errors) > 0 {
+		fmt.Println("Errors:")
+		for _, e := range r.Errors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+)
+
+// GitHubIssue is one entry in github-issues.json.
+type GitHubIssue struct {
+	Ref       string   `json:"ref"` // VTS ID
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels"`
+	Assignee  string   `json:"assignee,omitempty"`
+	State     string   `json:"state"` // open, closed
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// GitHubExporter emits a gh CLI batch script plus a JSON manifest.
+// Dependencies become "Blocked by #N" comments wired after all issues exist.
+type GitHubExporter struct {
+	issues []GitHubIssue
+	byVar  map[string]int // shell variable -> index in issues
+}
+
+func NewGitHubExporter() *GitHubExporter {
+	return &GitHubExporter{byVar: map[string]int{}}
+}
+
+func (g *GitHubExporter) Create(spec tobeads.BeadSpec) (string, error) {
+	state := "open"
+	if spec.Status == "closed" {
+		state = "closed"
+	}
+	issue := GitHubIssue{
+		Ref:      spec.ExternalRef,
+		Title:    spec.Title,
+		Body:     spec.Description,
+		Labels:   sortedLabels(spec.Labels),
+		Assignee: spec.Assignee,
+		State:    state,
+	}
+	if issue.Labels == nil {
+		issue.Labels = []string{}
+	}
+	ref := shellVar(spec.ExternalRef)
+	if _, ok := g.byVar[ref]; ok {
+		return "", fmt.Errorf("duplicate ref %s", spec.ExternalRef)
+	}
+	g.byVar[ref] = len(g.issues)
+	g.issues = append(g.issues, issue)
+	return ref, nil
+}
+
+func (g *GitHubExporter) DepAdd(fromRef, toRef string) error {
+	from, ok := g.byVar[fromRef]
+	if !ok {
+		return fmt.Errorf("unknown ref %s", fromRef)
+	}
+	to, ok := g.byVar[toRef]
+	if !ok {
+		return fmt.Errorf("unknown ref %s", toRef)
+	}
+	g.issues[from].BlockedBy = append(g.issues[from].BlockedBy, g.issues[to].Ref)
+	return nil
+}
+
+func (g *GitHubExporter) Flush(outDir string) ([]string, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	jsonPath := filepath.Join(outDir, "github-issues.json")
+	data, err := json.MarshalIndent(g.issues, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal issues: %w", err)
+	}
+	if err := os.WriteFile(jsonPath, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("write %s: %w", jsonPath, err)
+	}
+
+	scriptPath := filepath.Join(outDir, "github-issues.sh")
+	if err := os.WriteFile(scriptPath, []byte(g.script()), 0755); err != nil {
+		return nil, fmt.Errorf("write %s: %w", scriptPath, err)
+	}
+
+	return []string{jsonPath, scriptPath}, nil
+}
+
+// script renders the gh CLI batch script.
+func (g *GitHubExporter) script() string {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	b.WriteString("# Generated by vern export — creates GitHub issues from VTS tasks via the gh CLI.\n")
+	b.WriteString("# Runs against the current repo; set GH_REPO=owner/name to target another.\n")
+	b.WriteString("set -euo pipefail\n")
+
+	// Labels must exist before issues reference them
+	seen := map[string]bool{}
+	var labels []string
+	for _, issue := range g.issues {
+		for _, l := range issue.Labels {
+			if !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+	}
+	if len(labels) > 0 {
+		b.WriteString("\n# Labels\n")
+		for _, l := range sortedLabels(labels) {
+			b.WriteString(fmt.Sprintf("gh label create %s --force >/dev/null\n", shellQuote(l)))
+		}
+	}
+
+	b.WriteString("\n# Issues\n")
+	for _, issue := range g.issues {
+		v := shellVar(issue.Ref)
+		args := []string{"gh issue create", "--title " + shellQuote(issue.Title), "--body-file -"}
+		for _, l := range issue.Labels {
+			args = append(args, "--label "+shellQuote(l))
+		}
+		if issue.Assignee != "" {
+			args = append(args, "--assignee "+shellQuote(issue.Assignee))
+		}
+		eof := heredocDelimiter(issue.Body)
+		b.WriteString(fmt.Sprintf("%s=$(%s <<'%s'\n%s\n%s\n)\n", v, strings.Join(args, " "), eof, issue.Body, eof))
+		b.WriteString(fmt.Sprintf("%s=${%s##*/}\n", v, v))
+		b.WriteString(fmt.Sprintf("echo \"%s -> #$%s\"\n", issue.Ref, v))
+		if issue.State == "closed" {
+			b.WriteString(fmt.Sprintf("gh issue close \"$%s\" >/dev/null\n", v))
+		}
+	}
+
+	hasDeps := false
+	for _, issue := range g.issues {
+		if len(issue.BlockedBy) > 0 {
+			hasDeps = true
+			break
+		}
+	}
+	if hasDeps {
+		b.WriteString("\n# Dependencies\n")
+		for _, issue := range g.issues {
+			for _, dep := range issue.BlockedBy {
+				b.WriteString(fmt.Sprintf("gh issue comment \"$%s\" --body \"Blocked by #$%s (%s)\" >/dev/null\n",
+					shellVar(issue.Ref), shellVar(dep), dep))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// heredocDelimiter returns a heredoc delimiter that no line of body equals,
+// so a description can't end the heredoc early.
+func heredocDelimiter(body string) string {
+	lines := map[string]bool{}
+	for _, line := range strings.Split(body, "\n") {
+		lines[line] = true
+	}
+	eof := "VERN_BODY"
+	for i := 1; lines[eof]; i++ {
+		eof = fmt.Sprintf("VERN_BODY_%d", i)
+	}
+	return eof
+}
+
+// shellVar converts a VTS ID to a shell variable name (e.g. "VTS-001" -> "VTS_001").
+func shellVar(ref string) string {
+	v := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, ref)
+	if v == "" || (v[0] >= '0' && v[0] <= '9') {
+		v = "_" + v
+	}
+	return v
+}
+
+// shellQuote wraps s in single quotes, escaping embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
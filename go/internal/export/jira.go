@@ -0,0 +1,124 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+)
+
+type csvRow struct {
+	spec      tobeads.BeadSpec
+	id        string
+	blockedBy []string // exporter refs
+}
+
+// JiraExporter emits a CSV file for Jira's external system import.
+// Each row gets a numeric Issue Id; the "Blocked By" column lists Issue Ids
+// and maps to the "is blocked by" link type in the import wizard.
+type JiraExporter struct {
+	rows []csvRow
+	byID map[string]int
+}
+
+func NewJiraExporter() *JiraExporter {
+	return &JiraExporter{byID: map[string]int{}}
+}
+
+func (j *JiraExporter) Create(spec tobeads.BeadSpec) (string, error) {
+	id := strconv.Itoa(len(j.rows) + 1)
+	j.byID[id] = len(j.rows)
+	j.rows = append(j.rows, csvRow{spec: spec, id: id})
+	return id, nil
+}
+
+func (j *JiraExporter) DepAdd(fromRef, toRef string) error {
+	return addCSVDep(j.rows, j.byID, fromRef, toRef)
+}
+
+func (j *JiraExporter) Flush(outDir string) ([]string, error) {
+	// Jira takes repeated columns for multi-value fields
+	maxLabels, maxLinks := 1, 1
+	for _, r := range j.rows {
+		if len(r.spec.Labels) > maxLabels {
+			maxLabels = len(r.spec.Labels)
+		}
+		if len(r.blockedBy) > maxLinks {
+			maxLinks = len(r.blockedBy)
+		}
+	}
+
+	header := []string{"Issue Id", "External ID", "Summary", "Issue Type", "Status", "Story Points", "Assignee", "Description"}
+	for i := 0; i < maxLabels; i++ {
+		header = append(header, "Labels")
+	}
+	for i := 0; i < maxLinks; i++ {
+		header = append(header, "Blocked By")
+	}
+
+	records := [][]string{header}
+	for _, r := range j.rows {
+		points := ""
+		if sp := storyPoints(r.spec.Complexity); sp > 0 {
+			points = strconv.Itoa(sp)
+		}
+		rec := []string{
+			r.id,
+			r.spec.ExternalRef,
+			r.spec.Title,
+			"Task",
+			JiraStatusMap[r.spec.Status],
+			points,
+			r.spec.Assignee,
+			r.spec.Description,
+		}
+		rec = append(rec, padded(sortedLabels(r.spec.Labels), maxLabels)...)
+		rec = append(rec, padded(r.blockedBy, maxLinks)...)
+		records = append(records, rec)
+	}
+
+	path := filepath.Join(outDir, "jira-import.csv")
+	if err := writeCSV(path, records); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// addCSVDep records fromRef as blocked by toRef on a row set indexed by ref.
+func addCSVDep(rows []csvRow, index map[string]int, fromRef, toRef string) error {
+	from, ok := index[fromRef]
+	if !ok {
+		return fmt.Errorf("unknown ref %s", fromRef)
+	}
+	if _, ok := index[toRef]; !ok {
+		return fmt.Errorf("unknown ref %s", toRef)
+	}
+	rows[from].blockedBy = append(rows[from].blockedBy, toRef)
+	return nil
+}
+
+// padded returns vals extended with empty strings to length n.
+func padded(vals []string, n int) []string {
+	out := make([]string, n)
+	copy(out, vals)
+	return out
+}
+
+func writeCSV(path string, records [][]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("encode %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+)
+
+// LinearExporter emits a CSV file for Linear's CSV importer.
+// Rows are keyed by VTS ID; labels are comma-joined in a single column and
+// complexity becomes the issue Estimate. Linear's importer does not create
+// relations, so blockers are also listed at the end of each description.
+type LinearExporter struct {
+	rows []csvRow
+	byID map[string]int
+}
+
+func NewLinearExporter() *LinearExporter {
+	return &LinearExporter{byID: map[string]int{}}
+}
+
+func (l *LinearExporter) Create(spec tobeads.BeadSpec) (string, error) {
+	l.byID[spec.ExternalRef] = len(l.rows)
+	l.rows = append(l.rows, csvRow{spec: spec, id: spec.ExternalRef})
+	return spec.ExternalRef, nil
+}
+
+func (l *LinearExporter) DepAdd(fromRef, toRef string) error {
+	return addCSVDep(l.rows, l.byID, fromRef, toRef)
+}
+
+func (l *LinearExporter) Flush(outDir string) ([]string, error) {
+	records := [][]string{{"ID", "Title", "Description", "Status", "Estimate", "Labels", "Assignee", "Blocked By"}}
+	for _, r := range l.rows {
+		estimate := ""
+		if sp := storyPoints(r.spec.Complexity); sp > 0 {
+			estimate = strconv.Itoa(sp)
+		}
+		desc := r.spec.Description
+		if len(r.blockedBy) > 0 {
+			desc += "\n\n_Blocked by: " + strings.Join(r.blockedBy, ", ") + "_"
+		}
+		records = append(records, []string{
+			r.id,
+			r.spec.Title,
+			desc,
+			LinearStatusMap[r.spec.Status],
+			estimate,
+			strings.Join(sortedLabels(r.spec.Labels), ","),
+			r.spec.Assignee,
+			strings.Join(r.blockedBy, ","),
+		})
+	}
+
+	path := filepath.Join(outDir, "linear-import.csv")
+	if err := writeCSV(path, records); err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
@@ -0,0 +1,29 @@
+package export
+
+// StoryPoints maps complexity codes to Fibonacci story points.
+// Used for Jira "Story Points" and Linear "Estimate".
+var StoryPoints = map[string]int{
+	"XS": 1,
+	"S":  2,
+	"M":  3,
+	"L":  5,
+	"XL": 8,
+}
+
+// JiraStatusMap maps Beads statuses (from tobeads.StatusMap) to Jira workflow statuses.
+var JiraStatusMap = map[string]string{
+	"open":        "To Do",
+	"in_progress": "In Progress",
+	"blocked":     "To Do",
+	"closed":      "Done",
+	"deferred":    "Backlog",
+}
+
+// LinearStatusMap maps Beads statuses to Linear's default workflow states.
+var LinearStatusMap = map[string]string{
+	"open":        "Todo",
+	"in_progress": "In Progress",
+	"blocked":     "Todo",
+	"closed":      "Done",
+	"deferred":    "Backlog",
+}
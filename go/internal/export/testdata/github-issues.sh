@@ -0,0 +1,108 @@
+#!/usr/bin/env bash
+# Generated by vern export — creates GitHub issues from VTS tasks via the gh CLI.
+# Runs against the current repo; set GH_REPO=owner/name to target another.
+set -euo pipefail
+
+# Labels
+gh label create 'complexity:M' --force >/dev/null
+gh label create 'complexity:S' --force >/dev/null
+gh label create 'complexity:XS' --force >/dev/null
+gh label create 'source:discovery' --force >/dev/null
+gh label create 'source:oracle' --force >/dev/null
+
+# Issues
+VTS_001=$(gh issue create --title 'Setup Configuration' --body-file - --label 'complexity:XS' --label 'source:discovery' <<'VERN_BODY'
+Initialize the configuration system with defaults.
+
+## Files
+- config/settings.go
+
+---
+_Source: discovery | Ref: architect-breakdown.md_
+
+## Acceptance Criteria
+- Config file is created with defaults
+- Validation passes for all required fields
+VERN_BODY
+)
+VTS_001=${VTS_001##*/}
+echo "VTS-001 -> #$VTS_001"
+VTS_002=$(gh issue create --title 'Build the Parser' --body-file - --label 'complexity:S' --label 'source:discovery' <<'VERN_BODY'
+Implement the file parser with frontmatter support.
+
+## Files
+- parser/parser.go
+- parser/parser_test.go
+
+---
+_Source: discovery | Ref: architect-breakdown.md_
+
+## Acceptance Criteria
+- Parses YAML frontmatter correctly
+- Handles missing optional fields
+- Unit tests cover edge cases
+VERN_BODY
+)
+VTS_002=${VTS_002##*/}
+echo "VTS-002 -> #$VTS_002"
+VTS_003=$(gh issue create --title 'Build Normalizer' --body-file - --label 'complexity:M' --label 'source:discovery' <<'VERN_BODY'
+Transform parsed data into normalized output format.
+
+## Files
+- normalizer/normalizer.go
+
+---
+_Source: discovery | Ref: architect-breakdown.md_
+
+## Acceptance Criteria
+- All status values map correctly
+- Unknown statuses produce validation error
+- Complexity codes produce correct labels
+VERN_BODY
+)
+VTS_003=${VTS_003##*/}
+echo "VTS-003 -> #$VTS_003"
+VTS_004=$(gh issue create --title 'Implement Executor' --body-file - --label 'complexity:M' --label 'source:oracle' --assignee 'vern' <<'VERN_BODY'
+Build the execution layer that creates issues from normalized data.
+
+## Files
+- executor/executor.go
+- executor/executor_test.go
+
+---
+_Source: oracle | Ref: oracle-vision (revised 2024-01-15).md_
+
+## Acceptance Criteria
+- Creates issues via CLI with all mapped fields
+- Captures returned ID from JSON output
+- Handles duplicate gracefully
+- Produces ID mapping file
+VERN_BODY
+)
+VTS_004=${VTS_004##*/}
+echo "VTS-004 -> #$VTS_004"
+VTS_005=$(gh issue create --title 'Wire Dependencies' --body-file - --label 'complexity:S' --label 'source:discovery' <<'VERN_BODY'
+Add dependency edges between created issues using the ID map.
+
+## Files
+- executor/deps.go
+
+---
+_Source: discovery | Ref: architect-breakdown.md_
+
+## Acceptance Criteria
+- All dependency references resolved
+- Correct directionality verified
+- Handles missing refs gracefully
+VERN_BODY
+)
+VTS_005=${VTS_005##*/}
+echo "VTS-005 -> #$VTS_005"
+
+# Dependencies
+gh issue comment "$VTS_002" --body "Blocked by #$VTS_001 (VTS-001)" >/dev/null
+gh issue comment "$VTS_003" --body "Blocked by #$VTS_001 (VTS-001)" >/dev/null
+gh issue comment "$VTS_003" --body "Blocked by #$VTS_002 (VTS-002)" >/dev/null
+gh issue comment "$VTS_004" --body "Blocked by #$VTS_002 (VTS-002)" >/dev/null
+gh issue comment "$VTS_004" --body "Blocked by #$VTS_003 (VTS-003)" >/dev/null
+gh issue comment "$VTS_005" --body "Blocked by #$VTS_004 (VTS-004)" >/dev/null
@@ -0,0 +1,28 @@
+#!/usr/bin/env bash
+# Generated by vern export — creates GitHub issues from VTS tasks via the gh CLI.
+# Runs against the current repo; set GH_REPO=owner/name to target another.
+set -euo pipefail
+
+# Labels
+gh label create 'complexity:XS' --force >/dev/null
+gh label create 'source:discovery' --force >/dev/null
+
+# Issues
+VTS_001=$(gh issue create --title 'Quote the Heredoc' --body-file - --label 'complexity:XS' --label 'source:discovery' <<'VERN_BODY_2'
+The script feeds each body through a quoted heredoc:
+
+VERN_BODY
+VERN_BODY_1
+touch /tmp/pwned
+
+Neither line above may end the body.
+
+## Files
+- export/github.go
+
+---
+_Source: discovery | Ref: paranoid-review.md_
+VERN_BODY_2
+)
+VTS_001=${VTS_001##*/}
+echo "VTS-001 -> #$VTS_001"
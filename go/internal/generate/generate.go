@@ -23,7 +23,8 @@ type Options struct {
 	LLM         string // LLM for generation (default: claude)
 	DryRun      bool   // print without writing
 	NoUpdate    bool   // skip v.md/help/test/roster updates
-	RepoRoot    string // detected repo root
+	RepoRoot    string // detected repo root; empty writes the agent to PersonaDir
+	PersonaDir  string // where the agent goes without a repo (default: persona.UserDir())
 	LogFunc     func(string) // optional callback for progress logs
 }
 
@@ -51,12 +52,15 @@ func ValidateName(name, repoRoot string) error {
 		return fmt.Errorf("name must be 30 characters or fewer")
 	}
 
-	// Check for conflict with existing agents
+	// Check for conflict with existing agents; without a repo, with any
+	// persona on the search path
 	if repoRoot != "" {
 		agentPath := filepath.Join(repoRoot, "agents", name+".md")
 		if _, err := os.Stat(agentPath); err == nil {
 			return fmt.Errorf("persona %q already exists at %s", name, agentPath)
 		}
+	} else if cands := persona.Candidates("", name); len(cands) > 0 {
+		return fmt.Errorf("persona %q already exists (%s)", name, cands[0].Source)
 	}
 
 	// Check for alias conflicts
@@ -68,22 +72,55 @@ func ValidateName(name, repoRoot string) error {
 	return nil
 }
 
-// Run executes the full persona generation workflow.
+// Run executes the full persona generation workflow: validate, draft,
+// then write (or print, for a dry run).
 func Run(opts Options) error {
-	if opts.LLM == "" {
-		opts.LLM = "claude"
-	}
-
 	// 1. Validate
 	if err := ValidateName(opts.Name, opts.RepoRoot); err != nil {
 		return fmt.Errorf("invalid name: %w", err)
 	}
 
-	opts.log(fmt.Sprintf("Generating persona %q...", opts.Name))
-	opts.log(fmt.Sprintf("  LLM: %s | Timeout: 5m", opts.LLM))
+	gen, err := Draft(opts, nil, "")
+	if err != nil {
+		return err
+	}
+
+	// Dry run: print and exit
+	if opts.DryRun {
+		opts.log("\n--- DRY RUN: Generated content ---")
+		opts.log("\n=== " + opts.agentPath() + " ===")
+		opts.log(gen.Agent)
+		opts.log("\n=== commands/" + opts.Name + ".md ===")
+		opts.log(gen.Command)
+		opts.log("\n=== skills/" + opts.Name + "/SKILL.md ===")
+		opts.log(gen.Skill)
+		return nil
+	}
+
+	if err := Write(opts, gen); err != nil {
+		return err
+	}
+	opts.log(fmt.Sprintf("\nPersona %q is ready.", opts.Name))
+	return nil
+}
+
+// Draft asks the LLM for a persona without writing anything. Given the
+// previous draft and feedback on it, it asks for a revision instead.
+func Draft(opts Options, prev *GeneratedPersona, feedback string) (*GeneratedPersona, error) {
+	if opts.LLM == "" {
+		opts.LLM = "claude"
+	}
 
 	// 2. Build prompt
-	prompt := BuildPrompt(opts.Name, opts.Description, opts.Model, opts.Color)
+	var prompt string
+	if prev != nil && strings.TrimSpace(feedback) != "" {
+		opts.log(fmt.Sprintf("Revising persona %q...", opts.Name))
+		prompt = BuildRefinePrompt(opts.Name, opts.Description, opts.Model, opts.Color, prev, feedback)
+	} else {
+		opts.log(fmt.Sprintf("Generating persona %q...", opts.Name))
+		prompt = BuildPrompt(opts.Name, opts.Description, opts.Model, opts.Color)
+	}
+	opts.log(fmt.Sprintf("  LLM: %s | Timeout: 5m", opts.LLM))
 
 	// 3. Call LLM
 	opts.log("\nWaiting for LLM response...")
@@ -93,12 +130,13 @@ func Run(opts Options) error {
 		LLM:     opts.LLM,
 		Prompt:  prompt,
 		Timeout: 5 * time.Minute,
+		Step:    "generate",
 	})
 	if err != nil {
-		return fmt.Errorf("LLM call failed: %w", err)
+		return nil, fmt.Errorf("LLM call failed: %w", err)
 	}
 	if result.Output == "" {
-		return fmt.Errorf("LLM returned empty output")
+		return nil, fmt.Errorf("LLM returned empty output")
 	}
 
 	opts.log(fmt.Sprintf("  Response received (%.1fs, %d chars)", result.Duration.Seconds(), len(result.Output)))
@@ -107,42 +145,45 @@ func Run(opts Options) error {
 	opts.log("\nParsing output...")
 	gen, err := ParseOutput(opts.Name, result.Output)
 	if err != nil {
-		return fmt.Errorf("parse output: %w", err)
+		return nil, fmt.Errorf("parse output: %w", err)
 	}
 
-	// Extract model and color from the generated agent for display/updates
-	p, _ := persona.ParseString(gen.Agent)
-	modelName := "sonnet"
-	colorName := ""
-	if p != nil {
-		if p.Model != "" {
-			modelName = p.Model
-		}
-		if p.Color != "" {
-			colorName = p.Color
-		}
-	}
-
-	opts.log(fmt.Sprintf("  Agent:   OK (model=%s, color=%s)", modelName, colorName))
+	opts.log(fmt.Sprintf("  Agent:   OK (model=%s, color=%s)", ExtractModel(gen.Agent), ExtractColor(gen.Agent)))
 	opts.log("  Command: OK")
 	opts.log("  Skill:   OK")
+	return gen, nil
+}
 
-	// 5. Dry run: print and exit
-	if opts.DryRun {
-		opts.log("\n--- DRY RUN: Generated content ---")
-		opts.log("\n=== agents/" + opts.Name + ".md ===")
-		opts.log(gen.Agent)
-		opts.log("\n=== commands/" + opts.Name + ".md ===")
-		opts.log(gen.Command)
-		opts.log("\n=== skills/" + opts.Name + "/SKILL.md ===")
-		opts.log(gen.Skill)
+// Write saves an accepted draft. In a vern-bot checkout (RepoRoot set) it
+// writes the agent, command, and skill files and registers the persona;
+// otherwise it writes just the agent file to the persona dir, since the
+// command and skill files only mean something to the plugin.
+func Write(opts Options, gen *GeneratedPersona) error {
+	if opts.RepoRoot == "" {
+		path := opts.agentPath()
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("persona %q already exists at %s", opts.Name, path)
+		}
+		opts.log("\nWriting files...")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("create persona dir: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(gen.Agent), 0644); err != nil {
+			return fmt.Errorf("write agent file: %w", err)
+		}
+		opts.log(fmt.Sprintf("  Created %s", path))
+		opts.log("  Skipped plugin command and skill files (no vern-bot checkout)")
 		return nil
 	}
 
+	// Extract model and description from the generated agent for updates
+	p, _ := persona.ParseString(gen.Agent)
+	modelName := ExtractModel(gen.Agent)
+
 	// 6. Write files
 	opts.log("\nWriting files...")
 
-	agentPath := filepath.Join(opts.RepoRoot, "agents", opts.Name+".md")
+	agentPath := opts.agentPath()
 	if err := os.WriteFile(agentPath, []byte(gen.Agent), 0644); err != nil {
 		return fmt.Errorf("write agent file: %w", err)
 	}
@@ -212,24 +253,41 @@ func Run(opts Options) error {
 		}
 
 		// 8. Regenerate embedded assets
-		opts.log("\nRegenerating embedded assets...")
-		goDir := filepath.Join(opts.RepoRoot, "go")
-		cmd := exec.Command("go", "generate", "./internal/embedded/")
-		cmd.Dir = goDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			opts.log(fmt.Sprintf("  Warning: go generate failed: %v", err))
-			opts.log("  Run manually: cd go && go generate ./internal/embedded/")
-		} else {
-			opts.log("  Done")
-		}
+		regenerateEmbedded(opts)
 	}
-
-	opts.log(fmt.Sprintf("\nPersona %q is ready.", opts.Name))
 	return nil
 }
 
+// agentPath is where the agent file goes: agents/ in a checkout, else the
+// persona dir.
+func (o Options) agentPath() string {
+	if o.RepoRoot != "" {
+		return filepath.Join(o.RepoRoot, "agents", o.Name+".md")
+	}
+	dir := o.PersonaDir
+	if dir == "" {
+		dir = persona.UserDir()
+	}
+	return filepath.Join(dir, o.Name+".md")
+}
+
+// regenerateEmbedded reruns go generate so the binary's embedded agents
+// match agents/.
+func regenerateEmbedded(opts Options) {
+	opts.log("\nRegenerating embedded assets...")
+	goDir := filepath.Join(opts.RepoRoot, "go")
+	cmd := exec.Command("go", "generate", "./internal/embedded/")
+	cmd.Dir = goDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		opts.log(fmt.Sprintf("  Warning: go generate failed: %v", err))
+		opts.log("  Run manually: cd go && go generate ./internal/embedded/")
+	} else {
+		opts.log("  Done")
+	}
+}
+
 // DetectRepoRoot walks up from cwd looking for agents/ + go/ coexisting.
 func DetectRepoRoot() (string, error) {
 	// Check VERN_ROOT env var first
@@ -258,6 +316,11 @@ func DetectRepoRoot() (string, error) {
 	return "", fmt.Errorf("could not find vern-bot repo root (looking for agents/ + go/ dirs)")
 }
 
+// IsRepoRoot reports whether dir is a vern-bot checkout (agents/ and go/).
+func IsRepoRoot(dir string) bool {
+	return dir != "" && hasRepoMarkers(dir)
+}
+
 func hasRepoMarkers(dir string) bool {
 	agentsInfo, err := os.Stat(filepath.Join(dir, "agents"))
 	if err != nil || !agentsInfo.IsDir() {
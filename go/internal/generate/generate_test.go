@@ -1,6 +1,8 @@
 package generate
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -229,6 +231,7 @@ func TestValidateName(t *testing.T) {
 		{"-hyphen", true},     // starts with hyphen
 		{"mediocre", true},    // conflicts with existing alias
 		{"hole", true},        // conflicts with existing workflow
+		{"historian", true},   // an existing persona (without a repo)
 	}
 
 	for _, tt := range tests {
@@ -286,3 +289,131 @@ func TestComputeAliases_ConflictingPrefix(t *testing.T) {
 		}
 	}
 }
+
+const testVMD = "### Specialist Personas\n| Alias | Skill | Description |\n|-------|-------|-------------|\n| `academic` / `acad` / `a` | `/vern:academic` | Cites sources |\n| `ux` / `u` | `/vern:ux` | Finds the button |\n\n## Routing\n\n- `academic` / `acad` / `a` → invoke `/vern:academic`\n- `ux` / `u` → invoke `/vern:ux`\n\nBegin with: $ARGUMENTS\n"
+
+const testHelpMD = "SPECIALIST PERSONAS\n  /vern:academic <task>    Opus    - Evidence-based\n  /vern:ux <task>          Opus    - User experience\n\nWORKFLOWS\n  Aliases: med, great, nq,\n           acad, ux, setup\n\nEXAMPLES\n"
+
+const testEmbeddedTest = "var expectedAgents = []string{\n\t\"academic\", \"ux\",\n}\n"
+
+const testSelection = "func hardcodedRoster() []Vern {\n\treturn []Vern{\n\t\t{ID: \"academic\", Name: \"Academic Vern\", LLM: \"claude\", Desc: \"cites sources\"},\n\t\t{ID: \"ux\", Name: \"UX Vern\", LLM: \"claude\", Desc: \"finds the button\"},\n\t}\n}\n"
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Removing a persona right after registering it restores each file.
+func TestRemoveReversesUpdate(t *testing.T) {
+	aliases := ComputeAliases("nihilist", KnownAliases())
+
+	vmd := writeTestFile(t, testVMD)
+	if err := UpdateVMD(vmd, "nihilist", "Nothing matters", aliases); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveFromVMD(vmd, "nihilist"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, vmd); got != testVMD {
+		t.Errorf("v.md round trip:\n%s", got)
+	}
+
+	help := writeTestFile(t, testHelpMD)
+	if err := UpdateHelpMD(help, "nihilist", "sonnet", "Nothing matters", aliases[1]); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(readTestFile(t, help), "setup, nih") {
+		t.Fatalf("alias not added:\n%s", readTestFile(t, help))
+	}
+	if err := RemoveFromHelpMD(help, "nihilist", aliases[1]); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, help); got != testHelpMD {
+		t.Errorf("help.md round trip:\n%s", got)
+	}
+
+	emb := writeTestFile(t, testEmbeddedTest)
+	if err := UpdateEmbeddedTest(emb, "nihilist"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveFromEmbeddedTest(emb, "nihilist"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, emb); got != testEmbeddedTest {
+		t.Errorf("embedded_test.go round trip:\n%s", got)
+	}
+}
+
+func TestRemoveEntries(t *testing.T) {
+	sel := writeTestFile(t, testSelection)
+	if err := RemoveFromHardcodedRoster(sel, "ux"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, sel); strings.Contains(got, `"ux"`) || !strings.Contains(got, `"academic"`) {
+		t.Errorf("selection.go after remove:\n%s", got)
+	}
+	if err := RemoveFromHardcodedRoster(sel, "ux"); err == nil {
+		t.Error("removing a missing roster entry should fail")
+	}
+
+	help := writeTestFile(t, testHelpMD)
+	if err := RemoveFromHelpMD(help, "ux", "ux"); err != nil {
+		t.Fatal(err)
+	}
+	got := readTestFile(t, help)
+	if strings.Contains(got, "/vern:ux") || !strings.Contains(got, "acad, setup") {
+		t.Errorf("help.md after remove:\n%s", got)
+	}
+
+	emb := writeTestFile(t, testEmbeddedTest)
+	if err := RemoveFromEmbeddedTest(emb, "historian"); err == nil {
+		t.Error("removing a missing expectedAgents name should fail")
+	}
+}
+
+func TestBuildRefinePrompt(t *testing.T) {
+	prev := &GeneratedPersona{Name: "nihilist", Agent: "AGENT DRAFT\n", Command: "COMMAND DRAFT", Skill: "SKILL DRAFT"}
+	prompt := BuildRefinePrompt("nihilist", "Nothing matters", "", "", prev, "  More existential dread.  ")
+
+	if !strings.HasPrefix(prompt, BuildPrompt("nihilist", "Nothing matters", "", "")) {
+		t.Error("refine prompt should start with the original prompt")
+	}
+	for _, want := range []string{"=== AGENT ===\nAGENT DRAFT\n=== END AGENT ===", "COMMAND DRAFT", "SKILL DRAFT", "asked for these changes:\n\nMore existential dread.\n", `keep the name "nihilist"`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("refine prompt missing %q", want)
+		}
+	}
+}
+
+func TestWriteWithoutRepo(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "agents")
+	var logs []string
+	opts := Options{Name: "nihilist", PersonaDir: dir, LogFunc: func(s string) { logs = append(logs, s) }}
+	gen := &GeneratedPersona{Name: "nihilist", Agent: "---\nname: nihilist\n---\nNothing.\n", Command: "cmd", Skill: "skill"}
+
+	if err := Write(opts, gen); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dir, "nihilist.md")); got != gen.Agent {
+		t.Errorf("agent file = %q", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("only the agent file should be written, got %d entries", len(entries))
+	}
+	if err := Write(opts, gen); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second write err = %v, want already exists", err)
+	}
+}
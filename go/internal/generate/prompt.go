@@ -2,6 +2,7 @@ package generate
 
 import (
 	"bytes"
+	"strings"
 	"text/template"
 )
 
@@ -289,3 +290,53 @@ func BuildPrompt(name, desc, model, color string) string {
 	tmpl.Execute(&buf, data)
 	return buf.String()
 }
+
+// refinePromptTemplate follows the original prompt when revising a draft.
+const refinePromptTemplate = `
+
+## Previous Draft
+
+You already produced this draft:
+
+=== AGENT ===
+{{.Agent}}
+=== END AGENT ===
+
+=== COMMAND ===
+{{.Command}}
+=== END COMMAND ===
+
+=== SKILL ===
+{{.Skill}}
+=== END SKILL ===
+
+## Feedback
+
+The user reviewed the draft and asked for these changes:
+
+{{.Feedback}}
+
+Revise the persona to address the feedback. Keep everything the feedback doesn't mention, keep the name "{{.Name}}", and output all three sections again in the same format.
+`
+
+type refineData struct {
+	Name                  string
+	Agent, Command, Skill string
+	Feedback              string
+}
+
+// BuildRefinePrompt asks for a revision of prev: the original prompt, then
+// the previous draft and the user's feedback on it.
+func BuildRefinePrompt(name, desc, model, color string, prev *GeneratedPersona, feedback string) string {
+	data := refineData{
+		Name:     name,
+		Agent:    strings.TrimSpace(prev.Agent),
+		Command:  strings.TrimSpace(prev.Command),
+		Skill:    strings.TrimSpace(prev.Skill),
+		Feedback: strings.TrimSpace(feedback),
+	}
+	tmpl := template.Must(template.New("refine").Parse(refinePromptTemplate))
+	var buf bytes.Buffer
+	tmpl.Execute(&buf, data)
+	return BuildPrompt(name, desc, model, color) + buf.String()
+}
@@ -0,0 +1,203 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Remove deletes a generated persona and reverses the registrations Run
+// made: the v.md table row and routing entry, the help.md line and short
+// alias, the embedded_test.go name, and the hardcodedRoster entry. It then
+// regenerates embedded assets. Only Name, RepoRoot, DryRun, NoUpdate, and
+// LogFunc are used.
+func Remove(opts Options) error {
+	if opts.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	files := []string{
+		filepath.Join(opts.RepoRoot, "agents", opts.Name+".md"),
+		filepath.Join(opts.RepoRoot, "commands", opts.Name+".md"),
+		filepath.Join(opts.RepoRoot, "skills", opts.Name),
+	}
+	if _, err := os.Stat(files[0]); err != nil {
+		return fmt.Errorf("persona %q not found at %s", opts.Name, files[0])
+	}
+
+	if opts.DryRun {
+		opts.log("DRY RUN: would remove:")
+		for _, f := range files {
+			if _, err := os.Stat(f); err == nil {
+				opts.log("  " + f)
+			}
+		}
+		if !opts.NoUpdate {
+			opts.log("and unregister it from commands/v.md, commands/help.md, embedded_test.go, and selection.go")
+		}
+		return nil
+	}
+
+	opts.log(fmt.Sprintf("Removing persona %q...", opts.Name))
+	for _, f := range files {
+		if _, err := os.Stat(f); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(f); err != nil {
+			return fmt.Errorf("remove %s: %w", f, err)
+		}
+		opts.log(fmt.Sprintf("  Removed %s", f))
+	}
+
+	if opts.NoUpdate {
+		return nil
+	}
+	opts.log("\nUpdating registrations...")
+
+	// The alias Run added is deterministic, so recompute it
+	aliases := ComputeAliases(opts.Name, KnownAliases())
+	shortAlias := opts.Name
+	if len(aliases) > 1 {
+		shortAlias = aliases[1]
+	}
+
+	steps := []struct {
+		label string
+		fn    func(string) error
+		path  string
+	}{
+		{"commands/v.md", func(p string) error { return RemoveFromVMD(p, opts.Name) },
+			filepath.Join(opts.RepoRoot, "commands", "v.md")},
+		{"commands/help.md", func(p string) error { return RemoveFromHelpMD(p, opts.Name, shortAlias) },
+			filepath.Join(opts.RepoRoot, "commands", "help.md")},
+		{"go/internal/embedded/embedded_test.go", func(p string) error { return RemoveFromEmbeddedTest(p, opts.Name) },
+			filepath.Join(opts.RepoRoot, "go", "internal", "embedded", "embedded_test.go")},
+		{"go/internal/council/selection.go", func(p string) error { return RemoveFromHardcodedRoster(p, opts.Name) },
+			filepath.Join(opts.RepoRoot, "go", "internal", "council", "selection.go")},
+	}
+	for _, s := range steps {
+		if err := s.fn(s.path); err != nil {
+			opts.log(fmt.Sprintf("  Warning: %s: %v", s.label, err))
+		} else {
+			opts.log("  Updated " + s.label)
+		}
+	}
+
+	regenerateEmbedded(opts)
+	opts.log(fmt.Sprintf("\nPersona %q removed.", opts.Name))
+	return nil
+}
+
+// RemoveFromVMD drops a persona's Specialist Personas row and routing entry
+// from commands/v.md.
+func RemoveFromVMD(path, name string) error {
+	return removeLines(path, "`/vern:"+name+"`")
+}
+
+// RemoveFromHelpMD drops a persona's SPECIALIST PERSONAS line from
+// commands/help.md and its short alias from the Aliases line.
+func RemoveFromHelpMD(path, name, shortAlias string) error {
+	if err := removeLines(path, "/vern:"+name+" <task>"); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read help.md: %w", err)
+	}
+	content := string(data)
+
+	aliasIdx := strings.Index(content, "Aliases:")
+	if aliasIdx == -1 {
+		return nil
+	}
+	start := aliasIdx + len("Aliases:")
+	end := strings.Index(content[start:], "\n\n")
+	if end == -1 {
+		end = len(content) - start
+	}
+	end += start
+
+	// Find the alias as a whole token, then drop it with one neighbouring ", "
+	tokenRe := regexp.MustCompile(`[^\s,]+`)
+	for _, loc := range tokenRe.FindAllStringIndex(content[start:end], -1) {
+		s, e := start+loc[0], start+loc[1]
+		if content[s:e] != shortAlias {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(content[e:], ", "):
+			content = content[:s] + content[e+2:]
+		case strings.HasSuffix(content[:s], ", "):
+			content = content[:s-2] + content[e:]
+		default:
+			content = content[:s] + content[e:]
+		}
+		break
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// RemoveFromEmbeddedTest drops a name from the expectedAgents slice in
+// embedded_test.go.
+func RemoveFromEmbeddedTest(path, name string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read embedded_test.go: %w", err)
+	}
+	content := string(data)
+
+	sliceStart := strings.Index(content, "var expectedAgents = []string{")
+	if sliceStart == -1 {
+		return fmt.Errorf("expectedAgents slice not found")
+	}
+	sliceEnd := strings.Index(content[sliceStart:], "}")
+	if sliceEnd == -1 {
+		return fmt.Errorf("expectedAgents slice end not found")
+	}
+	sliceEnd += sliceStart
+
+	re := regexp.MustCompile(`"([a-z-]+)"`)
+	var names []string
+	found := false
+	for _, m := range re.FindAllStringSubmatch(content[sliceStart:sliceEnd+1], -1) {
+		if m[1] == name {
+			found = true
+			continue
+		}
+		names = append(names, m[1])
+	}
+	if !found {
+		return fmt.Errorf("%q not in expectedAgents", name)
+	}
+
+	content = content[:sliceStart] + formatExpectedAgents(names) + content[sliceEnd+1:]
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// RemoveFromHardcodedRoster drops a persona's entry from hardcodedRoster()
+// in selection.go.
+func RemoveFromHardcodedRoster(path, name string) error {
+	return removeLines(path, fmt.Sprintf("{ID: %q,", name))
+}
+
+// removeLines deletes every line of the file containing marker, failing if
+// there are none.
+func removeLines(path, marker string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	lines := strings.Split(string(data), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.Contains(line, marker) {
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == len(lines) {
+		return fmt.Errorf("no entry for %s", strings.Trim(marker, "`{,"))
+	}
+	return os.WriteFile(path, []byte(strings.Join(kept, "\n")), 0644)
+}
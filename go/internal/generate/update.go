@@ -306,7 +306,12 @@ func UpdateEmbeddedTest(path, name string) error {
 	names = append(names, name)
 	sort.Strings(names)
 
-	// Rebuild the slice with the same formatting (5 per line)
+	content = content[:sliceStart] + formatExpectedAgents(names) + content[sliceEnd+1:]
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// formatExpectedAgents renders the expectedAgents slice, 5 names per line.
+func formatExpectedAgents(names []string) string {
 	var sb strings.Builder
 	sb.WriteString("var expectedAgents = []string{\n")
 	for i := 0; i < len(names); i += 5 {
@@ -326,9 +331,7 @@ func UpdateEmbeddedTest(path, name string) error {
 		sb.WriteString("\n")
 	}
 	sb.WriteString("}")
-
-	content = content[:sliceStart] + sb.String() + content[sliceEnd+1:]
-	return os.WriteFile(path, []byte(content), 0644)
+	return sb.String()
 }
 
 // UpdateHardcodedRoster inserts a new Vern entry into the hardcodedRoster() function in selection.go.
@@ -0,0 +1,189 @@
+// Package judge scores LLM output against a rubric using a judge LLM.
+package judge
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+)
+
+const (
+	scoresStart = "=== SCORES ==="
+	scoresEnd   = "=== END SCORES ==="
+)
+
+// Criterion is one rubric dimension, scored 1-5.
+type Criterion struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CompareRubric is the default rubric for head-to-head comparisons.
+var CompareRubric = []Criterion{
+	{Name: "relevance", Description: "Answers the prompt that was asked, without drifting"},
+	{Name: "depth", Description: "Goes beyond the obvious; considers trade-offs and edge cases"},
+	{Name: "clarity", Description: "Well organized and easy to follow"},
+	{Name: "actionability", Description: "A reader could act on it without further questions"},
+}
+
+// PipelineRubric is the default rubric for discovery pipeline outputs.
+var PipelineRubric = []Criterion{
+	{Name: "completeness", Description: "Covers everything the task asks for, with no obvious gaps"},
+	{Name: "actionability", Description: "Concrete enough that a team could act on it without further questions"},
+	{Name: "consistency", Description: "Stays consistent with the original idea and doesn't contradict itself"},
+}
+
+// CriterionScore is the score a judge gave one criterion.
+type CriterionScore struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"` // 1-5
+}
+
+// Score is a judge's verdict on one output.
+type Score struct {
+	Scores    []CriterionScore `json:"scores"`
+	Overall   float64          `json:"overall"` // mean of the criterion scores, one decimal
+	Rationale string           `json:"rationale,omitempty"`
+	JudgeLLM  string           `json:"judge_llm,omitempty"`
+}
+
+// Get returns the score for a criterion, or 0 if the judge didn't give one.
+func (s *Score) Get(name string) int {
+	for _, c := range s.Scores {
+		if c.Name == name {
+			return c.Score
+		}
+	}
+	return 0
+}
+
+// Summary renders the score as e.g. "3.7/5 (completeness 4, actionability 3)".
+func (s *Score) Summary() string {
+	parts := make([]string, len(s.Scores))
+	for i, c := range s.Scores {
+		parts[i] = fmt.Sprintf("%s %d", c.Name, c.Score)
+	}
+	return fmt.Sprintf("%.1f/5 (%s)", s.Overall, strings.Join(parts, ", "))
+}
+
+// Options configures Evaluate.
+type Options struct {
+	Ctx         context.Context // optional: cancelled on TUI quit
+	LLM         string          // judge LLM (default: claude)
+	Rubric      []Criterion     // default: CompareRubric
+	Task        string          // the prompt or idea the output answers
+	Output      string          // the output to score
+	Timeout     time.Duration   // default: 5 minutes
+	QuietStderr bool
+}
+
+// Evaluate asks the judge LLM to score an output against the rubric.
+func Evaluate(opts Options) (*Score, error) {
+	if opts.LLM == "" {
+		opts.LLM = "claude"
+	}
+	if len(opts.Rubric) == 0 {
+		opts.Rubric = CompareRubric
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+
+	result, err := llm.Run(llm.RunOptions{
+		Ctx:         opts.Ctx,
+		LLM:         opts.LLM,
+		Prompt:      Prompt(opts.Rubric, opts.Task, opts.Output),
+		Timeout:     opts.Timeout,
+		QuietStderr: opts.QuietStderr,
+		Step:        "judge",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("judge %s: %w", opts.LLM, err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("judge %s exited with code %d", opts.LLM, result.ExitCode)
+	}
+	score, err := Parse(opts.Rubric, result.Output)
+	if err != nil {
+		return nil, err
+	}
+	score.JudgeLLM = result.LLMUsed
+	return score, nil
+}
+
+// Prompt builds the judge prompt. The judge is never told who wrote the
+// output, so scores aren't swayed by persona or LLM names.
+func Prompt(rubric []Criterion, task, output string) string {
+	var b strings.Builder
+	b.WriteString("You are an impartial evaluator. Score the RESPONSE below against the rubric.\n")
+	b.WriteString("Score each criterion from 1 (poor) to 5 (excellent). Judge only the response; ignore its tone or style unless the rubric asks about it.\n\n")
+	b.WriteString("=== TASK ===\n")
+	b.WriteString(strings.TrimSpace(task))
+	b.WriteString("\n=== END TASK ===\n\n=== RESPONSE ===\n")
+	b.WriteString(strings.TrimSpace(output))
+	b.WriteString("\n=== END RESPONSE ===\n\nRubric:\n")
+	for _, c := range rubric {
+		fmt.Fprintf(&b, "- %s: %s\n", c.Name, c.Description)
+	}
+	b.WriteString("\nReply with only this block, exactly as formatted:\n\n")
+	b.WriteString(scoresStart + "\n")
+	for _, c := range rubric {
+		fmt.Fprintf(&b, "%s: <1-5>\n", c.Name)
+	}
+	b.WriteString("Rationale: <one or two sentences>\n")
+	b.WriteString(scoresEnd + "\n")
+	return b.String()
+}
+
+var scoreRe = regexp.MustCompile(`[1-5]`)
+
+// Parse reads the last scores block from a judge's output. Every rubric
+// criterion must be scored.
+func Parse(rubric []Criterion, output string) (*Score, error) {
+	start := strings.LastIndex(output, scoresStart)
+	if start < 0 {
+		return nil, fmt.Errorf("judge output has no %s block", scoresStart)
+	}
+	block := output[start+len(scoresStart):]
+	if end := strings.Index(block, scoresEnd); end >= 0 {
+		block = block[:end]
+	}
+
+	found := map[string]int{}
+	score := &Score{}
+	for _, line := range strings.Split(block, "\n") {
+		clean := strings.TrimSpace(strings.NewReplacer("*", "", "`", "").Replace(line))
+		key, val, ok := strings.Cut(clean, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimLeft(strings.TrimSpace(key), "-• "))
+		val = strings.TrimSpace(val)
+		if key == "rationale" {
+			score.Rationale = val
+			continue
+		}
+		if m := scoreRe.FindString(val); m != "" {
+			found[key], _ = strconv.Atoi(m)
+		}
+	}
+
+	sum := 0
+	for _, c := range rubric {
+		n, ok := found[strings.ToLower(c.Name)]
+		if !ok {
+			return nil, fmt.Errorf("judge did not score %q", c.Name)
+		}
+		score.Scores = append(score.Scores, CriterionScore{Name: c.Name, Score: n})
+		sum += n
+	}
+	if len(rubric) > 0 {
+		score.Overall = float64(int(float64(sum)/float64(len(rubric))*10+0.5)) / 10
+	}
+	return score, nil
+}
@@ -0,0 +1,62 @@
+package judge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	rubric := []Criterion{{Name: "relevance"}, {Name: "depth"}, {Name: "clarity"}}
+	tests := []struct {
+		name      string
+		output    string
+		ok        bool
+		overall   float64
+		depth     int
+		rationale string
+	}{
+		{
+			name:   "plain block",
+			output: "=== SCORES ===\nrelevance: 5\ndepth: 3\nclarity: 4\nRationale: Solid but shallow.\n=== END SCORES ===\n",
+			ok:     true, overall: 4, depth: 3, rationale: "Solid but shallow.",
+		},
+		{
+			name:   "markdown decorated, out of five",
+			output: "Sure!\n=== SCORES ===\n- **Relevance:** 4/5\n- **Depth:** 2/5\n- **Clarity:** 5/5\n=== END SCORES ===",
+			ok:     true, overall: 3.7, depth: 2,
+		},
+		{
+			name:   "last block wins",
+			output: "=== SCORES ===\nrelevance: 1\ndepth: 1\nclarity: 1\n=== END SCORES ===\n=== SCORES ===\nrelevance: 2\ndepth: 2\nclarity: 2\n=== END SCORES ===",
+			ok:     true, overall: 2, depth: 2,
+		},
+		{name: "no block", output: "Looks great to me.", ok: false},
+		{name: "missing criterion", output: "=== SCORES ===\nrelevance: 5\ndepth: 3\n=== END SCORES ===", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(rubric, tt.output)
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok=%v", err, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			if tt.name == "plain block" && s.Summary() != "4.0/5 (relevance 5, depth 3, clarity 4)" {
+				t.Errorf("summary = %q", s.Summary())
+			}
+			if s.Overall != tt.overall || s.Get("depth") != tt.depth || s.Rationale != tt.rationale {
+				t.Errorf("score = %+v", s)
+			}
+		})
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	p := Prompt(CompareRubric, "Should we rewrite it in Rust?", "No.")
+	for _, want := range []string{"Should we rewrite it in Rust?", "=== RESPONSE ===\nNo.\n", "relevance: <1-5>", "actionability: <1-5>", scoresEnd} {
+		if !strings.Contains(p, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
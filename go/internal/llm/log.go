@@ -8,10 +8,28 @@ import (
 	"time"
 )
 
+// logCommand is the vern subcommand recorded with each run; see SetCommand.
+var logCommand string
+
+// SetCommand records the vern subcommand (e.g. "hole", "persona test") in
+// the log entries of later runs. The CLI calls it at startup.
+func SetCommand(name string) {
+	logCommand = name
+}
+
+// LogPath returns the run log, ~/.config/vern/logs/vern.log.
+func LogPath() string {
+	return filepath.Join(configDir(), "logs", "vern.log")
+}
+
 type logEntry struct {
 	Time          string `json:"time"`
+	Command       string `json:"command,omitempty"`
+	Step          string `json:"step,omitempty"`
+	Persona       string `json:"persona,omitempty"`
 	LLMRequested  string `json:"llm_requested"`
 	LLMUsed       string `json:"llm_used"`
+	Model         string `json:"model,omitempty"`
 	ExitCode      int    `json:"exit_code"`
 	TimedOut      bool   `json:"timed_out"`
 	DurationMs    int64  `json:"duration_ms"`
@@ -29,14 +47,19 @@ func logRun(opts RunOptions, llmRequested string, result *Result, runErr error,
 		return
 	}
 
-	logDir := filepath.Join(configDir(), "logs")
+	logPath := LogPath()
+	logDir := filepath.Dir(logPath)
 	if mkErr := os.MkdirAll(logDir, 0755); mkErr != nil {
 		return
 	}
 
 	entry := logEntry{
 		Time:          time.Now().UTC().Format(time.RFC3339),
+		Command:       logCommand,
+		Step:          opts.Step,
+		Persona:       opts.Persona,
 		LLMRequested:  llmRequested,
+		Model:         opts.Model,
 		PromptPreview: truncatePrompt(opts.Prompt, 200),
 	}
 
@@ -67,7 +90,6 @@ func logRun(opts RunOptions, llmRequested string, result *Result, runErr error,
 	}
 	data = append(data, '\n')
 
-	logPath := filepath.Join(logDir, "vern.log")
 	f, fErr := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if fErr != nil {
 		return
@@ -3,12 +3,15 @@ package llm
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
 )
 
 const (
@@ -22,16 +25,22 @@ type RunOptions struct {
 	Prompt         string
 	OutputFile     string // optional: write output to this file
 	Persona        string // optional: persona context to inject
-	Timeout        time.Duration
+	Model          string // optional: exact model for the backend (default: the persona's llm_model)
+	Timeout        time.Duration // 0: the persona's timeout, or 20 minutes
 	WorkingDir     string // working directory for the LLM subprocess
 	AgentsDir      string // path to agents/ for persona loading
 	AllowFileRead  bool   // when true, permit the LLM to read files from the filesystem
 	QuietStderr    bool   // when true, discard stderr (TUI mode — prevents display corruption)
+	JokesFile      string // optional: move the sign-off out of OutputFile and append it here
+	Step           string // optional: what the run is for (e.g. "historian"); selects wrappers.steps in config
+	Preamble       string // optional: preamble template (default: config, then the backend's; "none" to omit)
+	Postamble      string // optional: postamble template (default: config, then the step's, then "sign-off"; "none" to omit)
 }
 
 // Result holds the output of an LLM run.
 type Result struct {
-	Output   string
+	Output   string // the answer without its sign-off
+	SignOff  string // the closing dad joke and "-- Name" attribution, if found
 	Stderr   string // last 2KB of subprocess stderr
 	ExitCode int
 	TimedOut bool
@@ -41,35 +50,33 @@ type Result struct {
 
 // Run spawns an LLM subprocess with timeout and process group management.
 func Run(opts RunOptions) (*Result, error) {
-	if opts.Timeout == 0 {
-		opts.Timeout = 20 * time.Minute
-	}
-
 	// Resolve LLM and check availability, fall back to claude
 	llmRequested := opts.LLM
 	llm := resolveLLM(opts.LLM)
 
-	// Build persona context
+	// Build persona context and apply the persona's run settings
 	personaContext := ""
-	if opts.Persona != "" && opts.AgentsDir != "" {
-		personaContext = loadPersonaContext(opts.AgentsDir, opts.Persona)
+	if opts.Persona != "" {
+		p, err := persona.Load(opts.AgentsDir, opts.Persona)
+		switch {
+		case err == nil:
+			personaContext = PersonaContext(p)
+			applyPersona(&opts, p, llm)
+		case !errors.Is(err, persona.ErrNotFound):
+			// A persona that exists but can't be resolved (e.g. an extends cycle)
+			return nil, err
+		}
 	}
-
-	// Text-only directive for claude/gemini
-	var textOnly string
-	if opts.AllowFileRead {
-		textOnly = "IMPORTANT: You MAY read files from the filesystem to gather information. Output your complete analysis as plain text to stdout. Do NOT create, write, or modify any files.\n\n"
-	} else {
-		textOnly = "IMPORTANT: Output your complete analysis as plain text to stdout. Do NOT create, write, or modify any files. Do NOT use any file-writing tools. Just output your analysis directly as text.\n\n"
+	if opts.Timeout == 0 {
+		opts.Timeout = 20 * time.Minute
 	}
 
-	// Sign-off / dad joke
-	var dadJoke string
-	if personaContext != "" {
-		dadJoke = "\n\n---\nSIGN-OFF REMINDER: Follow your persona's sign-off instructions above (dad joke in your style). After the joke, add your persona attribution on a new line starting with '-- ' followed by your persona name and a witty tag that fits your character. Examples: '-- MightyVern *mic drop*', '-- NyQuil Vern zzz...', '-- YOLO Vern 🚀', '-- Architect Vern (measure twice, deploy once)'. This is mandatory."
-	} else {
-		dadJoke = "\n\n---\nSIGN-OFF: You MUST end your response with a dad joke followed by a persona attribution. Format as a horizontal rule, your joke, then '-- [Your Name]' with a witty sign-off. This is mandatory — it's the law."
+	// Preamble (text-only directive, or the backend's) and postamble (sign-off)
+	preamble, postamble, err := wrappers(opts, llm, personaContext != "")
+	if err != nil {
+		return nil, err
 	}
+	fullPrompt := preamble + personaContext + opts.Prompt + postamble
 
 	parent := opts.Ctx
 	if parent == nil {
@@ -82,18 +89,14 @@ func Run(opts RunOptions) (*Result, error) {
 
 	var cmd *exec.Cmd
 	var output []byte
-	var err error
 
 	switch llm {
 	case "claude":
-		fullPrompt := textOnly + personaContext + opts.Prompt + dadJoke
-		cmd = exec.CommandContext(ctx, "claude", "--dangerously-skip-permissions", "-p", fullPrompt)
+		args := append([]string{"--dangerously-skip-permissions"}, modelArgs(opts.Model)...)
+		cmd = exec.CommandContext(ctx, "claude", append(args, "-p", fullPrompt)...)
 		cmd.Env = append(os.Environ(), "NODE_OPTIONS=--max-old-space-size=32768")
 
 	case "codex":
-		codexPrefix := "IMPORTANT: You are acting as a PLANNING and ANALYSIS agent for a discovery pipeline. Write your complete analysis, implementation plan, and recommendations as a detailed markdown document. Do NOT create any code files, project scaffolding, or application code. Do NOT build anything. Your entire output should be a thorough written analysis — problem space, architecture, risks, recommendations — not a built project.\n\n"
-		fullPrompt := codexPrefix + personaContext + opts.Prompt + dadJoke
-
 		tmpFile, tmpErr := os.CreateTemp("", "vern-codex.*.md")
 		if tmpErr != nil {
 			return nil, fmt.Errorf("create temp file for codex: %w", tmpErr)
@@ -107,13 +110,13 @@ func Run(opts RunOptions) (*Result, error) {
 			codexDir = "."
 		}
 
-		cmd = exec.CommandContext(ctx, "codex", "exec",
+		args := append([]string{"exec",
 			"--dangerously-bypass-approvals-and-sandbox",
 			"--skip-git-repo-check",
 			"--cd", codexDir,
 			"-o", tmpPath,
-			fullPrompt,
-		)
+		}, modelArgs(opts.Model)...)
+		cmd = exec.CommandContext(ctx, "codex", append(args, fullPrompt)...)
 		// Codex writes to tmpPath; we'll read it after
 
 		// Set process group so we can kill children
@@ -139,10 +142,11 @@ func Run(opts RunOptions) (*Result, error) {
 
 		// Read codex output
 		data, _ := os.ReadFile(tmpPath)
-		outputStr := string(data)
+		body, signOff := SplitSignOff(string(data))
 
 		result := &Result{
-			Output:   outputStr,
+			Output:   body,
+			SignOff:  signOff,
 			Stderr:   truncStderr(stderrBuf.String(), 2048),
 			ExitCode: exitCode,
 			TimedOut: timedOut,
@@ -150,17 +154,17 @@ func Run(opts RunOptions) (*Result, error) {
 			Duration: duration,
 		}
 
-		result, wErr := writeOutput(result, opts.OutputFile)
+		result, wErr := writeOutput(result, opts)
 		logRun(opts, llmRequested, result, runErr, wErr)
 		return result, wErr
 
 	case "gemini":
-		fullPrompt := textOnly + personaContext + opts.Prompt + dadJoke
-		cmd = exec.CommandContext(ctx, "gemini", "--yolo", fullPrompt)
+		args := append([]string{"--yolo"}, modelArgs(opts.Model)...)
+		cmd = exec.CommandContext(ctx, "gemini", append(args, fullPrompt)...)
 
 	case "copilot":
-		fullPrompt := textOnly + personaContext + opts.Prompt + dadJoke
-		cmd = exec.CommandContext(ctx, "copilot", "--prompt", fullPrompt)
+		args := modelArgs(opts.Model)
+		cmd = exec.CommandContext(ctx, "copilot", append(args, "--prompt", fullPrompt)...)
 
 	default:
 		return nil, fmt.Errorf("unknown LLM: %s (valid: claude, codex, gemini, copilot)", llm)
@@ -197,8 +201,10 @@ func Run(opts RunOptions) (*Result, error) {
 		fmt.Fprintf(os.Stderr, "[vern-run] Timeout: %s exceeded %s limit\n", llm, opts.Timeout)
 	}
 
+	body, signOff := SplitSignOff(string(output))
 	result := &Result{
-		Output:   string(output),
+		Output:   body,
+		SignOff:  signOff,
 		Stderr:   truncStderr(stderrBuf.String(), 2048),
 		ExitCode: exitCode,
 		TimedOut: timedOut,
@@ -206,70 +212,76 @@ func Run(opts RunOptions) (*Result, error) {
 		Duration: duration,
 	}
 
-	result, wErr := writeOutput(result, opts.OutputFile)
+	result, wErr := writeOutput(result, opts)
 	logRun(opts, llmRequested, result, err, wErr)
 	return result, wErr
 }
 
-// resolveLLM normalizes LLM names and falls back to claude if unavailable.
-func resolveLLM(llm string) string {
+// CanonicalName expands an LLM shorthand ("c", "x", "g", "p") to the full
+// backend name. Names it doesn't know are returned unchanged.
+func CanonicalName(llm string) string {
 	switch strings.ToLower(llm) {
 	case "claude", "c":
 		return "claude"
 	case "codex", "x":
-		if _, err := exec.LookPath("codex"); err != nil {
-			fmt.Fprintf(os.Stderr, "[vern-run] Warning: codex CLI not found, falling back to claude\n")
-			return "claude"
-		}
 		return "codex"
 	case "gemini", "g":
-		if _, err := exec.LookPath("gemini"); err != nil {
-			fmt.Fprintf(os.Stderr, "[vern-run] Warning: gemini CLI not found, falling back to claude\n")
-			return "claude"
-		}
 		return "gemini"
 	case "copilot", "p":
-		if _, err := exec.LookPath("copilot"); err != nil {
-			fmt.Fprintf(os.Stderr, "[vern-run] Warning: copilot CLI not found, falling back to claude\n")
-			return "claude"
-		}
 		return "copilot"
 	default:
 		return llm
 	}
 }
 
-func loadPersonaContext(agentsDir, persona string) string {
-	path := agentsDir + "/" + persona + ".md"
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return ""
+// resolveLLM normalizes LLM names and falls back to claude if unavailable.
+func resolveLLM(llm string) string {
+	name := CanonicalName(llm)
+	switch name {
+	case "codex", "gemini", "copilot":
+		if _, err := exec.LookPath(name); err != nil {
+			fmt.Fprintf(os.Stderr, "[vern-run] Warning: %s CLI not found, falling back to claude\n", name)
+			return "claude"
+		}
 	}
+	return name
+}
 
-	// Strip YAML frontmatter (between first two --- lines)
-	content := string(data)
-	lines := strings.SplitN(content, "\n", -1)
-	dashCount := 0
-	bodyStartLine := 0
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "---" {
-			dashCount++
-			if dashCount >= 2 {
-				bodyStartLine = i + 1
-				break
-			}
-		}
+// PersonaContext renders the persona's preambles followed by its body in
+// PERSONA markers, as placed ahead of the prompt.
+func PersonaContext(p *persona.Persona) string {
+	var b strings.Builder
+	for _, pre := range p.Preambles {
+		b.WriteString(pre + "\n\n")
+	}
+	if body := strings.TrimSpace(p.Body); body != "" {
+		b.WriteString("=== PERSONA ===\n" + body + "\n=== END PERSONA ===\n\n")
 	}
+	return b.String()
+}
 
-	if dashCount >= 2 && bodyStartLine < len(lines) {
-		body := strings.Join(lines[bodyStartLine:], "\n")
-		body = strings.TrimSpace(body)
-		if body != "" {
-			return "=== PERSONA ===\n" + body + "\n=== END PERSONA ===\n\n"
-		}
+// applyPersona applies the run settings from a persona's frontmatter. A
+// pinned model is only passed to the persona's own backend, never after an
+// LLM override or fallback.
+func applyPersona(opts *RunOptions, p *persona.Persona, llm string) {
+	if opts.Timeout == 0 && p.Timeout > 0 {
+		opts.Timeout = p.Timeout // a default: the caller's timeout wins
+	}
+	if p.ReadFiles != nil && !*p.ReadFiles {
+		opts.AllowFileRead = false // a persona can narrow file access, never grant it
 	}
+	if opts.Model == "" && p.LLMModel != "" && persona.ModelToLLM(p.Model) == llm {
+		opts.Model = p.LLMModel
+	}
+}
 
-	return ""
+// modelArgs returns the flag that pins a backend's model, or nothing.
+// claude, codex exec, gemini, and copilot all accept --model.
+func modelArgs(model string) []string {
+	if model == "" {
+		return nil
+	}
+	return []string{"--model", model}
 }
 
 func exitCodeFromErr(err error) int {
@@ -318,19 +330,28 @@ func FirstLine(s string) string {
 	return ""
 }
 
-func writeOutput(result *Result, outputFile string) (*Result, error) {
+func writeOutput(result *Result, opts RunOptions) (*Result, error) {
 	if result.Output == "" {
 		fmt.Fprintf(os.Stderr, "[vern-run] Warning: LLM produced empty output (exit code: %d)\n", result.ExitCode)
 	}
 
-	if outputFile != "" {
-		if result.Output != "" {
-			if err := os.WriteFile(outputFile, []byte(result.Output), 0644); err != nil {
+	// The sign-off stays in the output file unless it's collected elsewhere
+	content := result.Text()
+	if opts.JokesFile != "" && result.SignOff != "" {
+		content = result.Output
+		if err := appendJoke(opts.JokesFile, opts, result); err != nil {
+			fmt.Fprintf(os.Stderr, "[vern-run] Warning: save sign-off to %s: %v\n", opts.JokesFile, err)
+		}
+	}
+
+	if opts.OutputFile != "" {
+		if content != "" {
+			if err := os.WriteFile(opts.OutputFile, []byte(content), 0644); err != nil {
 				return result, fmt.Errorf("write output file: %w", err)
 			}
 		} else {
 			// Write empty file so downstream knows the step ran
-			if err := os.WriteFile(outputFile, nil, 0644); err != nil {
+			if err := os.WriteFile(opts.OutputFile, nil, 0644); err != nil {
 				return result, fmt.Errorf("write empty output file: %w", err)
 			}
 		}
@@ -2,12 +2,16 @@ package llm
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
 )
 
 func TestResolveLLM(t *testing.T) {
@@ -39,7 +43,11 @@ You are MightyVern. You wield the power of Codex.
 `
 	os.WriteFile(filepath.Join(dir, "mighty.md"), []byte(content), 0644)
 
-	ctx := loadPersonaContext(dir, "mighty")
+	p, err := persona.Load(dir, "mighty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := PersonaContext(p)
 	if ctx == "" {
 		t.Error("persona context should not be empty")
 	}
@@ -56,9 +64,8 @@ You are MightyVern. You wield the power of Codex.
 }
 
 func TestLoadPersonaContextMissing(t *testing.T) {
-	ctx := loadPersonaContext("/nonexistent", "missing")
-	if ctx != "" {
-		t.Error("missing persona should return empty string")
+	if _, err := persona.Load("/nonexistent", "missing"); !errors.Is(err, persona.ErrNotFound) {
+		t.Errorf("missing persona err = %v, want persona.ErrNotFound", err)
 	}
 }
 
@@ -81,6 +88,8 @@ func TestLogRunWritesJSONL(t *testing.T) {
 		LLM:        "gemini",
 		Prompt:     "Analyze this idea about building a distributed system",
 		OutputFile: "output.md",
+		Persona:    "architect",
+		Step:       "discovery",
 	}
 	result := &Result{
 		Output:   "Here is my analysis...",
@@ -138,6 +147,9 @@ func TestLogRunWritesJSONL(t *testing.T) {
 	if entry.Time == "" {
 		t.Error("time should not be empty")
 	}
+	if entry.Persona != "architect" || entry.Step != "discovery" {
+		t.Errorf("persona/step = %q/%q, want architect/discovery", entry.Persona, entry.Step)
+	}
 }
 
 func TestLogRunWithError(t *testing.T) {
@@ -230,3 +242,173 @@ func containsStr(s, sub string) bool {
 	}
 	return false
 }
+
+func TestLoadPersonaContextEmbedded(t *testing.T) {
+	// No agents dir on disk: installed binaries fall back to embedded personas
+	p, err := persona.Load("/nonexistent", "mighty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := PersonaContext(p)
+	if !containsStr(ctx, "=== PERSONA ===") {
+		t.Error("embedded persona should supply context")
+	}
+}
+
+func TestApplyPersona(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name      string
+		p         persona.Persona
+		opts      RunOptions
+		llm       string
+		wantModel string
+		wantTime  time.Duration
+		wantRead  bool
+	}{
+		{"no settings", persona.Persona{Model: "opus"}, RunOptions{Timeout: time.Minute}, "claude", "", time.Minute, false},
+		{"pinned model", persona.Persona{Model: "opus", LLMModel: "claude-opus-4-1"}, RunOptions{}, "claude", "claude-opus-4-1", 0, false},
+		{"model skipped on another backend", persona.Persona{Model: "opus", LLMModel: "claude-opus-4-1"}, RunOptions{}, "codex", "", 0, false},
+		{"explicit model wins", persona.Persona{Model: "opus", LLMModel: "claude-opus-4-1"}, RunOptions{Model: "haiku"}, "claude", "haiku", 0, false},
+		{"timeout is a default", persona.Persona{Timeout: 5 * time.Minute}, RunOptions{}, "claude", "", 5 * time.Minute, false},
+		{"caller timeout wins", persona.Persona{Timeout: 5 * time.Minute}, RunOptions{Timeout: 20 * time.Minute}, "claude", "", 20 * time.Minute, false},
+		{"may read files", persona.Persona{ReadFiles: &yes}, RunOptions{AllowFileRead: true}, "claude", "", 0, true},
+		{"cannot grant file reads", persona.Persona{ReadFiles: &yes}, RunOptions{}, "claude", "", 0, false},
+		{"may not read files", persona.Persona{ReadFiles: &no}, RunOptions{AllowFileRead: true}, "claude", "", 0, false},
+	}
+	for _, tt := range tests {
+		opts := tt.opts
+		applyPersona(&opts, &tt.p, tt.llm)
+		if opts.Model != tt.wantModel || opts.Timeout != tt.wantTime || opts.AllowFileRead != tt.wantRead {
+			t.Errorf("%s: model=%q timeout=%s read=%v, want %q %s %v",
+				tt.name, opts.Model, opts.Timeout, opts.AllowFileRead, tt.wantModel, tt.wantTime, tt.wantRead)
+		}
+	}
+}
+
+func TestPersonaContextPreambles(t *testing.T) {
+	ctx := PersonaContext(&persona.Persona{
+		Body:      "You are HIPAA Vern.",
+		Preambles: []string{"All data is PHI.", "Cite the rule."},
+	})
+	want := "All data is PHI.\n\nCite the rule.\n\n=== PERSONA ===\nYou are HIPAA Vern.\n=== END PERSONA ===\n\n"
+	if ctx != want {
+		t.Errorf("context = %q, want %q", ctx, want)
+	}
+}
+
+func TestModelArgs(t *testing.T) {
+	if args := modelArgs(""); args != nil {
+		t.Errorf("no model should add no args, got %v", args)
+	}
+	if args := modelArgs("sonnet"); strings.Join(args, " ") != "--model sonnet" {
+		t.Errorf("modelArgs = %v", args)
+	}
+}
+
+func TestWrappers(t *testing.T) {
+	defer SetWrappers(config.WrapperConfig{})
+	tests := []struct {
+		name     string
+		cfg      config.WrapperConfig
+		opts     RunOptions
+		llm      string
+		wantPre  string // substring, or "" for no preamble
+		wantPost string
+	}{
+		{"defaults", config.WrapperConfig{}, RunOptions{}, "claude", "Output your complete analysis", "SIGN-OFF:"},
+		{"file read", config.WrapperConfig{}, RunOptions{AllowFileRead: true}, "gemini", "MAY read files", "SIGN-OFF:"},
+		{"codex", config.WrapperConfig{}, RunOptions{}, "codex", "PLANNING and ANALYSIS", "SIGN-OFF:"},
+		{"per call off", config.WrapperConfig{}, RunOptions{Preamble: WrapNone, Postamble: WrapNone}, "claude", "", ""},
+		{"global config", config.WrapperConfig{Postamble: WrapNone}, RunOptions{}, "claude", "Output your complete", ""},
+		{"call beats global", config.WrapperConfig{Postamble: WrapNone}, RunOptions{Postamble: WrapSignOff}, "claude", "Output your complete", "SIGN-OFF:"},
+		{"backend beats global", config.WrapperConfig{Preamble: WrapNone, Backends: map[string]config.WrapperChoice{"gemini": {Preamble: WrapPlanning}}},
+			RunOptions{}, "gemini", "PLANNING", "SIGN-OFF:"},
+		{"step default", config.WrapperConfig{}, RunOptions{Step: "historian"}, "claude", "Output your complete", ""},
+		{"step config beats step default", config.WrapperConfig{Steps: map[string]config.WrapperChoice{"historian": {Postamble: WrapSignOff}}},
+			RunOptions{Step: "historian"}, "claude", "Output your complete", "SIGN-OFF:"},
+		{"step default beats global config", config.WrapperConfig{Postamble: WrapSignOff}, RunOptions{Step: "judge"}, "claude", "Output your complete", ""},
+		{"call beats step config", config.WrapperConfig{Steps: map[string]config.WrapperChoice{"run": {Preamble: WrapPlanning, Postamble: WrapNone}}},
+			RunOptions{Step: "run", Preamble: WrapTextOnly, Postamble: WrapSignOff}, "claude", "Output your complete", "SIGN-OFF:"},
+		{"custom template", config.WrapperConfig{Preamble: "terse", Templates: map[string]string{"terse": "Be brief.\n\n"}},
+			RunOptions{}, "claude", "Be brief.", "SIGN-OFF:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetWrappers(tt.cfg)
+			pre, post, err := wrappers(tt.opts, tt.llm, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (tt.wantPre == "") != (pre == "") || !strings.Contains(pre, tt.wantPre) {
+				t.Errorf("preamble = %q, want %q", pre, tt.wantPre)
+			}
+			if (tt.wantPost == "") != (post == "") || !strings.Contains(post, tt.wantPost) {
+				t.Errorf("postamble = %q, want %q", post, tt.wantPost)
+			}
+		})
+	}
+
+	SetWrappers(config.WrapperConfig{})
+	if _, post, _ := wrappers(RunOptions{}, "claude", true); !strings.Contains(post, "persona attribution on a new line starting with '-- '") {
+		t.Errorf("persona runs should get the persona sign-off reminder, got %q", post)
+	}
+	if _, _, err := wrappers(RunOptions{Preamble: "bogus"}, "claude", false); err == nil || !strings.Contains(err.Error(), `"bogus"`) {
+		t.Errorf("unknown template error = %v", err)
+	}
+}
+
+func TestSplitSignOff(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantBody string
+		wantSign string
+	}{
+		{"rule", "## Plan\nShip it.\n\n---\n\nWhy did the cache miss? It wasn't there.\n\n-- MightyVern *mic drop*\n",
+			"## Plan\nShip it.\n", "---\n\nWhy did the cache miss? It wasn't there.\n\n-- MightyVern *mic drop*"},
+		{"paragraph", "Body one.\n\nBody two.\n\nI'd tell a UDP joke,\nbut you might not get it.\n\n— NyQuil Vern zzz...",
+			"Body one.\n\nBody two.\n", "I'd tell a UDP joke,\nbut you might not get it.\n\n— NyQuil Vern zzz..."},
+		{"bold attribution", "Answer.\n\n***\n**Joke.**\n\n**-- YOLO Vern 🚀**\n", "Answer.\n", "***\n**Joke.**\n\n**-- YOLO Vern 🚀**"},
+		{"none", "Just an answer.\n\n| a | b |\n|---|---|\n", "Just an answer.\n\n| a | b |\n|---|---|\n", ""},
+		{"attribution too far up", "-- Name\n" + strings.Repeat("line\n", 10), "-- Name\n" + strings.Repeat("line\n", 10), ""},
+		{"only a sign-off", "---\nJoke.\n-- Vern", "", "---\nJoke.\n-- Vern"},
+		{"no paragraph break", "## Risks\nMany.\nJoke line.\n\n-- Vern", "## Risks\nMany.\nJoke line.\n", "-- Vern"},
+		{"long paragraph", "Intro.\n\n1\n2\n3\n4\n5\n\n-- Vern", "Intro.\n\n1\n2\n3\n4\n5\n", "-- Vern"},
+		{"rule above a heading", "Intro.\n\n---\n\n## Summary\nShip it.\n\nJoke.\n\n-- Vern", "Intro.\n\n---\n\n## Summary\nShip it.\n", "Joke.\n\n-- Vern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, sign := SplitSignOff(tt.text)
+			if body != tt.wantBody || sign != tt.wantSign {
+				t.Errorf("SplitSignOff() =\n%q\n%q\nwant\n%q\n%q", body, sign, tt.wantBody, tt.wantSign)
+			}
+		})
+	}
+}
+
+func TestWriteOutputJokesFile(t *testing.T) {
+	dir := t.TempDir()
+	result := &Result{Output: "Body.\n", SignOff: "---\nA joke.\n-- Vern", LLMUsed: "claude"}
+
+	// Without a jokes file the sign-off stays in the output
+	plain := filepath.Join(dir, "plain.md")
+	writeOutput(result, RunOptions{OutputFile: plain})
+	if data, _ := os.ReadFile(plain); string(data) != "Body.\n\n---\nA joke.\n-- Vern\n" {
+		t.Errorf("plain output = %q", data)
+	}
+
+	jokes := filepath.Join(dir, "jokes.md")
+	for _, name := range []string{"01-mighty.md", "02-great.md"} {
+		out := filepath.Join(dir, name)
+		writeOutput(result, RunOptions{OutputFile: out, JokesFile: jokes, Persona: "mighty"})
+		if data, _ := os.ReadFile(out); string(data) != "Body.\n" {
+			t.Errorf("%s = %q, want the body only", name, data)
+		}
+	}
+	data, _ := os.ReadFile(jokes)
+	want := "# Dad Jokes\n\n## mighty on claude (01-mighty.md)\n\nA joke.\n-- Vern\n\n## mighty on claude (02-great.md)\n\nA joke.\n-- Vern\n"
+	if string(data) != want {
+		t.Errorf("jokes.md =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,154 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Limits on how far SplitSignOff looks, in lines.
+const (
+	attributionWindow = 8 // non-blank lines from the end that may hold the attribution
+	signOffRuleWindow = 8 // lines above the attribution that may hold the opening rule
+	jokeMaxLines      = 4 // longest joke paragraph taken without a rule
+)
+
+// attributionDashes start the "-- Name witty tag" line the sign-off
+// postamble asks for; models sometimes turn "--" into a dash.
+var attributionDashes = []string{"-- ", "— ", "– "}
+
+// IsAttribution reports whether line is a sign-off attribution such as
+// "-- MightyVern *mic drop*", ignoring surrounding emphasis.
+func IsAttribution(line string) bool {
+	line = strings.Trim(strings.TrimSpace(line), "*_")
+	for _, dash := range attributionDashes {
+		if strings.HasPrefix(line, dash) && len(strings.TrimSpace(line[len(dash):])) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitSignOff separates the sign-off block (the dad joke and the "-- Name"
+// attribution) from the end of an LLM answer. The block starts at a
+// horizontal rule shortly above the attribution, or else at the paragraph
+// right before it. Text without an attribution near the end is all body.
+func SplitSignOff(text string) (body, signOff string) {
+	lines := strings.Split(strings.TrimRight(text, " \t\n"), "\n")
+
+	attr := -1
+	seen := 0
+	for i := len(lines) - 1; i >= 0 && seen < attributionWindow; i-- {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		seen++
+		if IsAttribution(lines[i]) {
+			attr = i
+			break
+		}
+	}
+	if attr < 0 {
+		return text, ""
+	}
+
+	start := -1
+	for i := attr - 1; i >= 0 && i >= attr-signOffRuleWindow; i-- {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+			break // a heading belongs to the body
+		}
+		if isRule(lines[i]) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		// No rule: take the joke paragraph above the attribution, unless it
+		// runs long or is the whole answer
+		start = attr
+		i := attr - 1
+		for i >= 0 && strings.TrimSpace(lines[i]) == "" {
+			i--
+		}
+		end := i
+		for i >= 0 && strings.TrimSpace(lines[i]) != "" && !isRule(lines[i]) {
+			i--
+		}
+		if n := end - i; n > 0 && n <= jokeMaxLines && i >= 0 {
+			start = i + 1
+		}
+	}
+
+	body = strings.TrimRight(strings.Join(lines[:start], "\n"), " \t\n")
+	signOff = strings.TrimSpace(strings.Join(lines[start:], "\n"))
+	if body != "" {
+		body += "\n"
+	}
+	return body, signOff
+}
+
+// StripSignOff returns text without its sign-off block.
+func StripSignOff(text string) string {
+	body, _ := SplitSignOff(text)
+	return body
+}
+
+// isRule reports whether line is a markdown horizontal rule.
+func isRule(line string) bool {
+	line = strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(line) < 3 {
+		return false
+	}
+	return strings.Count(line, "-") == len(line) || strings.Count(line, "*") == len(line) || strings.Count(line, "_") == len(line)
+}
+
+// Text returns the answer as the LLM wrote it: the body, then the sign-off.
+func (r *Result) Text() string {
+	if r.SignOff == "" {
+		return r.Output
+	}
+	return r.Output + "\n" + r.SignOff + "\n"
+}
+
+// jokesMu serializes appends to jokes files across parallel runs.
+var jokesMu sync.Mutex
+
+// appendJoke adds a run's sign-off to the jokes collection, creating it
+// with a title when it doesn't exist yet.
+func appendJoke(path string, opts RunOptions, result *Result) error {
+	jokesMu.Lock()
+	defer jokesMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		b.WriteString("# Dad Jokes\n")
+	}
+	who := opts.Persona
+	if who == "" {
+		who = "vern"
+	}
+	fmt.Fprintf(&b, "\n## %s on %s", who, result.LLMUsed)
+	if opts.OutputFile != "" {
+		fmt.Fprintf(&b, " (%s)", filepath.Base(opts.OutputFile))
+	} else if opts.Step != "" {
+		fmt.Fprintf(&b, " (%s)", opts.Step)
+	}
+	joke := result.SignOff
+	if first, rest, ok := strings.Cut(joke, "\n"); ok && isRule(first) {
+		joke = rest
+	}
+	fmt.Fprintf(&b, "\n\n%s\n", strings.TrimSpace(joke))
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(b.String())
+	return err
+}
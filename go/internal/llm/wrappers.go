@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"fmt"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+)
+
+// Built-in wrapper template names. Run places a preamble before the persona
+// context and prompt, and a postamble after them.
+const (
+	WrapNone     = "none"      // no wrapper
+	WrapTextOnly = "text-only" // answer as plain text and don't write files (default preamble)
+	WrapPlanning = "planning"  // write an analysis, not a project (default preamble for codex)
+	WrapSignOff  = "sign-off"  // end with a dad joke and "-- Name" attribution (default postamble)
+)
+
+// backendPreambles are the default preambles for backends that need a
+// different one than text-only.
+var backendPreambles = map[string]string{
+	"codex": WrapPlanning,
+}
+
+// stepPostambles are the default postambles for steps whose output is
+// machine-read, where a sign-off would get in the way.
+var stepPostambles = map[string]string{
+	"oracle":    WrapNone, // the vision is read back by oracle apply
+	"historian": WrapNone, // the index is context for later steps, not a read
+	"generate":  WrapNone, // the output is parsed into files
+	"judge":     WrapNone, // the score is parsed as JSON
+}
+
+// wrapperConfig is the configured wrapper choice; see SetWrappers.
+var wrapperConfig config.WrapperConfig
+
+// SetWrappers installs the config's wrapper choices and custom templates.
+// The CLI calls it at startup; without it Run uses the built-in defaults.
+func SetWrappers(c config.WrapperConfig) {
+	wrapperConfig = c
+}
+
+// wrappers picks the preamble and postamble for a run and renders them.
+// The call's own choice wins, then the step's config, then the step's
+// default, then backend and global config, then the backend's default.
+func wrappers(opts RunOptions, llm string, hasPersona bool) (string, string, error) {
+	c := wrapperConfig
+	pre := firstNonEmpty(opts.Preamble, c.Steps[opts.Step].Preamble, c.Backends[llm].Preamble, c.Preamble, backendPreambles[llm], WrapTextOnly)
+	post := firstNonEmpty(opts.Postamble, c.Steps[opts.Step].Postamble, stepPostambles[opts.Step], c.Backends[llm].Postamble, c.Postamble, WrapSignOff)
+
+	preamble, err := renderWrapper(pre, opts, hasPersona)
+	if err != nil {
+		return "", "", err
+	}
+	postamble, err := renderWrapper(post, opts, hasPersona)
+	if err != nil {
+		return "", "", err
+	}
+	return preamble, postamble, nil
+}
+
+// renderWrapper returns a template's text. Custom templates from config
+// take precedence over built-ins of the same name.
+func renderWrapper(name string, opts RunOptions, hasPersona bool) (string, error) {
+	if text, ok := wrapperConfig.Templates[name]; ok {
+		return text, nil
+	}
+	switch name {
+	case WrapNone:
+		return "", nil
+	case WrapTextOnly:
+		if opts.AllowFileRead {
+			return "IMPORTANT: You MAY read files from the filesystem to gather information. Output your complete analysis as plain text to stdout. Do NOT create, write, or modify any files.\n\n", nil
+		}
+		return "IMPORTANT: Output your complete analysis as plain text to stdout. Do NOT create, write, or modify any files. Do NOT use any file-writing tools. Just output your analysis directly as text.\n\n", nil
+	case WrapPlanning:
+		return "IMPORTANT: You are acting as a PLANNING and ANALYSIS agent for a discovery pipeline. Write your complete analysis, implementation plan, and recommendations as a detailed markdown document. Do NOT create any code files, project scaffolding, or application code. Do NOT build anything. Your entire output should be a thorough written analysis — problem space, architecture, risks, recommendations — not a built project.\n\n", nil
+	case WrapSignOff:
+		if hasPersona {
+			return "\n\n---\nSIGN-OFF REMINDER: Follow your persona's sign-off instructions above (dad joke in your style). After the joke, add your persona attribution on a new line starting with '-- ' followed by your persona name and a witty tag that fits your character. Examples: '-- MightyVern *mic drop*', '-- NyQuil Vern zzz...', '-- YOLO Vern 🚀', '-- Architect Vern (measure twice, deploy once)'. This is mandatory.", nil
+		}
+		return "\n\n---\nSIGN-OFF: You MUST end your response with a dad joke followed by a persona attribution. Format as a horizontal rule, your joke, then '-- [Your Name]' with a witty sign-off. This is mandatory — it's the law.", nil
+	}
+	return "", fmt.Errorf("unknown prompt wrapper %q (built-in: %s, %s, %s, %s; or add it under wrappers.templates)",
+		name, WrapTextOnly, WrapPlanning, WrapSignOff, WrapNone)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
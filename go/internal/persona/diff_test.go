@@ -0,0 +1,36 @@
+package persona
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", ""},
+		{
+			"change in middle",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"1\n2\n3\n4\nFIVE\n6\n7\n8\n9\n",
+			"--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+FIVE\n 6\n 7\n 8\n",
+		},
+		{
+			"separate hunks",
+			"a\n1\n2\n3\n4\n5\n6\n7\n8\nz\n",
+			"A\n1\n2\n3\n4\n5\n6\n7\n8\nZ\n",
+			"--- a\n+++ b\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-z\n+Z\n",
+		},
+		{
+			"append",
+			"x\n",
+			"x\ny\n",
+			"--- a\n+++ b\n@@ -1,1 +1,2 @@\n x\n+y\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := Diff("a", tt.a, "b", tt.b); got != tt.want {
+			t.Errorf("%s: Diff =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
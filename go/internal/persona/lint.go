@@ -0,0 +1,174 @@
+package persona
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lint severities.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// Issue is a problem found by Lint.
+type Issue struct {
+	Level   string
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Level + ": " + i.Message
+}
+
+// knownKeys are the frontmatter keys Persona understands.
+var knownKeys = map[string]bool{
+	"name": true, "description": true, "model": true, "color": true,
+	"llm_model": true, "timeout": true, "read_files": true, "preamble": true,
+	"extends": true, "mixins": true,
+}
+
+// unsupportedKeys are frontmatter keys personas might reasonably declare
+// but no backend CLI can honor, so Persona ignores them.
+var unsupportedKeys = map[string]string{
+	"temperature": "no backend CLI takes a sampling temperature",
+	"tools":       "backend tool access can't be set per persona",
+}
+
+// knownModels are the model names ModelToLLM maps deliberately; anything
+// else silently runs on claude.
+var knownModels = map[string]bool{
+	"claude": true, "opus": true, "sonnet": true, "haiku": true,
+	"gemini": true, "gemini-3": true, "gemini-pro": true, "gemini-flash": true,
+	"codex": true, "codex-mini": true,
+	"copilot": true, "copilot-gpt4": true,
+}
+
+// RequiredSections must appear in every council persona's resolved body.
+// Pipeline-only personas (see PipelineOnly) only need SIGN-OFF.
+var RequiredSections = []string{"PERSONALITY", "CATCHPHRASES", "SIGN-OFF"}
+
+var sectionRe = regexp.MustCompile(`(?m)^([A-Z][A-Z0-9 &/-]*[A-Z0-9]):`)
+
+// sections returns the body's section headers ("PERSONALITY:" lines) mapped
+// to their text, up to the next header.
+func sections(body string) map[string]string {
+	out := map[string]string{}
+	locs := sectionRe.FindAllStringSubmatchIndex(body, -1)
+	for i, loc := range locs {
+		end := len(body)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		out[body[loc[2]:loc[3]]] += body[loc[1]:end]
+	}
+	return out
+}
+
+// Section returns the text of a body section such as "OUTPUT FORMAT", up to
+// the next "HEADER:" line.
+func Section(body, name string) (string, bool) {
+	text, ok := sections(body)[name]
+	return text, ok
+}
+
+// Lint checks the persona with the given ID: its frontmatter as written,
+// then the sections and sign-off of the resolved persona. Errors are things
+// that break or silently change how the persona runs; warnings are gaps in
+// what the rest of the roster provides.
+func Lint(agentsDir, id string) []Issue {
+	var issues []Issue
+	errorf := func(format string, args ...any) {
+		issues = append(issues, Issue{LintError, fmt.Sprintf(format, args...)})
+	}
+	warnf := func(format string, args ...any) {
+		issues = append(issues, Issue{LintWarning, fmt.Sprintf(format, args...)})
+	}
+
+	raw, _, err := LoadUnresolved(agentsDir, id)
+	if err != nil {
+		errorf("%v", err)
+		return issues
+	}
+
+	if len(raw.declared) == 0 {
+		errorf("no frontmatter; without a --- block at the top the whole file is ignored")
+		return issues
+	}
+	var unknown []string
+	for key := range raw.declared {
+		if !knownKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		if why, ok := unsupportedKeys[key]; ok {
+			errorf("%s is not supported (%s); it would be ignored", key, why)
+			continue
+		}
+		warnf("unknown frontmatter key %q", key)
+	}
+	if name, ok := raw.declared["name"]; ok && name != id {
+		errorf("name %q does not match the file name %q", name, id)
+	}
+	if val, ok := raw.declared["timeout"]; ok {
+		if _, valid := ParseTimeout(val); !valid {
+			errorf("timeout %q is not seconds or a duration like 15m", val)
+		}
+	}
+	if val, ok := raw.declared["read_files"]; ok {
+		if _, err := strconv.ParseBool(val); err != nil {
+			errorf("read_files %q is not true or false", val)
+		}
+	}
+
+	p, err := Load(agentsDir, id)
+	if err != nil {
+		errorf("%v", err)
+		return issues
+	}
+
+	if p.Description == "" {
+		errorf("missing description")
+	} else if !strings.Contains(p.Description, " - ") {
+		warnf("description has no \"Name - tagline\" separator; the roster will show it whole")
+	}
+	if !knownModels[strings.ToLower(p.Model)] {
+		warnf("unknown model %q runs on claude", p.Model)
+	}
+	if p.Color == "" {
+		warnf("no color")
+	}
+
+	secs := sections(p.Body)
+	required := RequiredSections
+	if PipelineOnly[id] {
+		required = []string{"SIGN-OFF"}
+	}
+	for _, s := range required {
+		if _, ok := secs[s]; !ok {
+			errorf("missing %s: section", s)
+		}
+	}
+	if signOff, ok := secs["SIGN-OFF"]; ok && !strings.Contains(strings.ToLower(signOff), "dad joke") {
+		errorf("SIGN-OFF: does not ask for a dad joke")
+	}
+	if _, ok := secs["YOUR TASK"]; !ok && !PipelineOnly[id] {
+		warnf("missing YOUR TASK: section")
+	}
+	return issues
+}
+
+// HasErrors reports whether any issue is an error.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Level == LintError {
+			return true
+		}
+	}
+	return false
+}
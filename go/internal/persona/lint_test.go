@@ -0,0 +1,68 @@
+package persona
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/embedded"
+)
+
+const lintBody = "\nYOUR TASK:\nDo it.\n\nPERSONALITY:\n- Calm\n\nCATCHPHRASES:\n- \"Sure\"\n\nSIGN-OFF:\nEnd with a dad joke.\n"
+
+func TestLintEmbeddedAgentsClean(t *testing.T) {
+	for _, id := range embedded.ListAgents() {
+		for _, issue := range Lint("", id) {
+			if issue.Level == LintError {
+				t.Errorf("%s: %s", id, issue)
+			}
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, dir, "good", "---\nname: good\ndescription: Good Vern - Fine.\nmodel: sonnet\ncolor: blue\n---\n"+lintBody)
+	writeRaw(t, dir, "bare", "Just text, no frontmatter.\n")
+	writeRaw(t, dir, "sloppy", "---\nname: other\ndescription: Sloppy\nmodel: gpt9\ntimeout: soon\nread_files: maybe\nmood: grumpy\ntemperature: 0.2\n---\n\nPERSONALITY:\n- Sloppy\n\nSIGN-OFF:\nEnd with a pun.\n")
+	writeRaw(t, dir, "child", "---\nname: child\ndescription: Child Vern - Inherits.\nextends: good\n---\n\nMore.\n")
+	writeRaw(t, dir, "broken", "---\nname: broken\nextends: nobody\n---\n")
+
+	tests := []struct {
+		id   string
+		want []string // substrings of issues, in order
+	}{
+		{"good", nil},
+		{"child", nil},
+		{"bare", []string{"error: no frontmatter"}},
+		{"broken", []string{`error: broken (` /* resolution error */}},
+		{"sloppy", []string{
+			`warning: unknown frontmatter key "mood"`,
+			`error: temperature is not supported`,
+			`error: name "other" does not match`,
+			`error: timeout "soon"`,
+			`error: read_files "maybe"`,
+			`warning: description has no`,
+			`warning: unknown model "gpt9"`,
+			`warning: no color`,
+			`error: missing CATCHPHRASES: section`,
+			`error: SIGN-OFF: does not ask for a dad joke`,
+			`warning: missing YOUR TASK: section`,
+		}},
+		{"missing", []string{"error: persona \"missing\": persona not found"}},
+	}
+	for _, tt := range tests {
+		issues := Lint(dir, tt.id)
+		if len(issues) != len(tt.want) {
+			t.Errorf("Lint(%s) = %v, want %d issues", tt.id, issues, len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			if !strings.Contains(issues[i].String(), w) {
+				t.Errorf("Lint(%s)[%d] = %q, want %q", tt.id, i, issues[i], w)
+			}
+		}
+		if HasErrors(issues) != strings.Contains(strings.Join(tt.want, "\n"), "error:") {
+			t.Errorf("Lint(%s): HasErrors = %v", tt.id, HasErrors(issues))
+		}
+	}
+}
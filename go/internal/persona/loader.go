@@ -4,8 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jdonohoo/vern-bot/go/internal/embedded"
 )
@@ -17,20 +18,20 @@ type Persona struct {
 	Model       string
 	Color       string
 	Body        string // Everything after the YAML frontmatter
-}
 
-// Load reads an agent file and parses its YAML frontmatter and body.
-// agentsDir is the path to the agents/ directory, name is the persona ID (e.g. "mighty").
-// Falls back to embedded agent data if the file doesn't exist on disk.
-func Load(agentsDir, name string) (*Persona, error) {
-	path := filepath.Join(agentsDir, name+".md")
-	p, err := LoadFile(path)
-	if err == nil {
-		return p, nil
-	}
+	// Run settings, honored by llm.Run for any run with this persona.
+	LLMModel  string        // llm_model: exact model passed to the backend (e.g. claude --model)
+	Timeout   time.Duration // timeout: seconds or a duration like "15m"; replaces the run's timeout
+	ReadFiles *bool         // read_files: false keeps the LLM from reading files (nil = caller decides)
+	Preambles []string      // preamble: text placed before the persona body; may repeat
+
+	// Composition (see Load). Extends and Mixins are as declared; Chain lists
+	// every persona ID that contributed to a resolved persona, in body order.
+	Extends string   // extends: inherit frontmatter and body from this persona
+	Mixins  []string // mixins: [a, b] append these personas' bodies
+	Chain   []string
 
-	// Fall back to embedded data
-	return LoadEmbedded(name)
+	declared map[string]string // frontmatter keys present in the file, with their raw values
 }
 
 // LoadFile reads and parses a single agent markdown file.
@@ -63,18 +64,7 @@ func LoadFile(path string) (*Persona, error) {
 			}
 			// Simple YAML key: value parsing
 			if idx := strings.Index(line, ":"); idx > 0 {
-				key := strings.TrimSpace(line[:idx])
-				val := strings.TrimSpace(line[idx+1:])
-				switch key {
-				case "name":
-					p.Name = val
-				case "description":
-					p.Description = val
-				case "model":
-					p.Model = val
-				case "color":
-					p.Color = val
-				}
+				p.set(strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]))
 			}
 		case 2:
 			bodyLines = append(bodyLines, line)
@@ -99,7 +89,7 @@ func LoadFile(path string) (*Persona, error) {
 func LoadEmbedded(name string) (*Persona, error) {
 	content, ok := embedded.GetAgent(name)
 	if !ok {
-		return nil, fmt.Errorf("agent %q not found in embedded data", name)
+		return nil, fmt.Errorf("agent %q not found in embedded data: %w", name, ErrNotFound)
 	}
 	return ParseString(content)
 }
@@ -122,18 +112,7 @@ func ParseString(content string) (*Persona, error) {
 				continue
 			}
 			if idx := strings.Index(line, ":"); idx > 0 {
-				key := strings.TrimSpace(line[:idx])
-				val := strings.TrimSpace(line[idx+1:])
-				switch key {
-				case "name":
-					p.Name = val
-				case "description":
-					p.Description = val
-				case "model":
-					p.Model = val
-				case "color":
-					p.Color = val
-				}
+				p.set(strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]))
 			}
 		case 2:
 			bodyLines = append(bodyLines, line)
@@ -147,6 +126,76 @@ func ParseString(content string) (*Persona, error) {
 	return p, nil
 }
 
+// set applies one frontmatter key. Unknown keys and unparseable values
+// are ignored.
+func (p *Persona) set(key, val string) {
+	if p.declared == nil {
+		p.declared = map[string]string{}
+	}
+	p.declared[key] = val
+	switch key {
+	case "name":
+		p.Name = val
+	case "description":
+		p.Description = val
+	case "model":
+		p.Model = val
+	case "color":
+		p.Color = val
+	case "llm_model":
+		p.LLMModel = unquote(val)
+	case "timeout":
+		if d, ok := ParseTimeout(val); ok {
+			p.Timeout = d
+		}
+	case "read_files":
+		if b, err := strconv.ParseBool(val); err == nil {
+			p.ReadFiles = &b
+		}
+	case "preamble":
+		if val = unquote(val); val != "" {
+			p.Preambles = append(p.Preambles, val)
+		}
+	case "extends":
+		p.Extends = unquote(val)
+	case "mixins":
+		p.Mixins = parseList(val)
+	}
+}
+
+// parseList reads a flow list ("[a, b]") or a comma-separated one ("a, b").
+func parseList(val string) []string {
+	val = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(val), "["), "]")
+	var out []string
+	for _, item := range strings.Split(val, ",") {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ParseTimeout reads a timeout given as whole seconds ("900") or a Go
+// duration ("15m").
+func ParseTimeout(val string) (time.Duration, bool) {
+	val = unquote(val)
+	if n, err := strconv.Atoi(val); err == nil && n > 0 {
+		return time.Duration(n) * time.Second, true
+	}
+	if d, err := time.ParseDuration(val); err == nil && d > 0 {
+		return d, true
+	}
+	return 0, false
+}
+
+// unquote strips one pair of matching YAML quotes.
+func unquote(val string) string {
+	if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+		return val[1 : len(val)-1]
+	}
+	return val
+}
+
 // ModelToLLM maps a model identifier from agent frontmatter to the LLM engine name.
 func ModelToLLM(model string) string {
 	switch strings.ToLower(model) {
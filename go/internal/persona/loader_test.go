@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadFile(t *testing.T) {
@@ -102,3 +103,61 @@ func TestShortDescription(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRunSettings(t *testing.T) {
+	p, err := ParseString(`---
+name: hipaa
+description: HIPAA Vern - Paranoid about PHI.
+model: opus
+llm_model: "claude-opus-4-1"
+timeout: 15m
+read_files: false
+preamble: Treat every record as PHI.
+preamble: 'Cite the HIPAA rule for each risk.'
+---
+
+You are HIPAA Vern.
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.LLMModel != "claude-opus-4-1" {
+		t.Errorf("llm_model = %q", p.LLMModel)
+	}
+	if p.Timeout != 15*time.Minute {
+		t.Errorf("timeout = %s", p.Timeout)
+	}
+	if p.ReadFiles == nil || *p.ReadFiles {
+		t.Errorf("read_files = %v, want false", p.ReadFiles)
+	}
+	want := []string{"Treat every record as PHI.", "Cite the HIPAA rule for each risk."}
+	if len(p.Preambles) != 2 || p.Preambles[0] != want[0] || p.Preambles[1] != want[1] {
+		t.Errorf("preambles = %q, want %q", p.Preambles, want)
+	}
+
+	// Personas without the new keys leave the caller in charge
+	p, _ = ParseString("---\nname: yolo\n---\nFull send.\n")
+	if p.LLMModel != "" || p.Timeout != 0 || p.ReadFiles != nil || p.Preambles != nil {
+		t.Errorf("unset run settings should stay zero: %+v", p)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		val  string
+		want time.Duration
+		ok   bool
+	}{
+		{"900", 900 * time.Second, true},
+		{"15m", 15 * time.Minute, true},
+		{`"90s"`, 90 * time.Second, true},
+		{"0", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTimeout(tt.val)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseTimeout(%q) = %s, %v; want %s, %v", tt.val, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package persona
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/embedded"
+)
+
+// Persona sources, from highest to lowest precedence.
+const (
+	SourceProject  = "project"  // .vern/agents/ in the working directory
+	SourceConfig   = "config"   // persona_dirs listed in config
+	SourceUser     = "user"     // ~/.config/vern/agents/
+	SourceAgents   = "repo"     // the vern-bot agents/ directory
+	SourceEmbedded = "embedded" // compiled into the binary
+)
+
+// PipelineOnly personas drive pipeline steps and are never summoned to a
+// council.
+var PipelineOnly = map[string]bool{
+	"vernhole-orchestrator": true,
+	"oracle":                true,
+	"historian":             true,
+}
+
+// ProjectDir holds per-project personas, relative to the working directory.
+var ProjectDir = filepath.Join(".vern", "agents")
+
+// UserDir returns the per-user persona directory, ~/.config/vern/agents.
+func UserDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "vern", "agents")
+}
+
+// Dir is a directory of persona .md files and the source it represents.
+type Dir struct {
+	Path   string
+	Source string
+}
+
+// searchDirs are searched before the agents dir, highest precedence first.
+var searchDirs []Dir
+
+// SetSearchDirs sets the persona directories searched before the agents
+// dir, highest precedence first. The CLI installs DefaultSearchDirs at
+// startup; without it only the agents dir and embedded personas are used.
+func SetSearchDirs(dirs ...Dir) {
+	searchDirs = dirs
+}
+
+// DefaultSearchDirs returns the standard search path: the project's
+// .vern/agents/, then the configured dirs in order, then ~/.config/vern/agents/.
+// A leading ~/ in a configured dir is expanded.
+func DefaultSearchDirs(configured []string) []Dir {
+	dirs := []Dir{{Path: ProjectDir, Source: SourceProject}}
+	for _, d := range configured {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		if strings.HasPrefix(d, "~/") {
+			d = filepath.Join(os.Getenv("HOME"), d[2:])
+		}
+		dirs = append(dirs, Dir{Path: d, Source: SourceConfig})
+	}
+	return append(dirs, Dir{Path: UserDir(), Source: SourceUser})
+}
+
+// SearchDirs returns every on-disk persona directory for agentsDir, highest
+// precedence first. Embedded personas come after all of them.
+func SearchDirs(agentsDir string) []Dir {
+	dirs := append([]Dir(nil), searchDirs...)
+	if agentsDir != "" {
+		dirs = append(dirs, Dir{Path: agentsDir, Source: SourceAgents})
+	}
+	return dirs
+}
+
+// Entry is a persona found on the search path.
+type Entry struct {
+	ID     string
+	Path   string // empty for embedded personas
+	Source string
+}
+
+// Find returns the persona file that wins for id, or false when id exists
+// only in embedded data (or not at all).
+func Find(agentsDir, id string) (Entry, bool) {
+	if cands := Candidates(agentsDir, id); len(cands) > 0 && cands[0].Path != "" {
+		return cands[0], true
+	}
+	return Entry{}, false
+}
+
+// List returns every persona on the search path merged with the embedded
+// ones, sorted by ID. When an ID appears in several places, the
+// highest-precedence one wins.
+func List(agentsDir string) []Entry {
+	seen := map[string]bool{}
+	var entries []Entry
+	for _, d := range SearchDirs(agentsDir) {
+		files, _ := filepath.Glob(filepath.Join(d.Path, "*.md"))
+		sort.Strings(files)
+		for _, f := range files {
+			id := strings.TrimSuffix(filepath.Base(f), ".md")
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			entries = append(entries, Entry{ID: id, Path: f, Source: d.Source})
+		}
+	}
+	for _, id := range embedded.ListAgents() {
+		if !seen[id] {
+			seen[id] = true
+			entries = append(entries, Entry{ID: id, Source: SourceEmbedded})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries
+}
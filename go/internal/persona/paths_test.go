@@ -0,0 +1,99 @@
+package persona
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeAgent(t *testing.T, dir, id, desc string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + id + "\ndescription: " + desc + "\nmodel: opus\n---\n\nYou are " + desc + ".\n"
+	if err := os.WriteFile(filepath.Join(dir, id+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchPrecedence(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	user := filepath.Join(root, "user")
+	agents := filepath.Join(root, "agents")
+
+	writeAgent(t, agents, "paranoid", "Agents Paranoid")
+	writeAgent(t, agents, "startup", "Agents Startup")
+	writeAgent(t, user, "paranoid", "User Paranoid")
+	writeAgent(t, user, "hipaa", "User HIPAA")
+	writeAgent(t, project, "hipaa", "Project HIPAA")
+
+	SetSearchDirs(Dir{Path: project, Source: SourceProject}, Dir{Path: user, Source: SourceUser})
+	t.Cleanup(func() { SetSearchDirs() })
+
+	tests := []struct {
+		id         string
+		wantDesc   string
+		wantSource string
+	}{
+		{"hipaa", "Project HIPAA", SourceProject},
+		{"paranoid", "User Paranoid", SourceUser},
+		{"startup", "Agents Startup", SourceAgents},
+	}
+	for _, tt := range tests {
+		p, err := Load(agents, tt.id)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", tt.id, err)
+		}
+		if p.Description != tt.wantDesc {
+			t.Errorf("Load(%s) description = %q, want %q", tt.id, p.Description, tt.wantDesc)
+		}
+		e, ok := Find(agents, tt.id)
+		if !ok || e.Source != tt.wantSource {
+			t.Errorf("Find(%s) = %+v, %v; want source %s", tt.id, e, ok, tt.wantSource)
+		}
+	}
+
+	// Embedded personas fill in whatever the dirs don't have
+	if _, ok := Find(agents, "yolo"); ok {
+		t.Error("yolo should not be found on disk")
+	}
+	if _, err := Load(agents, "yolo"); err != nil {
+		t.Errorf("yolo should fall back to embedded: %v", err)
+	}
+
+	sources := map[string]string{}
+	for _, e := range List(agents) {
+		if _, dup := sources[e.ID]; dup {
+			t.Errorf("List has %s twice", e.ID)
+		}
+		sources[e.ID] = e.Source
+	}
+	want := map[string]string{"hipaa": SourceProject, "paranoid": SourceUser, "startup": SourceAgents, "yolo": SourceEmbedded}
+	for id, src := range want {
+		if sources[id] != src {
+			t.Errorf("List source for %s = %q, want %q", id, sources[id], src)
+		}
+	}
+}
+
+func TestDefaultSearchDirs(t *testing.T) {
+	t.Setenv("HOME", "/home/vern")
+	dirs := DefaultSearchDirs([]string{"~/team-personas", " ", "/opt/personas"})
+
+	want := []Dir{
+		{Path: ProjectDir, Source: SourceProject},
+		{Path: "/home/vern/team-personas", Source: SourceConfig},
+		{Path: "/opt/personas", Source: SourceConfig},
+		{Path: "/home/vern/.config/vern/agents", Source: SourceUser},
+	}
+	if len(dirs) != len(want) {
+		t.Fatalf("got %d dirs, want %d: %+v", len(dirs), len(want), dirs)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Errorf("dirs[%d] = %+v, want %+v", i, dirs[i], want[i])
+		}
+	}
+}
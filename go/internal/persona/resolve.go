@@ -0,0 +1,192 @@
+package persona
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/embedded"
+)
+
+// ErrNotFound is returned (wrapped) when no persona has the requested ID.
+var ErrNotFound = errors.New("persona not found")
+
+var idRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ValidID reports whether id is a well-formed persona ID: lowercase
+// letters, digits, and hyphens. IDs are joined onto search directories,
+// so callers that act on the resolved file check this first.
+func ValidID(id string) bool {
+	return idRe.MatchString(id)
+}
+
+// Load returns the persona with the given ID (e.g. "mighty"), resolved:
+// its extends chain and mixins are flattened into one persona.
+// agentsDir is the path to the agents/ directory. The search path (see
+// SearchDirs) is checked first, so project and user personas override the
+// agents dir; embedded agent data comes last.
+//
+// A persona inherits the frontmatter of the one it extends, overriding
+// whatever it declares itself. The body is the base body, then each mixin's
+// body, then its own, so its own instructions come last. Preambles
+// accumulate in the same order. A persona may extend its own ID to build on
+// the lower-precedence persona it overrides. Cycles are errors.
+func Load(agentsDir, name string) (*Persona, error) {
+	e, ok := lookup(agentsDir, name, Entry{})
+	if !ok {
+		return nil, fmt.Errorf("persona %q: %w", name, ErrNotFound)
+	}
+	return resolve(agentsDir, e, nil)
+}
+
+// LoadEntry resolves a specific definition from Candidates, which need not
+// be the one that wins.
+func LoadEntry(agentsDir string, e Entry) (*Persona, error) {
+	return resolve(agentsDir, e, nil)
+}
+
+// LoadUnresolved returns the persona with the given ID as written, without
+// following extends or mixins, and where it was found.
+func LoadUnresolved(agentsDir, name string) (*Persona, Entry, error) {
+	e, ok := lookup(agentsDir, name, Entry{})
+	if !ok {
+		return nil, Entry{}, fmt.Errorf("persona %q: %w", name, ErrNotFound)
+	}
+	p, err := readEntry(e)
+	return p, e, err
+}
+
+// Candidates returns every definition of id, highest precedence first; the
+// first one is what Load uses.
+func Candidates(agentsDir, id string) []Entry {
+	var out []Entry
+	for _, d := range SearchDirs(agentsDir) {
+		path := filepath.Join(d.Path, id+".md")
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			out = append(out, Entry{ID: id, Path: path, Source: d.Source})
+		}
+	}
+	if _, ok := embedded.GetAgent(id); ok {
+		out = append(out, Entry{ID: id, Source: SourceEmbedded})
+	}
+	return out
+}
+
+// lookup finds the definition of id referenced from persona from. A persona
+// referring to its own ID gets the next definition below it.
+func lookup(agentsDir, id string, from Entry) (Entry, bool) {
+	cands := Candidates(agentsDir, id)
+	if id == from.ID {
+		for i, c := range cands {
+			if c == from && i+1 < len(cands) {
+				return cands[i+1], true
+			}
+		}
+		return Entry{}, false
+	}
+	if len(cands) == 0 {
+		return Entry{}, false
+	}
+	return cands[0], true
+}
+
+func readEntry(e Entry) (*Persona, error) {
+	if e.Path == "" {
+		return LoadEmbedded(e.ID)
+	}
+	return LoadFile(e.Path)
+}
+
+// label names an entry in error messages.
+func (e Entry) label() string {
+	if e.Path == "" {
+		return e.ID + " (embedded)"
+	}
+	return e.ID + " (" + e.Path + ")"
+}
+
+func resolve(agentsDir string, e Entry, stack []Entry) (*Persona, error) {
+	for i, s := range stack {
+		if s == e {
+			names := make([]string, 0, len(stack)-i+1)
+			for _, c := range stack[i:] {
+				names = append(names, c.label())
+			}
+			return nil, fmt.Errorf("persona cycle: %s -> %s", strings.Join(names, " -> "), e.label())
+		}
+	}
+	p, err := readEntry(e)
+	if err != nil {
+		return nil, err
+	}
+	if p.Extends == "" && len(p.Mixins) == 0 {
+		p.Chain = []string{e.ID}
+		return p, nil
+	}
+	stack = append(stack, e)
+
+	out := &Persona{Model: "claude"}
+	var bodies []string
+	if p.Extends != "" {
+		be, ok := lookup(agentsDir, p.Extends, e)
+		if !ok {
+			return nil, fmt.Errorf("%s extends unknown persona %q", e.label(), p.Extends)
+		}
+		base, err := resolve(agentsDir, be, stack)
+		if err != nil {
+			return nil, err
+		}
+		*out = *base
+		out.Preambles = append([]string(nil), base.Preambles...)
+		bodies = append(bodies, base.Body)
+	}
+	for _, id := range p.Mixins {
+		me, ok := lookup(agentsDir, id, e)
+		if !ok {
+			return nil, fmt.Errorf("%s mixes in unknown persona %q", e.label(), id)
+		}
+		mixin, err := resolve(agentsDir, me, stack)
+		if err != nil {
+			return nil, err
+		}
+		out.Preambles = append(out.Preambles, mixin.Preambles...)
+		out.Chain = append(out.Chain, mixin.Chain...)
+		bodies = append(bodies, mixin.Body)
+	}
+	bodies = append(bodies, p.Body)
+	out.Preambles = append(out.Preambles, p.Preambles...)
+	out.Chain = append(out.Chain, e.ID)
+
+	// The persona's own frontmatter overrides what it inherited
+	for key := range p.declared {
+		switch key {
+		case "name":
+			out.Name = p.Name
+		case "description":
+			out.Description = p.Description
+		case "model":
+			out.Model = p.Model
+		case "color":
+			out.Color = p.Color
+		case "llm_model":
+			out.LLMModel = p.LLMModel
+		case "timeout":
+			out.Timeout = p.Timeout
+		case "read_files":
+			out.ReadFiles = p.ReadFiles
+		}
+	}
+	out.Extends, out.Mixins, out.declared = p.Extends, p.Mixins, p.declared
+
+	var parts []string
+	for _, b := range bodies {
+		if b = strings.TrimSpace(b); b != "" {
+			parts = append(parts, b)
+		}
+	}
+	out.Body = strings.Join(parts, "\n\n")
+	return out, nil
+}
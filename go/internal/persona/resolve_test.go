@@ -0,0 +1,142 @@
+package persona
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeRaw(t *testing.T, dir, id, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadResolvesExtendsAndMixins(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, dir, "base", "---\nname: base\ndescription: Base Vern - Careful.\nmodel: sonnet\ncolor: red\ntimeout: 10m\npreamble: Base rule.\n---\n\nBASE BODY\n")
+	writeRaw(t, dir, "scholar", "---\nname: scholar\ndescription: Scholar Vern\nmodel: opus\npreamble: Cite sources.\n---\n\nSCHOLAR BODY\n")
+	writeRaw(t, dir, "hipaa", "---\nname: hipaa\ndescription: HIPAA Vern - PHI paranoia.\nextends: base\nmixins: [scholar]\ntimeout: 5m\n---\n\nHIPAA BODY\n")
+
+	p, err := Load(dir, "hipaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Description != "HIPAA Vern - PHI paranoia." {
+		t.Errorf("own description should win, got %q", p.Description)
+	}
+	if p.Model != "sonnet" || p.Color != "red" {
+		t.Errorf("undeclared frontmatter should come from the base: model=%q color=%q", p.Model, p.Color)
+	}
+	if p.Timeout != 5*time.Minute {
+		t.Errorf("timeout = %s, want the persona's own 5m", p.Timeout)
+	}
+	if p.Body != "BASE BODY\n\nSCHOLAR BODY\n\nHIPAA BODY" {
+		t.Errorf("body = %q", p.Body)
+	}
+	if strings.Join(p.Preambles, "|") != "Base rule.|Cite sources." {
+		t.Errorf("preambles = %q", p.Preambles)
+	}
+	if strings.Join(p.Chain, ",") != "base,scholar,hipaa" {
+		t.Errorf("chain = %v", p.Chain)
+	}
+
+	raw, e, err := LoadUnresolved(dir, "hipaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Body == p.Body || raw.Extends != "base" || e.Source != SourceAgents {
+		t.Errorf("unresolved = %+v from %+v", raw, e)
+	}
+}
+
+func TestLoadSelfExtends(t *testing.T) {
+	agents := t.TempDir()
+	user := t.TempDir()
+	writeRaw(t, agents, "paranoid", "---\nname: paranoid\ndescription: Paranoid Vern\nmodel: sonnet\n---\n\nORIGINAL\n")
+	writeRaw(t, user, "paranoid", "---\nextends: paranoid\n---\n\nALSO HIPAA\n")
+	SetSearchDirs(Dir{Path: user, Source: SourceUser})
+	t.Cleanup(func() { SetSearchDirs() })
+
+	p, err := Load(agents, "paranoid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Body != "ORIGINAL\n\nALSO HIPAA" || p.Description != "Paranoid Vern" {
+		t.Errorf("override should build on the persona it shadows: %+v", p)
+	}
+}
+
+func TestLoadResolveErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, dir, "a", "---\nextends: b\n---\nA\n")
+	writeRaw(t, dir, "b", "---\nmixins: c\n---\nB\n")
+	writeRaw(t, dir, "c", "---\nextends: a\n---\nC\n")
+	writeRaw(t, dir, "orphan", "---\nextends: nobody\n---\nO\n")
+
+	tests := []struct {
+		id      string
+		wantErr string
+	}{
+		{"a", "persona cycle: a ("},
+		{"orphan", `extends unknown persona "nobody"`},
+	}
+	for _, tt := range tests {
+		_, err := Load(dir, tt.id)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Load(%s) err = %v, want %q", tt.id, err, tt.wantErr)
+		}
+		if errors.Is(err, ErrNotFound) {
+			t.Errorf("Load(%s): resolution errors should not be ErrNotFound", tt.id)
+		}
+	}
+
+	if _, err := Load(dir, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing persona err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		val  string
+		want string
+	}{
+		{"[academic, ux]", "academic|ux"},
+		{"academic,ux", "academic|ux"},
+		{`["academic"]`, "academic"},
+		{"[]", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(parseList(tt.val), "|"); got != tt.want {
+			t.Errorf("parseList(%q) = %q, want %q", tt.val, got, tt.want)
+		}
+	}
+}
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"mighty", true},
+		{"code-poet", true},
+		{"vernhole-orchestrator", true},
+		{"", false},
+		{"../../README", false},
+		{"agents/mighty", false},
+		{"Mighty", false},
+		{"-yolo", false},
+	}
+	for _, tt := range tests {
+		if got := ValidID(tt.id); got != tt.want {
+			t.Errorf("ValidID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
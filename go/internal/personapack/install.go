@@ -0,0 +1,202 @@
+package personapack
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+)
+
+// What Install does with each file.
+const (
+	StatusNew       = "new"
+	StatusUnchanged = "unchanged"
+	StatusUpdate    = "update"   // written by an earlier install of this pack and not edited since
+	StatusConflict  = "conflict" // exists with other content
+)
+
+// VernDir returns the user's vern directory, ~/.config/vern. Agents go to
+// persona.UserDir(), councils to council.UserCouncilDir(), and command and
+// skill files to commands/ and skills/ here, for use with the plugin.
+func VernDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "vern")
+}
+
+// recordDir holds one JSON record per installed pack.
+func recordDir() string {
+	return filepath.Join(VernDir(), "packs")
+}
+
+// installRecord is what Install remembers about a pack, so a newer version
+// can replace its files without reporting conflicts.
+type installRecord struct {
+	Manifest Manifest          `json:"manifest"`
+	Files    map[string]string `json:"files"` // installed path -> sha256
+}
+
+// Action is one file Install writes, or would write.
+type Action struct {
+	Rel    string // path inside the pack
+	Path   string // where it's installed
+	Status string
+}
+
+// InstallOptions configures Install.
+type InstallOptions struct {
+	AgentsDir string       // used to report definitions that shadow, or are shadowed by, the pack's
+	Force     bool         // overwrite conflicting files
+	DryRun    bool         // plan and report without writing
+	LogFunc   func(string) // optional callback for progress logs
+}
+
+func (o InstallOptions) log(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if o.LogFunc != nil {
+		o.LogFunc(msg)
+	} else {
+		fmt.Println(msg)
+	}
+}
+
+// ErrConflict is returned (wrapped) when installing would overwrite files
+// that didn't come from this pack.
+var ErrConflict = errors.New("conflicting files")
+
+// installPath maps a path inside the pack to where it's installed.
+func installPath(rel string) string {
+	dir, file, _ := strings.Cut(rel, "/")
+	switch dir {
+	case "agents":
+		return filepath.Join(persona.UserDir(), file)
+	case "councils":
+		return filepath.Join(council.UserCouncilDir(), file)
+	}
+	return filepath.Join(VernDir(), filepath.FromSlash(rel))
+}
+
+// Install writes a pack's files into the user's vern directory. A file that
+// already exists with other content is a conflict, unless an earlier
+// version of the same pack installed it and it hasn't been edited since;
+// conflicts abort the install unless Force is set. The returned actions
+// cover every file, in pack order.
+func Install(p *Pack, opts InstallOptions) ([]Action, error) {
+	prev := readRecord(p.Manifest.Name)
+	if prev != nil {
+		opts.log("Upgrading %s %s -> %s", p.Manifest.Name, prev.Manifest.Version, p.Manifest.Version)
+	}
+
+	names := make([]string, 0, len(p.Files))
+	for name := range p.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var actions []Action
+	var conflicts []string
+	for _, rel := range names {
+		a := Action{Rel: rel, Path: installPath(rel), Status: StatusNew}
+		if existing, err := os.ReadFile(a.Path); err == nil {
+			switch {
+			case bytes.Equal(existing, p.Files[rel]):
+				a.Status = StatusUnchanged
+			case prev != nil && prev.Files[a.Path] == hash(existing):
+				a.Status = StatusUpdate
+			default:
+				a.Status = StatusConflict
+				conflicts = append(conflicts, a.Path)
+			}
+		}
+		actions = append(actions, a)
+	}
+
+	for _, w := range shadowNotes(p, opts.AgentsDir) {
+		opts.log("Note: %s", w)
+	}
+
+	if opts.DryRun {
+		return actions, nil
+	}
+	if len(conflicts) > 0 && !opts.Force {
+		return actions, fmt.Errorf("%w would be overwritten: %s (use --force to overwrite them)", ErrConflict, strings.Join(conflicts, ", "))
+	}
+
+	rec := installRecord{Manifest: p.Manifest, Files: map[string]string{}}
+	for _, a := range actions {
+		data := p.Files[a.Rel]
+		rec.Files[a.Path] = hash(data)
+		if a.Status == StatusUnchanged {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(a.Path), 0755); err != nil {
+			return actions, err
+		}
+		if err := os.WriteFile(a.Path, data, 0644); err != nil {
+			return actions, err
+		}
+	}
+	return actions, writeRecord(rec)
+}
+
+// shadowNotes describes how the pack's personas and councils interact with
+// definitions elsewhere on the search path.
+func shadowNotes(p *Pack, agentsDir string) []string {
+	var notes []string
+	for _, id := range p.Manifest.Personas {
+		for _, e := range persona.Candidates(agentsDir, id) {
+			switch e.Source {
+			case persona.SourceProject, persona.SourceConfig:
+				notes = append(notes, fmt.Sprintf("%s (%s: %s) takes precedence over the pack's %s", id, e.Source, e.Path, id))
+			case persona.SourceAgents, persona.SourceEmbedded:
+				notes = append(notes, fmt.Sprintf("the pack's %s overrides the %s definition", id, e.Source))
+			}
+		}
+	}
+	builtins := council.AllTiers()
+	for _, name := range p.Manifest.Councils {
+		if _, ok := builtins[name]; ok {
+			notes = append(notes, fmt.Sprintf("council %s replaces the built-in %s council", name, name))
+		}
+		project := filepath.Join(council.ProjectCouncilDir, name+".json")
+		if _, err := os.Stat(project); err == nil {
+			notes = append(notes, fmt.Sprintf("%s takes precedence over the pack's %s council", project, name))
+		}
+	}
+	return notes
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readRecord(name string) *installRecord {
+	data, err := os.ReadFile(filepath.Join(recordDir(), name+".json"))
+	if err != nil {
+		return nil
+	}
+	var rec installRecord
+	if json.Unmarshal(data, &rec) != nil {
+		return nil
+	}
+	return &rec
+}
+
+func writeRecord(rec installRecord) error {
+	if err := os.MkdirAll(recordDir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(recordDir(), rec.Manifest.Name+".json"), append(data, '\n'), 0644)
+}
@@ -0,0 +1,423 @@
+// Package personapack bundles personas, their plugin command and skill
+// files, and custom councils into a versioned .tar.gz pack, and installs
+// packs into the user's vern directory.
+package personapack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/embedded"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+)
+
+// FormatVersion is the pack layout this vern writes and the newest it reads.
+const FormatVersion = 1
+
+// ManifestFile is the manifest's path inside a pack.
+const ManifestFile = "manifest.json"
+
+// maxFileSize caps each file read from a pack.
+const maxFileSize = 1 << 20
+
+// Manifest describes a pack. It's stored as manifest.json at the root of
+// the archive, next to agents/, commands/, skills/, and councils/.
+type Manifest struct {
+	Format      int       `json:"format"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Description string    `json:"description,omitempty"`
+	Created     time.Time `json:"created"`
+	VernVersion string    `json:"vern_version,omitempty"`
+	Personas    []string  `json:"personas"`
+	Councils    []string  `json:"councils,omitempty"`
+}
+
+// Pack is a manifest plus its files, keyed by slash-separated path inside
+// the archive (agents/<id>.md, commands/<id>.md, skills/<id>/SKILL.md,
+// councils/<name>.json).
+type Pack struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+func agentPath(id string) string     { return "agents/" + id + ".md" }
+func commandPath(id string) string   { return "commands/" + id + ".md" }
+func skillPath(id string) string     { return "skills/" + id + "/SKILL.md" }
+func councilPath(name string) string { return "councils/" + name + ".json" }
+
+// ExportOptions configures Build.
+type ExportOptions struct {
+	Name        string
+	Version     string
+	Description string
+	VernVersion string
+	Personas    []string
+	Councils    []string                // custom councils to include
+	Tiers       map[string]council.Tier // every known council, from council.LoadTiers
+	AgentsDir   string
+	RepoRoot    string       // optional: commands/ and skills/ are also read from here
+	LogFunc     func(string) // optional callback for progress logs
+}
+
+func (o ExportOptions) log(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if o.LogFunc != nil {
+		o.LogFunc(msg)
+	} else {
+		fmt.Println(msg)
+	}
+}
+
+// Build collects the personas and councils into a pack. Core members of an
+// included council are added when they aren't built-in personas. Every
+// persona a pack member extends, mixes in, or seats on a council must be in
+// the pack or built in, so the pack works on its own.
+func Build(opts ExportOptions) (*Pack, error) {
+	if !persona.ValidID(opts.Name) {
+		return nil, fmt.Errorf("pack name %q must be lowercase letters, digits, and hyphens", opts.Name)
+	}
+	if opts.Version == "" {
+		return nil, fmt.Errorf("version is required")
+	}
+	p := &Pack{
+		Manifest: Manifest{
+			Format:      FormatVersion,
+			Name:        opts.Name,
+			Version:     opts.Version,
+			Description: opts.Description,
+			Created:     time.Now().UTC().Truncate(time.Second),
+			VernVersion: opts.VernVersion,
+		},
+		Files: map[string][]byte{},
+	}
+
+	ids := append([]string(nil), opts.Personas...)
+	for _, name := range opts.Councils {
+		t, ok := opts.Tiers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown council %q", name)
+		}
+		if t.Source == "" {
+			return nil, fmt.Errorf("%q is a built-in council; only custom councils can be exported", name)
+		}
+		data, err := json.MarshalIndent(council.ConfigFromTier(t), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		p.Files[councilPath(name)] = append(data, '\n')
+		p.Manifest.Councils = append(p.Manifest.Councils, name)
+		for _, id := range t.Core {
+			if _, builtin := embedded.GetAgent(id); !builtin {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("nothing to export: name personas or a council")
+	}
+
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		content, e, err := agentContent(opts.AgentsDir, id)
+		if err != nil {
+			return nil, err
+		}
+		p.Files[agentPath(id)] = content
+		p.Manifest.Personas = append(p.Manifest.Personas, id)
+		opts.log("  + %s (%s)", agentPath(id), e.Source)
+
+		for _, rel := range []string{commandPath(id), skillPath(id)} {
+			if data, src, ok := pluginFile(opts.RepoRoot, rel); ok {
+				p.Files[rel] = data
+				opts.log("  + %s (%s)", rel, src)
+			}
+		}
+	}
+	sort.Strings(p.Manifest.Personas)
+
+	if err := p.checkDependencies(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// agentContent returns a persona's markdown as written, from the file that
+// wins on the search path or from embedded data.
+func agentContent(agentsDir, id string) ([]byte, persona.Entry, error) {
+	_, e, err := persona.LoadUnresolved(agentsDir, id)
+	if err != nil {
+		return nil, e, err
+	}
+	if e.Path == "" {
+		content, _ := embedded.GetAgent(id)
+		return []byte(content), e, nil
+	}
+	data, err := os.ReadFile(e.Path)
+	return data, e, err
+}
+
+// pluginFile looks for a command or skill file in the repo checkout, then
+// where Install puts them.
+func pluginFile(repoRoot, rel string) ([]byte, string, bool) {
+	var roots []string
+	if repoRoot != "" {
+		roots = append(roots, repoRoot)
+	}
+	roots = append(roots, VernDir())
+	for _, root := range roots {
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if data, err := os.ReadFile(full); err == nil {
+			return data, full, true
+		}
+	}
+	return nil, "", false
+}
+
+// checkDependencies reports personas the pack refers to but neither
+// contains nor can rely on being built in.
+func (p *Pack) checkDependencies() error {
+	available := func(id string) bool {
+		if _, ok := p.Files[agentPath(id)]; ok {
+			return true
+		}
+		_, ok := embedded.GetAgent(id)
+		return ok
+	}
+	var missing []string
+	for _, id := range p.Manifest.Personas {
+		per, err := persona.ParseString(string(p.Files[agentPath(id)]))
+		if err != nil {
+			return fmt.Errorf("%s: %w", agentPath(id), err)
+		}
+		if per.Extends != "" && !available(per.Extends) {
+			missing = append(missing, fmt.Sprintf("%s extends %s", id, per.Extends))
+		}
+		for _, m := range per.Mixins {
+			if !available(m) {
+				missing = append(missing, fmt.Sprintf("%s mixes in %s", id, m))
+			}
+		}
+	}
+	for _, name := range p.Manifest.Councils {
+		c, err := p.council(name)
+		if err != nil {
+			return err
+		}
+		for _, id := range c.Core {
+			if !available(id) {
+				missing = append(missing, fmt.Sprintf("council %s seats %s", name, id))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("pack is incomplete: %s, which is neither in the pack nor built in", strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// council parses a council file in the pack.
+func (p *Pack) council(name string) (council.Tier, error) {
+	rel := councilPath(name)
+	data, ok := p.Files[rel]
+	if !ok {
+		return council.Tier{}, fmt.Errorf("%s is listed in the manifest but missing", rel)
+	}
+	return parseCouncil(rel, data)
+}
+
+// Write saves the pack as a gzipped tar, manifest first.
+func (p *Pack) Write(path string) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	manifest, err := json.MarshalIndent(p.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	names := make([]string, 0, len(p.Files))
+	for name := range p.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: p.Manifest.Created,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := add(ManifestFile, append(manifest, '\n')); err != nil {
+		return fmt.Errorf("write pack: %w", err)
+	}
+	for _, name := range names {
+		if err := add(name, p.Files[name]); err != nil {
+			return fmt.Errorf("write pack: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("write pack: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("write pack: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// Read opens and validates a pack. Files outside the pack layout, unknown
+// format versions, and manifests that don't match the files are errors.
+func Read(file string) (*Pack, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a persona pack: %w", file, err)
+	}
+	tr := tar.NewReader(gz)
+
+	p := &Pack{Files: map[string][]byte{}}
+	var manifest []byte
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%s: %s is not a regular file", file, hdr.Name)
+		}
+		name := path.Clean(hdr.Name)
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("%s: %s is too large", file, name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		if name == ManifestFile {
+			manifest = data
+			continue
+		}
+		if !validFile(name) {
+			return nil, fmt.Errorf("%s: unexpected file %q", file, hdr.Name)
+		}
+		p.Files[name] = data
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("%s: no %s", file, ManifestFile)
+	}
+	if err := json.Unmarshal(manifest, &p.Manifest); err != nil {
+		return nil, fmt.Errorf("%s: parse %s: %w", file, ManifestFile, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return p, nil
+}
+
+// validFile reports whether an archive path fits the pack layout.
+func validFile(name string) bool {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 2 && (parts[0] == "agents" || parts[0] == "commands"):
+		return strings.HasSuffix(parts[1], ".md") && persona.ValidID(strings.TrimSuffix(parts[1], ".md"))
+	case len(parts) == 2 && parts[0] == "councils":
+		return strings.HasSuffix(parts[1], ".json") && persona.ValidID(strings.TrimSuffix(parts[1], ".json"))
+	case len(parts) == 3 && parts[0] == "skills":
+		return persona.ValidID(parts[1]) && parts[2] == "SKILL.md"
+	}
+	return false
+}
+
+// validate checks the manifest against the files.
+func (p *Pack) validate() error {
+	m := p.Manifest
+	if m.Format < 1 {
+		return fmt.Errorf("manifest has no format version")
+	}
+	if m.Format > FormatVersion {
+		return fmt.Errorf("pack format %d is newer than this vern supports (%d); upgrade vern", m.Format, FormatVersion)
+	}
+	if !persona.ValidID(m.Name) || m.Version == "" {
+		return fmt.Errorf("manifest needs a name and version")
+	}
+
+	listed := map[string]bool{}
+	for _, id := range m.Personas {
+		if !persona.ValidID(id) {
+			return fmt.Errorf("invalid persona ID %q", id)
+		}
+		data, ok := p.Files[agentPath(id)]
+		if !ok {
+			return fmt.Errorf("%s is listed in the manifest but missing", agentPath(id))
+		}
+		per, err := persona.ParseString(string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", agentPath(id), err)
+		}
+		if per.Name != id {
+			return fmt.Errorf("%s: name %q doesn't match the file name", agentPath(id), per.Name)
+		}
+		listed[agentPath(id)] = true
+		listed[commandPath(id)] = true
+		listed[skillPath(id)] = true
+	}
+	for _, name := range m.Councils {
+		if _, err := p.council(name); err != nil {
+			return err
+		}
+		listed[councilPath(name)] = true
+	}
+	for name := range p.Files {
+		if !listed[name] {
+			return fmt.Errorf("%s isn't listed in the manifest", name)
+		}
+	}
+	return p.checkDependencies()
+}
+
+// parseCouncil reads a council file the way council.LoadTierFile does.
+func parseCouncil(rel string, data []byte) (council.Tier, error) {
+	var c config.CouncilConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return council.Tier{}, fmt.Errorf("parse %s: %w", rel, err)
+	}
+	t := council.TierFromConfig(strings.TrimSuffix(path.Base(rel), ".json"), c)
+	if err := t.Validate(); err != nil {
+		return council.Tier{}, fmt.Errorf("%s: %w", rel, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,212 @@
+package personapack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func agent(id, extra string) string {
+	return "---\nname: " + id + "\ndescription: " + id + " - test\n" + extra + "---\nPERSONALITY:\nTest.\n"
+}
+
+// setup creates an agents dir and repo root with two fintech personas and
+// a council seating them, with HOME pointed at a temp dir.
+func setup(t *testing.T) (agentsDir, repoRoot string, tiers map[string]council.Tier) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	repoRoot = t.TempDir()
+	agentsDir = filepath.Join(repoRoot, "agents")
+	os.MkdirAll(agentsDir, 0755)
+	os.MkdirAll(filepath.Join(repoRoot, "commands"), 0755)
+	os.WriteFile(filepath.Join(agentsDir, "ledger.md"), []byte(agent("ledger", "extends: paranoid\n")), 0644)
+	os.WriteFile(filepath.Join(agentsDir, "auditor.md"), []byte(agent("auditor", "")), 0644)
+	os.WriteFile(filepath.Join(agentsDir, "loose.md"), []byte(agent("loose", "mixins: [auditor]\n")), 0644)
+	os.WriteFile(filepath.Join(repoRoot, "commands", "ledger.md"), []byte("Run ledger."), 0644)
+
+	tiers = council.AllTiers()
+	tiers["fintech"] = council.Tier{Name: "fintech", Display: "Fintech", Core: []string{"ledger", "auditor", "mighty"}, Fixed: true, Source: "config"}
+	return agentsDir, repoRoot, tiers
+}
+
+func TestBuildAndRead(t *testing.T) {
+	agentsDir, repoRoot, tiers := setup(t)
+	p, err := Build(ExportOptions{
+		Name: "fintech", Version: "1.2.0", VernVersion: "test",
+		Councils: []string{"fintech"}, Tiers: tiers,
+		AgentsDir: agentsDir, RepoRoot: repoRoot, LogFunc: func(string) {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(p.Manifest.Personas, ",") != "auditor,ledger" {
+		t.Errorf("personas = %v; want the council's non-built-in core members", p.Manifest.Personas)
+	}
+
+	path := filepath.Join(t.TempDir(), "fintech.tar.gz")
+	if err := p.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Manifest.Version != "1.2.0" || got.Manifest.Format != FormatVersion || len(got.Manifest.Councils) != 1 {
+		t.Errorf("manifest = %+v", got.Manifest)
+	}
+	for _, rel := range []string{"agents/ledger.md", "agents/auditor.md", "commands/ledger.md", "councils/fintech.json"} {
+		if string(got.Files[rel]) != string(p.Files[rel]) {
+			t.Errorf("%s didn't round-trip", rel)
+		}
+	}
+	if !strings.Contains(string(got.Files["councils/fintech.json"]), `"fixed": true`) {
+		t.Errorf("council = %s", got.Files["councils/fintech.json"])
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	agentsDir, _, tiers := setup(t)
+	tests := []struct {
+		name string
+		opts ExportOptions
+		want string
+	}{
+		{"bad name", ExportOptions{Name: "Fin Tech", Version: "1", Personas: []string{"ledger"}}, "pack name"},
+		{"unknown persona", ExportOptions{Name: "p", Version: "1", Personas: []string{"nope"}}, "not found"},
+		{"built-in council", ExportOptions{Name: "p", Version: "1", Councils: []string{"war"}, Tiers: tiers}, "built-in council"},
+		{"missing mixin", ExportOptions{Name: "p", Version: "1", Personas: []string{"loose"}}, "loose mixes in auditor"},
+		{"nothing", ExportOptions{Name: "p", Version: "1"}, "nothing to export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.AgentsDir = agentsDir
+			tt.opts.LogFunc = func(string) {}
+			_, err := Build(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func writeTar(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pack.tar.gz")
+	f, _ := os.Create(path)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+	return path
+}
+
+func TestReadRejects(t *testing.T) {
+	manifest := `{"format":1,"name":"p","version":"1","personas":["ledger"]}`
+	ledger := agent("ledger", "")
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no manifest", map[string]string{"agents/ledger.md": ledger}, "no manifest.json"},
+		{"path escape", map[string]string{ManifestFile: manifest, "agents/ledger.md": ledger, "../evil.md": "x"}, "unexpected file"},
+		{"newer format", map[string]string{ManifestFile: `{"format":9,"name":"p","version":"1"}`}, "upgrade vern"},
+		{"missing agent", map[string]string{ManifestFile: manifest}, "missing"},
+		{"name mismatch", map[string]string{ManifestFile: manifest, "agents/ledger.md": agent("other", "")}, "doesn't match"},
+		{"unlisted file", map[string]string{ManifestFile: manifest, "agents/ledger.md": ledger, "agents/extra.md": agent("extra", "")}, "isn't listed"},
+		{"bad council", map[string]string{ManifestFile: `{"format":1,"name":"p","version":"1","personas":["ledger"],"councils":["c"]}`,
+			"agents/ledger.md": ledger, "councils/c.json": `{"core":["ledger"],"exclude":["ledger"]}`}, "both a core member and excluded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(writeTar(t, tt.files))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func statuses(actions []Action) string {
+	var out []string
+	for _, a := range actions {
+		out = append(out, a.Rel+"="+a.Status)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestInstall(t *testing.T) {
+	agentsDir, repoRoot, tiers := setup(t)
+	build := func(version string) *Pack {
+		p, err := Build(ExportOptions{Name: "fintech", Version: version, Councils: []string{"fintech"}, Tiers: tiers,
+			AgentsDir: agentsDir, RepoRoot: repoRoot, LogFunc: func(string) {}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	opts := InstallOptions{AgentsDir: agentsDir, LogFunc: func(string) {}}
+
+	v1 := build("1.0.0")
+	actions, err := Install(v1, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(actions); got != "agents/auditor.md=new agents/ledger.md=new commands/ledger.md=new councils/fintech.json=new" {
+		t.Errorf("first install: %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), ".config", "vern", "agents", "ledger.md")); err != nil {
+		t.Errorf("agent not installed in the user dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(council.UserCouncilDir(), "fintech.json")); err != nil {
+		t.Errorf("council not installed: %v", err)
+	}
+
+	// A newer version replaces files the last install wrote
+	v2 := build("1.1.0")
+	v2.Files["agents/ledger.md"] = []byte(agent("ledger", "color: red\n"))
+	actions, err = Install(v2, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(actions); !strings.Contains(got, "agents/ledger.md=update") || !strings.Contains(got, "agents/auditor.md=unchanged") {
+		t.Errorf("upgrade: %s", got)
+	}
+
+	// A locally edited file is a conflict
+	edited := filepath.Join(os.Getenv("HOME"), ".config", "vern", "agents", "auditor.md")
+	os.WriteFile(edited, []byte(agent("auditor", "color: blue\n")), 0644)
+	v3 := build("1.2.0")
+	v3.Files["agents/auditor.md"] = []byte(agent("auditor", "color: green\n"))
+	if _, err := Install(v3, opts); !errors.Is(err, ErrConflict) || !strings.Contains(err.Error(), "auditor.md") {
+		t.Fatalf("err = %v, want a conflict on auditor.md", err)
+	}
+	if data, _ := os.ReadFile(edited); !strings.Contains(string(data), "blue") {
+		t.Error("a conflicting install must not write anything")
+	}
+
+	dry := opts
+	dry.DryRun = true
+	if _, err := Install(v3, dry); err != nil {
+		t.Errorf("dry run should report conflicts without failing: %v", err)
+	}
+
+	opts.Force = true
+	if _, err := Install(v3, opts); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(edited); !strings.Contains(string(data), "green") {
+		t.Error("--force should overwrite")
+	}
+}
@@ -0,0 +1,128 @@
+package personatest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+)
+
+// CheckResult is one assertion on one answer.
+type CheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// signOffWindow is how many trailing lines may hold the attribution.
+const signOffWindow = 8
+
+// OutputSections returns the "## " headings a persona's OUTPUT FORMAT
+// section asks for, shortened to their names ("## Verdict: A | B" is
+// "Verdict"). Template headings with [placeholders] are skipped.
+func OutputSections(body string) []string {
+	format, ok := persona.Section(body, "OUTPUT FORMAT")
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, line := range strings.Split(format, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "## ") || strings.Contains(line, "[") {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+		if i := strings.IndexAny(name, ":("); i > 0 {
+			name = strings.TrimSpace(name[:i])
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Check runs every assertion for a probe's answer: the persona's output
+// sections, the sign-off attribution llm.Run asks for, length bounds, and
+// the probe's own contains/not_contains lists.
+func Check(probe Probe, sections []string, output string) []CheckResult {
+	var checks []CheckResult
+
+	if len(sections) > 0 {
+		var missing []string
+		for _, s := range sections {
+			if !hasHeading(output, s) {
+				missing = append(missing, s)
+			}
+		}
+		c := CheckResult{Name: "sections", Passed: len(missing) == 0}
+		if len(missing) > 0 {
+			c.Detail = "missing " + strings.Join(missing, ", ")
+		}
+		checks = append(checks, c)
+	}
+
+	c := CheckResult{Name: "sign-off"}
+	if line, ok := attribution(output); ok {
+		c.Passed = true
+		c.Detail = line
+	} else {
+		c.Detail = "no '-- Name' attribution line at the end"
+	}
+	checks = append(checks, c)
+
+	minWords, maxWords := probe.MinWords, probe.MaxWords
+	if minWords == 0 {
+		minWords = DefaultMinWords
+	}
+	if maxWords == 0 {
+		maxWords = DefaultMaxWords
+	}
+	words := len(strings.Fields(output))
+	checks = append(checks, CheckResult{
+		Name:   "length",
+		Passed: words >= minWords && words <= maxWords,
+		Detail: fmt.Sprintf("%d words (want %d-%d)", words, minWords, maxWords),
+	})
+
+	lower := strings.ToLower(output)
+	for _, s := range probe.Contains {
+		checks = append(checks, CheckResult{
+			Name:   fmt.Sprintf("contains %q", s),
+			Passed: strings.Contains(lower, strings.ToLower(s)),
+		})
+	}
+	for _, s := range probe.NotContains {
+		checks = append(checks, CheckResult{
+			Name:   fmt.Sprintf("not contains %q", s),
+			Passed: !strings.Contains(lower, strings.ToLower(s)),
+		})
+	}
+	return checks
+}
+
+// hasHeading reports whether output has a markdown heading (or a bold line)
+// mentioning name.
+func hasHeading(output, name string) bool {
+	name = strings.ToLower(name)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if (strings.HasPrefix(line, "#") || strings.HasPrefix(line, "**")) && strings.Contains(strings.ToLower(line), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// attribution finds the "-- Persona Name" line among the last few lines.
+func attribution(output string) (string, bool) {
+	lines := strings.Split(strings.TrimRight(output, "\n \t"), "\n")
+	start := max(len(lines)-signOffWindow, 0)
+	for i := len(lines) - 1; i >= start; i-- {
+		if llm.IsAttribution(lines[i]) {
+			return strings.TrimSpace(lines[i]), true
+		}
+	}
+	return "", false
+}
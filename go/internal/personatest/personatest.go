@@ -0,0 +1,255 @@
+// Package personatest runs a persona against stored probe prompts and checks
+// each answer against assertions, live or replayed from recorded answers.
+package personatest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
+)
+
+// ProbeDir holds per-project probes, relative to the working directory:
+// <id>.json lists a persona's probes, and <id>/ holds its recorded answers
+// and the latest report.
+var ProbeDir = filepath.Join(".vern", "probes")
+
+// Report file names written to the persona's probe directory.
+const (
+	MarkdownFile = "report.md"
+	JSONFile     = "report.json"
+	goldenSuffix = ".golden.md"
+)
+
+// Default length bounds, in words.
+const (
+	DefaultMinWords = 80
+	DefaultMaxWords = 4000
+)
+
+// Probe is a stored prompt plus what its answer must satisfy.
+type Probe struct {
+	Name        string   `json:"name"`
+	Prompt      string   `json:"prompt"`
+	MinWords    int      `json:"min_words,omitempty"` // default DefaultMinWords
+	MaxWords    int      `json:"max_words,omitempty"` // default DefaultMaxWords
+	Contains    []string `json:"contains,omitempty"`  // case-insensitive
+	NotContains []string `json:"not_contains,omitempty"`
+}
+
+// DefaultProbes are used for personas without a probe file.
+var DefaultProbes = []Probe{
+	{Name: "plan-review", Prompt: "Review this plan: next sprint we move our monolith's user sessions into Redis so we can scale the web tier horizontally. What do you think?"},
+	{Name: "greenfield", Prompt: "We want to build a small internal tool that lets employees book meeting rooms. How should we approach it?"},
+	{Name: "tradeoff", Prompt: "Should a five-person team adopt Kubernetes for a single web app and a Postgres database?"},
+}
+
+var probeNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// LoadProbes returns the probes in dir/<id>.json, or DefaultProbes when the
+// file doesn't exist.
+func LoadProbes(dir, id string) ([]Probe, error) {
+	path := filepath.Join(dir, id+".json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultProbes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var probes []Probe
+	if err := json.Unmarshal(data, &probes); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(probes) == 0 {
+		return nil, fmt.Errorf("%s: no probes", path)
+	}
+	seen := map[string]bool{}
+	for _, p := range probes {
+		if !probeNameRe.MatchString(p.Name) {
+			return nil, fmt.Errorf("%s: probe name %q must be lowercase letters, digits, and hyphens", path, p.Name)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("%s: duplicate probe %q", path, p.Name)
+		}
+		seen[p.Name] = true
+		if strings.TrimSpace(p.Prompt) == "" {
+			return nil, fmt.Errorf("%s: probe %q has no prompt", path, p.Name)
+		}
+	}
+	return probes, nil
+}
+
+// Backend answers probes as a persona.
+type Backend interface {
+	Name() string
+	Answer(ctx context.Context, probe Probe) (string, error)
+}
+
+// LiveBackend runs the persona on an LLM.
+type LiveBackend struct {
+	LLM       string // default: the persona's own
+	Persona   string
+	AgentsDir string
+	Timeout   time.Duration
+}
+
+func (b LiveBackend) Name() string {
+	return "live (" + b.backendLLM() + ")"
+}
+
+// backendLLM is b.LLM, or the LLM the persona's model runs on.
+func (b LiveBackend) backendLLM() string {
+	if b.LLM != "" {
+		return b.LLM
+	}
+	p, err := persona.Load(b.AgentsDir, b.Persona)
+	if err != nil {
+		return "persona default"
+	}
+	return persona.ModelToLLM(p.Model)
+}
+
+func (b LiveBackend) Answer(ctx context.Context, probe Probe) (string, error) {
+	result, err := llm.Run(llm.RunOptions{
+		Ctx:         ctx,
+		LLM:         b.backendLLM(),
+		Prompt:      probe.Prompt,
+		Persona:     b.Persona,
+		AgentsDir:   b.AgentsDir,
+		Timeout:     b.Timeout,
+		QuietStderr: true,
+		Step:        "persona-test",
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.ExitCode != 0 || result.Output == "" {
+		msg := llm.FirstLine(result.Stderr)
+		if msg == "" {
+			msg = "empty output"
+		}
+		return "", fmt.Errorf("exit %d: %s", result.ExitCode, msg)
+	}
+	return result.Text(), nil
+}
+
+// ReplayBackend answers from recorded <probe>.golden.md files, so tests
+// run offline.
+type ReplayBackend struct {
+	Dir string
+}
+
+func (b ReplayBackend) Name() string {
+	return "replay"
+}
+
+func (b ReplayBackend) Answer(ctx context.Context, probe Probe) (string, error) {
+	data, err := os.ReadFile(GoldenPath(b.Dir, probe.Name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("no recorded answer for %q (run live with --record first)", probe.Name)
+	}
+	return string(data), err
+}
+
+// GoldenPath is where a probe's recorded answer lives.
+func GoldenPath(dir, probe string) string {
+	return filepath.Join(dir, probe+goldenSuffix)
+}
+
+// Options configures a test run.
+type Options struct {
+	Ctx       context.Context // optional: cancelled on TUI quit
+	Persona   string
+	AgentsDir string
+	Probes    []Probe
+	Backend   Backend
+	OutputDir string       // report (and recordings) go here
+	Record    bool         // save each answer as the probe's golden file
+	LogFunc   func(string) // optional callback for progress logs
+}
+
+func (o Options) log(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if o.LogFunc != nil {
+		o.LogFunc(msg)
+	} else {
+		fmt.Println(msg)
+	}
+}
+
+// Run answers every probe in parallel, checks the answers, and writes
+// report.md and report.json to the output directory.
+func Run(opts Options) (*Report, error) {
+	p, err := persona.Load(opts.AgentsDir, opts.Persona)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Probes) == 0 {
+		return nil, fmt.Errorf("no probes")
+	}
+	if opts.Ctx == nil {
+		opts.Ctx = context.Background()
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	report := &Report{
+		Persona:  opts.Persona,
+		Backend:  opts.Backend.Name(),
+		Sections: OutputSections(p.Body),
+		Started:  time.Now(),
+		Results:  make([]ProbeResult, len(opts.Probes)),
+	}
+
+	var wg sync.WaitGroup
+	for i, probe := range opts.Probes {
+		wg.Add(1)
+		go func(r *ProbeResult, probe Probe) {
+			defer wg.Done()
+			r.Probe = probe
+			out, err := opts.Backend.Answer(opts.Ctx, probe)
+			if err != nil {
+				r.Error = err.Error()
+				opts.log("    FAILED %s: %v", probe.Name, err)
+				return
+			}
+			r.Output = out
+			r.Words = len(strings.Fields(out))
+			r.Checks = Check(probe, report.Sections, out)
+			r.Passed = allPassed(r.Checks)
+			if opts.Record {
+				if err := os.WriteFile(GoldenPath(opts.OutputDir, probe.Name), []byte(out), 0644); err != nil {
+					opts.log("    Warning: record %s: %v", probe.Name, err)
+				}
+			}
+			status := "PASS"
+			if !r.Passed {
+				status = "FAIL"
+			}
+			opts.log("    %s %s (%d words)", status, probe.Name, r.Words)
+		}(&report.Results[i], probe)
+	}
+	wg.Wait()
+
+	return report, report.Write(opts.OutputDir)
+}
+
+func allPassed(checks []CheckResult) bool {
+	for _, c := range checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
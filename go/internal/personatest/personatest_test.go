@@ -0,0 +1,166 @@
+package personatest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAgent = `---
+name: tester
+description: Tester - checks things
+---
+PERSONALITY:
+Thorough.
+
+OUTPUT FORMAT:
+` + "```" + `
+## Verdict: PASS | FAIL
+## Risks (top 3)
+### [Risk Name]
+` + "```" + `
+
+SIGN-OFF:
+End with a dad joke.
+`
+
+func TestOutputSections(t *testing.T) {
+	got := OutputSections(testAgent)
+	if strings.Join(got, ",") != "Verdict,Risks" {
+		t.Errorf("OutputSections = %v", got)
+	}
+	if OutputSections("PERSONALITY:\nNo format.") != nil {
+		t.Error("want no sections without OUTPUT FORMAT")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	body := strings.Repeat("word ", 100)
+	good := "## Verdict: PASS\n" + body + "\n**Risks**\nnone\n\nWhy did the test fail? It lost its assertion.\n\n-- Tester checks twice\n"
+	tests := []struct {
+		name   string
+		probe  Probe
+		output string
+		failed []string
+	}{
+		{"all pass", Probe{}, good, nil},
+		{"missing section", Probe{}, strings.Replace(good, "**Risks**", "Risks", 1), []string{"sections"}},
+		{"no sign-off", Probe{}, strings.Replace(good, "-- Tester", "Tester", 1), []string{"sign-off"}},
+		{"em dash sign-off", Probe{}, strings.Replace(good, "-- Tester", "— Tester", 1), nil},
+		{"too short", Probe{MinWords: 500}, good, []string{"length"}},
+		{"too long", Probe{MaxWords: 50}, good, []string{"length"}},
+		{"contains", Probe{Contains: []string{"ASSERTION", "redis"}}, good, []string{`contains "redis"`}},
+		{"not contains", Probe{NotContains: []string{"verdict"}}, good, []string{`not contains "verdict"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var failed []string
+			for _, c := range Check(tt.probe, []string{"Verdict", "Risks"}, tt.output) {
+				if !c.Passed {
+					failed = append(failed, c.Name)
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.failed, ",") {
+				t.Errorf("failed checks = %v, want %v", failed, tt.failed)
+			}
+		})
+	}
+}
+
+func TestLoadProbes(t *testing.T) {
+	dir := t.TempDir()
+	probes, err := LoadProbes(dir, "tester")
+	if err != nil || len(probes) != len(DefaultProbes) {
+		t.Fatalf("missing file: got %d probes, err %v", len(probes), err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `[{"name":"one","prompt":"Hi","contains":["x"]}]`, ""},
+		{"empty", `[]`, "no probes"},
+		{"bad name", `[{"name":"One Two","prompt":"Hi"}]`, "probe name"},
+		{"duplicate", `[{"name":"a","prompt":"Hi"},{"name":"a","prompt":"Yo"}]`, "duplicate"},
+		{"no prompt", `[{"name":"a"}]`, "no prompt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.WriteFile(filepath.Join(dir, "tester.json"), []byte(tt.content), 0644)
+			_, err := LoadProbes(dir, "tester")
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunReplay(t *testing.T) {
+	agents := t.TempDir()
+	os.WriteFile(filepath.Join(agents, "tester.md"), []byte(testAgent), 0644)
+	out := t.TempDir()
+
+	good := "## Verdict: PASS\n## Risks\n" + strings.Repeat("word ", 100) + "\n-- Tester\n"
+	os.WriteFile(GoldenPath(out, "good"), []byte(good), 0644)
+	os.WriteFile(GoldenPath(out, "bad"), []byte("## Verdict: FAIL\nshort"), 0644)
+
+	report, err := Run(Options{
+		Persona:   "tester",
+		AgentsDir: agents,
+		Probes:    []Probe{{Name: "good", Prompt: "x"}, {Name: "bad", Prompt: "y"}, {Name: "missing", Prompt: "z"}},
+		Backend:   ReplayBackend{Dir: out},
+		OutputDir: out,
+		LogFunc:   func(string) {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed() != 2 || !report.Results[0].Passed {
+		t.Errorf("want only the first probe to pass: %+v", report.Results)
+	}
+	if !strings.Contains(report.Results[2].Error, "--record") {
+		t.Errorf("missing golden error = %q", report.Results[2].Error)
+	}
+
+	md, _ := os.ReadFile(filepath.Join(out, MarkdownFile))
+	for _, want := range []string{
+		"**Result:** 1/3 probes passed",
+		"| good | pass | 107 | ✓ sections, ✓ sign-off, ✓ length |",
+		"- ✗ sections: missing Risks",
+		"| missing | error | 0 |",
+	} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("report.md missing %q\n%s", want, md)
+		}
+	}
+	var decoded Report
+	data, _ := os.ReadFile(filepath.Join(out, JSONFile))
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Backend != "replay" || len(decoded.Results) != 3 {
+		t.Errorf("report.json = %s (err %v)", data, err)
+	}
+}
+
+func TestLiveBackendName(t *testing.T) {
+	agents := t.TempDir()
+	os.WriteFile(filepath.Join(agents, "tester.md"), []byte(strings.Replace(testAgent, "description:", "model: gemini-3\ndescription:", 1)), 0644)
+
+	tests := []struct {
+		backend LiveBackend
+		want    string
+	}{
+		{LiveBackend{LLM: "codex", Persona: "tester", AgentsDir: agents}, "live (codex)"},
+		{LiveBackend{Persona: "tester", AgentsDir: agents}, "live (gemini)"},
+		{LiveBackend{Persona: "nobody", AgentsDir: agents}, "live (persona default)"},
+	}
+	for _, tt := range tests {
+		if got := tt.backend.Name(); got != tt.want {
+			t.Errorf("Name() = %q, want %q", got, tt.want)
+		}
+	}
+}
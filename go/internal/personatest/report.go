@@ -0,0 +1,112 @@
+package personatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProbeResult is one probe's answer and checks.
+type ProbeResult struct {
+	Probe  Probe         `json:"probe"`
+	Output string        `json:"-"`
+	Words  int           `json:"words"`
+	Checks []CheckResult `json:"checks"`
+	Passed bool          `json:"passed"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// Report is the result of a test run, written as report.json.
+type Report struct {
+	Persona  string        `json:"persona"`
+	Backend  string        `json:"backend"`
+	Sections []string      `json:"sections,omitempty"` // required by the persona's OUTPUT FORMAT
+	Started  time.Time     `json:"started"`
+	Results  []ProbeResult `json:"results"`
+}
+
+// Passed reports whether every probe was answered and passed every check.
+func (r *Report) Passed() bool {
+	return r.Failed() == 0
+}
+
+// Failed counts probes that errored or failed a check.
+func (r *Report) Failed() int {
+	n := 0
+	for _, res := range r.Results {
+		if !res.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// Markdown renders a summary table, then each failing check.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Persona Test: %s\n\n", r.Persona)
+	fmt.Fprintf(&b, "**Backend:** %s  \n**Run:** %s\n\n", r.Backend, r.Started.Format("2006-01-02 15:04"))
+	if len(r.Sections) > 0 {
+		fmt.Fprintf(&b, "**Required sections:** %s\n\n", strings.Join(r.Sections, ", "))
+	}
+	fmt.Fprintf(&b, "**Result:** %d/%d probes passed\n\n", len(r.Results)-r.Failed(), len(r.Results))
+
+	b.WriteString("| Probe | Status | Words | Checks |\n")
+	b.WriteString("|-------|--------|-------|--------|\n")
+	for _, res := range r.Results {
+		status, checks := "pass", ""
+		switch {
+		case res.Error != "":
+			status, checks = "error", res.Error
+		case !res.Passed:
+			status = "fail"
+		}
+		if res.Error == "" {
+			var parts []string
+			for _, c := range res.Checks {
+				mark := "✓"
+				if !c.Passed {
+					mark = "✗"
+				}
+				parts = append(parts, mark+" "+c.Name)
+			}
+			checks = strings.Join(parts, ", ")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", res.Probe.Name, status, res.Words, strings.ReplaceAll(checks, "|", "\\|"))
+	}
+
+	for _, res := range r.Results {
+		if res.Passed || res.Error != "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", res.Probe.Name)
+		for _, c := range res.Checks {
+			if !c.Passed {
+				fmt.Fprintf(&b, "- ✗ %s", c.Name)
+				if c.Detail != "" {
+					fmt.Fprintf(&b, ": %s", c.Detail)
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// Write saves report.md and report.json to dir.
+func (r *Report) Write(dir string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, JSONFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", JSONFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MarkdownFile), []byte(r.Markdown()), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", MarkdownFile, err)
+	}
+	return nil
+}
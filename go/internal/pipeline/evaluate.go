@@ -0,0 +1,179 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
+)
+
+// evaluation holds the resolved judge settings for a pipeline run.
+type evaluation struct {
+	llm       string
+	rubric    []judge.Criterion
+	threshold float64 // 0 = score only, never re-run
+	maxReruns int
+}
+
+// newEvaluation returns the judge settings, or nil when evaluation is off.
+// A threshold (flag or config) implies evaluation.
+func newEvaluation(cfg *config.Config, opts Options) *evaluation {
+	threshold := cfg.Evaluation.Threshold
+	if opts.EvalThreshold > 0 {
+		threshold = opts.EvalThreshold
+	}
+	if !opts.Evaluate && !cfg.Evaluation.Enabled && threshold <= 0 {
+		return nil
+	}
+	e := &evaluation{
+		llm:       cfg.GetEvaluationLLM(),
+		rubric:    judge.PipelineRubric,
+		threshold: threshold,
+		maxReruns: cfg.GetMaxReruns(),
+	}
+	if len(cfg.Evaluation.Rubric) > 0 {
+		e.rubric = make([]judge.Criterion, len(cfg.Evaluation.Rubric))
+		for i, c := range cfg.Evaluation.Rubric {
+			e.rubric[i] = judge.Criterion{Name: c.Name, Description: c.Description}
+		}
+	}
+	return e
+}
+
+// evaluateStep scores a successful step. While the score is below the
+// threshold, the step is re-run with the judge's feedback, up to maxReruns
+// times; whichever attempt scored highest is kept.
+func (p *Pipeline) evaluateStep(step config.PipelineStep, runPrompt string, best StepResult) StepResult {
+	task := p.stepTask(step)
+	best.Score = p.scoreOutput(fmt.Sprintf("Step %d", step.Step), task, best.OutputFile)
+
+	for rerun := 1; rerun <= p.eval.maxReruns; rerun++ {
+		if best.Score == nil || p.eval.threshold <= 0 || best.Score.Overall >= p.eval.threshold {
+			break
+		}
+		p.printf("    Score %.1f is below threshold %.1f — re-running step %d (%d/%d)\n",
+			best.Score.Overall, p.eval.threshold, step.Step, rerun, p.eval.maxReruns)
+		p.log("Step %d (%s): score %.1f below threshold %.1f, re-run %d/%d",
+			step.Step, step.Name, best.Score.Overall, p.eval.threshold, rerun, p.eval.maxReruns)
+
+		kept, _ := os.ReadFile(best.OutputFile)
+		next := p.runStep(step, feedbackPrompt(runPrompt, best.Score), best.OutputFile)
+		if next.Status == "ok" {
+			next.Score = p.scoreOutput(fmt.Sprintf("Step %d", step.Step), task, best.OutputFile)
+		}
+		if next.Status == "ok" && next.Score != nil && next.Score.Overall >= best.Score.Overall {
+			next.Reruns = rerun
+			best = next
+			continue
+		}
+
+		// The re-run failed or scored lower: put the better output back
+		os.WriteFile(best.OutputFile, kept, 0644)
+		best.Reruns = rerun
+		p.printf("    Re-run did not improve the score — keeping the earlier output\n")
+		p.log("Step %d (%s): re-run %d did not improve on %.1f, kept earlier output", step.Step, step.Name, rerun, best.Score.Overall)
+	}
+	return best
+}
+
+// stepTask describes what a step was asked to do, for the judge.
+func (p *Pipeline) stepTask(step config.PipelineStep) string {
+	task := p.opts.Idea
+	if prefix := strings.TrimSpace(step.PromptPrefix); prefix != "" {
+		task = prefix + "\n\nIdea: " + task
+	}
+	return task
+}
+
+// feedbackPrompt adds the judge's verdict on the previous attempt to a
+// step prompt.
+func feedbackPrompt(runPrompt string, score *judge.Score) string {
+	feedback := fmt.Sprintf("A reviewer scored a previous attempt at this step %s.", score.Summary())
+	if score.Rationale != "" {
+		feedback += "\nReviewer notes: " + score.Rationale
+	}
+	return runPrompt + "\n\n=== REVIEWER FEEDBACK ===\n" + feedback +
+		"\nProduce a complete new response that addresses the weakest criteria.\n=== END REVIEWER FEEDBACK ==="
+}
+
+// scoreOutput has the judge score a file. Judge failures are reported and
+// return nil; they never fail the pipeline.
+func (p *Pipeline) scoreOutput(label, task, path string) *judge.Score {
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return p.scoreContent(label, task, string(data))
+}
+
+func (p *Pipeline) scoreContent(label, task, content string) *judge.Score {
+	score, err := judge.Evaluate(judge.Options{
+		Ctx:         p.opts.Ctx,
+		LLM:         p.eval.llm,
+		Rubric:      p.eval.rubric,
+		Task:        task,
+		Output:      content,
+		QuietStderr: p.quiet,
+	})
+	if err != nil {
+		p.printf("    Evaluation of %s failed: %v\n", strings.ToLower(label), err)
+		p.log("Evaluation of %s: FAILED (%v)", label, err)
+		return nil
+	}
+	p.printf("    %s score: %s\n", label, score.Summary())
+	p.log("Evaluation of %s: %s", label, score.Summary())
+	return score
+}
+
+// scoreVTS scores the VTS task files in vtsDir as one set.
+func (p *Pipeline) scoreVTS(vtsDir string) *judge.Score {
+	files, _ := filepath.Glob(filepath.Join(vtsDir, "vts-*.md"))
+	if len(files) == 0 {
+		return nil
+	}
+	sort.Strings(files)
+	p.printf("\n>>> Evaluating the VTS set (%d tasks)...\n", len(files))
+	var b strings.Builder
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "=== %s ===\n%s\n\n", filepath.Base(f), strings.TrimSpace(string(data)))
+	}
+	task := "Break this idea down into a complete, implementable set of Vern Task Spec (VTS) task files:\n\n" + p.opts.Idea
+	return p.scoreContent("VTS set", task, b.String())
+}
+
+// writeQuality renders the evaluation section of pipeline-status.md.
+func (p *Pipeline) writeQuality(b *strings.Builder) {
+	if p.eval == nil {
+		return
+	}
+	b.WriteString("\n## Quality\n\n")
+	names := make([]string, len(p.eval.rubric))
+	for i, c := range p.eval.rubric {
+		names[i] = c.Name
+	}
+	fmt.Fprintf(b, "**Judge:** %s | **Rubric:** %s", p.eval.llm, strings.Join(names, ", "))
+	if p.eval.threshold > 0 {
+		fmt.Fprintf(b, " | **Re-run below:** %.1f", p.eval.threshold)
+	}
+	b.WriteString("\n\n")
+
+	for _, r := range p.results {
+		if r.Score != nil {
+			fmt.Fprintf(b, "- Step %d (%s): %s\n", r.StepNum, r.Name, r.Score.Summary())
+		}
+	}
+	if p.vtsScore != nil {
+		fmt.Fprintf(b, "- VTS set: %s\n", p.vtsScore.Summary())
+	}
+	if p.vernholeScore != nil {
+		fmt.Fprintf(b, "- VernHole synthesis: %s\n", p.vernholeScore.Summary())
+	}
+}
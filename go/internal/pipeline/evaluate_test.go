@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
+)
+
+func TestNewEvaluation(t *testing.T) {
+	cfg := &config.Config{}
+	if e := newEvaluation(cfg, Options{}); e != nil {
+		t.Fatalf("evaluation should be off by default, got %+v", e)
+	}
+
+	e := newEvaluation(cfg, Options{EvalThreshold: 3})
+	if e == nil || e.threshold != 3 || e.maxReruns != 1 || e.llm != "claude" {
+		t.Fatalf("threshold flag should enable evaluation, got %+v", e)
+	}
+	if len(e.rubric) != len(judge.PipelineRubric) {
+		t.Errorf("want default rubric, got %+v", e.rubric)
+	}
+
+	cfg.Evaluation = config.EvaluationConfig{
+		Enabled:   true,
+		LLM:       "gemini",
+		Threshold: 2.5,
+		MaxReruns: 3,
+		Rubric:    []config.RubricCriterion{{Name: "novelty", Description: "Not obvious"}},
+	}
+	e = newEvaluation(cfg, Options{EvalThreshold: 4})
+	if e.llm != "gemini" || e.threshold != 4 || e.maxReruns != 3 {
+		t.Errorf("evaluation = %+v", e)
+	}
+	if len(e.rubric) != 1 || e.rubric[0].Name != "novelty" {
+		t.Errorf("rubric = %+v", e.rubric)
+	}
+}
+
+func TestFeedbackPrompt(t *testing.T) {
+	score := &judge.Score{
+		Scores:    []judge.CriterionScore{{Name: "completeness", Score: 2}, {Name: "actionability", Score: 3}},
+		Overall:   2.5,
+		Rationale: "Skips the data model.",
+	}
+	got := feedbackPrompt("Analyze the idea.", score)
+	for _, want := range []string{
+		"Analyze the idea.\n\n=== REVIEWER FEEDBACK ===",
+		"2.5/5 (completeness 2, actionability 3)",
+		"Reviewer notes: Skips the data model.",
+		"=== END REVIEWER FEEDBACK ===",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteStatusScores(t *testing.T) {
+	dir := t.TempDir()
+	score := &judge.Score{Scores: []judge.CriterionScore{{Name: "completeness", Score: 4}}, Overall: 4}
+	p := &Pipeline{
+		opts:       Options{DiscoveryDir: dir},
+		steps:      []config.PipelineStep{{Step: 1}, {Step: 2}},
+		statusPath: filepath.Join(dir, "pipeline-status.md"),
+		startTime:  time.Now(),
+		eval:       &evaluation{llm: "claude", rubric: []judge.Criterion{{Name: "completeness"}}, threshold: 3.5, maxReruns: 1},
+		results: []StepResult{
+			{StepNum: 1, Name: "Analysis", Status: "ok", LLMUsed: "claude", Score: score, Reruns: 1},
+			{StepNum: 2, Name: "Review", Status: "failed", LLMUsed: "codex"},
+		},
+		vtsScore: score,
+	}
+	p.writeStatus("running", []int{2})
+
+	data, err := os.ReadFile(p.statusPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := string(data)
+	for _, want := range []string{
+		"| Step | Name | LLM | Status | Duration | Size | Score |",
+		"| 1 | Analysis | claude | ok |  |  | 4.0 (1 re-run) |",
+		"| 2 | Review | codex | FAILED (exit 0, 0 attempts) |  |  | - |",
+		"## Quality",
+		"**Judge:** claude | **Rubric:** completeness | **Re-run below:** 3.5",
+		"- Step 1 (Analysis): 4.0/5 (completeness 4)",
+		"- VTS set: 4.0/5 (completeness 4)",
+	} {
+		if !strings.Contains(status, want) {
+			t.Errorf("status missing %q:\n%s", want, status)
+		}
+	}
+}
@@ -2,97 +2,37 @@ package pipeline
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/jdonohoo/vern-bot/go/internal/config"
 	"github.com/jdonohoo/vern-bot/go/internal/council"
 )
 
-// EventHandler receives pipeline progress events.
-// Implementations control how progress is displayed (console, TUI, etc).
+// EventHandler receives progress events from the discovery pipeline,
+// RunVernHole, RunHistorian, and the Oracle.
+// Implementations control how progress is displayed: the CLI renders events
+// as text (NewConsoleHandler), the TUI receives them on a channel
+// (ChannelHandler) and drives its panels from the typed data.
 type EventHandler interface {
-	OnStepStart(stepNum int, name string, llm string)
-	OnStepComplete(stepNum int, result StepResult)
-	OnStepRetry(stepNum int, attempt int, llm string)
-	OnStepFallback(stepNum int, from string, to string)
-	OnVernStart(idx int, vern council.Vern, llm string)
-	OnVernComplete(idx int, result VernHoleResult)
+	OnLog(line string) // one free-form progress line, without trailing newline
+	OnPhaseStart(phase string)
+	OnPhaseComplete(phase string, status string) // status: "ok", "failed", "skipped"
+	OnPipelineBanner(banner BannerData)
+	OnStepStart(step StepStartData)
+	OnStepRetry(retry StepRetryData)
+	OnStepFallback(fallback StepFallbackData)
+	OnStepComplete(result StepResult)
+	OnVernStart(start VernStartData)
+	OnVernComplete(done VernCompleteData)
 	OnSynthesisStart(llm string)
-	OnSynthesisComplete()
+	OnSynthesisComplete(ok bool)
 	OnPipelineComplete(results []StepResult)
-	OnPipelineBanner(idea string, dir string, mode string, stepCount int, batchMode bool, resumeFrom int, maxRetries int, timeout int)
 }
 
-// ConsoleHandler is the default EventHandler that prints to stdout (current behavior).
-type ConsoleHandler struct{}
-
-func (h *ConsoleHandler) OnStepStart(stepNum int, name string, llm string) {
-	// Handled inline in pipeline.go for now
-}
-
-func (h *ConsoleHandler) OnStepComplete(stepNum int, result StepResult) {
-	// Handled inline in pipeline.go for now
-}
-
-func (h *ConsoleHandler) OnStepRetry(stepNum int, attempt int, llm string) {
-	// Handled inline in pipeline.go for now
-}
-
-func (h *ConsoleHandler) OnStepFallback(stepNum int, from string, to string) {
-	// Handled inline in pipeline.go for now
-}
-
-func (h *ConsoleHandler) OnVernStart(idx int, vern council.Vern, llm string) {
-	fmt.Printf(">>> Vern %d: %s (%s)\n", idx+1, vern.Name, llm)
-}
-
-func (h *ConsoleHandler) OnVernComplete(idx int, result VernHoleResult) {
-	if result.Succeeded {
-		fmt.Printf("    OK: %s\n", result.Vern.ID)
-	} else {
-		fmt.Printf("    FAILED: %s (exit %d)\n", result.Vern.ID, result.ExitCode)
-	}
-}
-
-func (h *ConsoleHandler) OnSynthesisStart(llm string) {
-	fmt.Printf(">>> Synthesizing with %s...\n", llm)
-}
-
-func (h *ConsoleHandler) OnSynthesisComplete() {
-	fmt.Println("    Synthesis complete")
-}
-
-func (h *ConsoleHandler) OnPipelineComplete(results []StepResult) {
-	completed := 0
-	for _, r := range results {
-		if r.Status == "ok" {
-			completed++
-		}
-	}
-	fmt.Printf("\nPipeline: %d/%d steps completed\n", completed, len(results))
-}
-
-func (h *ConsoleHandler) OnPipelineBanner(idea string, dir string, mode string, stepCount int, batchMode bool, resumeFrom int, maxRetries int, timeout int) {
-	fmt.Println("=== VERN DISCOVERY PIPELINE ===")
-	fmt.Printf("Idea: %s\n", idea)
-	fmt.Printf("Discovery folder: %s\n", dir)
-	fmt.Println("Output: Vern Task Spec (VTS)")
-	fmt.Printf("Pipeline mode: %s (%d steps)\n", mode, stepCount)
-	if batchMode {
-		fmt.Println("Mode: batch (non-interactive)")
-	}
-	if resumeFrom > 0 {
-		fmt.Printf("Resuming from: step %d\n", resumeFrom)
-	}
-	fmt.Printf("Max retries: %d\n", maxRetries)
-	fmt.Printf("Timeout: %ds per step\n", timeout)
-	fmt.Println()
-}
-
-// ChannelHandler sends events through a channel for TUI integration.
-type ChannelHandler struct {
-	Events chan Event
-}
-
-// Event is a pipeline event sent to the TUI.
+// Event is a progress event as delivered to a FuncHandler or ChannelHandler.
 type Event struct {
 	Type string
 	Data interface{}
@@ -100,102 +40,259 @@ type Event struct {
 
 // Event type constants.
 const (
-	EventStepStart        = "step_start"
-	EventStepComplete     = "step_complete"
-	EventStepRetry        = "step_retry"
-	EventStepFallback     = "step_fallback"
-	EventVernStart        = "vern_start"
-	EventVernComplete     = "vern_complete"
-	EventSynthesisStart   = "synthesis_start"
-	EventSynthesisComplete = "synthesis_complete"
-	EventPipelineComplete = "pipeline_complete"
-	EventPipelineBanner   = "pipeline_banner"
+	EventLog               = "log"                // Data: string
+	EventPhaseStart        = "phase_start"        // Data: string (phase)
+	EventPhaseComplete     = "phase_complete"     // Data: PhaseData
+	EventStepStart         = "step_start"         // Data: StepStartData
+	EventStepComplete      = "step_complete"      // Data: StepResult
+	EventStepRetry         = "step_retry"         // Data: StepRetryData
+	EventStepFallback      = "step_fallback"      // Data: StepFallbackData
+	EventVernStart         = "vern_start"         // Data: VernStartData
+	EventVernComplete      = "vern_complete"      // Data: VernCompleteData
+	EventSynthesisStart    = "synthesis_start"    // Data: string (llm)
+	EventSynthesisComplete = "synthesis_complete" // Data: bool (ok)
+	EventPipelineComplete  = "pipeline_complete"  // Data: []StepResult
+	EventPipelineBanner    = "pipeline_banner"    // Data: BannerData
+)
+
+// Phases reported through OnPhaseStart / OnPhaseComplete.
+const (
+	PhaseHistorian   = "historian"
+	PhaseVernHole    = "vernhole"
+	PhaseOracle      = "oracle"
+	PhaseOracleApply = "oracle_apply"
+)
+
+// Fallback reasons in StepFallbackData.
+const (
+	FallbackUnavailable = "unavailable" // CLI not installed; resolved to another LLM
+	FallbackTimeout     = "timeout"     // timed out; switching immediately
+	FallbackFailed      = "failed"      // all retries failed
 )
 
+type PhaseData struct {
+	Phase  string
+	Status string
+}
+
 type StepStartData struct {
 	StepNum int
+	Total   int
 	Name    string
 	LLM     string
 }
 
 type StepRetryData struct {
-	StepNum int
-	Attempt int
-	LLM     string
+	StepNum    int
+	Name       string
+	Attempt    int // 1-based retry number (not counting the first try)
+	MaxRetries int
+	LLM        string
 }
 
 type StepFallbackData struct {
-	StepNum int
-	From    string
-	To      string
+	StepNum  int
+	From     string
+	To       string
+	Reason   string // Fallback* constant
+	Attempts int    // attempts made before falling back (FallbackFailed)
 }
 
 type VernStartData struct {
-	Index int
+	Index int // 0-based
+	Total int
 	Vern  council.Vern
 	LLM   string
 }
 
+type VernCompleteData struct {
+	Total  int
+	LLM    string // LLM the Vern ran on (after any single_llm override)
+	Result VernHoleResult
+}
+
 type BannerData struct {
 	Idea       string
 	Dir        string
 	Mode       string
-	StepCount  int
-	BatchMode  bool
+	Steps      []config.PipelineStep
 	ResumeFrom int
 	MaxRetries int
 	Timeout    int
 }
 
-func NewChannelHandler() *ChannelHandler {
-	return &ChannelHandler{
-		Events: make(chan Event, 100),
+// EventLines renders an event as the progress lines the CLI prints, without
+// trailing newlines. Blank strings are blank lines. Signal-only events (phase
+// changes, synthesis, pipeline completion) render nothing; the log lines
+// around them describe what happened.
+func EventLines(e Event) []string {
+	switch d := e.Data.(type) {
+	case string:
+		if e.Type == EventLog {
+			return []string{d}
+		}
+	case BannerData:
+		lines := []string{
+			"=== VERN DISCOVERY PIPELINE ===",
+			"Idea: " + d.Idea,
+			"Discovery folder: " + d.Dir,
+			"Output: Vern Task Spec (VTS)",
+			fmt.Sprintf("Pipeline: %s (%d steps)", d.Mode, len(d.Steps)),
+		}
+		if d.ResumeFrom > 0 {
+			lines = append(lines, fmt.Sprintf("Resuming from: step %d", d.ResumeFrom))
+		}
+		lines = append(lines, fmt.Sprintf("Retries: %d | Timeout: %ds", d.MaxRetries, d.Timeout), "")
+		for _, s := range d.Steps {
+			lines = append(lines, fmt.Sprintf("  %d. %s → %s", s.Step, s.Name, s.LLM))
+		}
+		return append(lines, "")
+	case StepStartData:
+		return []string{"", fmt.Sprintf(">>> Pass %d/%d: %s (%s)", d.StepNum, d.Total, d.Name, d.LLM)}
+	case StepRetryData:
+		return []string{fmt.Sprintf("    Retry %d/%d for step %d (%s) with %s...", d.Attempt, d.MaxRetries, d.StepNum, d.Name, d.LLM)}
+	case StepFallbackData:
+		switch d.Reason {
+		case FallbackUnavailable:
+			return []string{fmt.Sprintf("    %s not available — resolved to %s", d.From, d.To)}
+		case FallbackTimeout:
+			return []string{fmt.Sprintf("    Timeout on %s — falling back to %s", d.From, d.To)}
+		default:
+			return []string{fmt.Sprintf("    %s failed after %d attempt(s) — falling back to %s", d.From, d.Attempts, d.To)}
+		}
+	case StepResult:
+		dur := (time.Duration(d.DurationMS) * time.Millisecond).Truncate(time.Second)
+		switch d.Status {
+		case "ok":
+			if d.FellBack {
+				return []string{fmt.Sprintf("    OK (%s→%s, %d bytes, %s)", d.OriginalLLM, d.LLMUsed, d.OutputBytes, dur)}
+			}
+			return []string{fmt.Sprintf("    OK (%s, %d bytes, %s)", d.LLMUsed, d.OutputBytes, dur)}
+		case "failed":
+			if d.ErrorDetail != "" {
+				return []string{fmt.Sprintf("    FAILED after %d attempts (last exit: %d): %s", d.Attempts, d.ExitCode, d.ErrorDetail)}
+			}
+			return []string{fmt.Sprintf("    FAILED after %d attempts (last exit: %d)", d.Attempts, d.ExitCode)}
+		}
+	case VernStartData:
+		return []string{fmt.Sprintf(">>> Vern %d/%d: %s (%s)", d.Index+1, d.Total, d.Vern.Name, d.LLM)}
+	case VernCompleteData:
+		r := d.Result
+		if r.Succeeded {
+			return []string{fmt.Sprintf("    OK (%s, %dB, Vern %d/%d)", d.LLM, len(r.Output), r.Index+1, d.Total)}
+		}
+		if r.Error != "" {
+			return []string{fmt.Sprintf("    FAILED (%s, exit %d, Vern %d/%d): %s — excluding from synthesis", r.Vern.ID, r.ExitCode, r.Index+1, d.Total, r.Error)}
+		}
+		return []string{fmt.Sprintf("    FAILED (%s, exit %d, Vern %d/%d) — excluding from synthesis", r.Vern.ID, r.ExitCode, r.Index+1, d.Total)}
 	}
+	return nil
+}
+
+// FuncHandler adapts an event callback to EventHandler.
+type FuncHandler func(Event)
+
+func (f FuncHandler) OnLog(line string) {
+	f(Event{Type: EventLog, Data: line})
+}
+
+func (f FuncHandler) OnPhaseStart(phase string) {
+	f(Event{Type: EventPhaseStart, Data: phase})
+}
+
+func (f FuncHandler) OnPhaseComplete(phase string, status string) {
+	f(Event{Type: EventPhaseComplete, Data: PhaseData{Phase: phase, Status: status}})
 }
 
-func (h *ChannelHandler) OnStepStart(stepNum int, name string, llm string) {
-	h.Events <- Event{Type: EventStepStart, Data: StepStartData{StepNum: stepNum, Name: name, LLM: llm}}
+func (f FuncHandler) OnPipelineBanner(banner BannerData) {
+	f(Event{Type: EventPipelineBanner, Data: banner})
 }
 
-func (h *ChannelHandler) OnStepComplete(stepNum int, result StepResult) {
-	h.Events <- Event{Type: EventStepComplete, Data: result}
+func (f FuncHandler) OnStepStart(step StepStartData) {
+	f(Event{Type: EventStepStart, Data: step})
 }
 
-func (h *ChannelHandler) OnStepRetry(stepNum int, attempt int, llm string) {
-	h.Events <- Event{Type: EventStepRetry, Data: StepRetryData{StepNum: stepNum, Attempt: attempt, LLM: llm}}
+func (f FuncHandler) OnStepRetry(retry StepRetryData) {
+	f(Event{Type: EventStepRetry, Data: retry})
 }
 
-func (h *ChannelHandler) OnStepFallback(stepNum int, from string, to string) {
-	h.Events <- Event{Type: EventStepFallback, Data: StepFallbackData{StepNum: stepNum, From: from, To: to}}
+func (f FuncHandler) OnStepFallback(fallback StepFallbackData) {
+	f(Event{Type: EventStepFallback, Data: fallback})
 }
 
-func (h *ChannelHandler) OnVernStart(idx int, vern council.Vern, llm string) {
-	h.Events <- Event{Type: EventVernStart, Data: VernStartData{Index: idx, Vern: vern, LLM: llm}}
+func (f FuncHandler) OnStepComplete(result StepResult) {
+	f(Event{Type: EventStepComplete, Data: result})
 }
 
-func (h *ChannelHandler) OnVernComplete(idx int, result VernHoleResult) {
-	h.Events <- Event{Type: EventVernComplete, Data: result}
+func (f FuncHandler) OnVernStart(start VernStartData) {
+	f(Event{Type: EventVernStart, Data: start})
 }
 
-func (h *ChannelHandler) OnSynthesisStart(llm string) {
-	h.Events <- Event{Type: EventSynthesisStart, Data: llm}
+func (f FuncHandler) OnVernComplete(done VernCompleteData) {
+	f(Event{Type: EventVernComplete, Data: done})
 }
 
-func (h *ChannelHandler) OnSynthesisComplete() {
-	h.Events <- Event{Type: EventSynthesisComplete}
+func (f FuncHandler) OnSynthesisStart(llm string) {
+	f(Event{Type: EventSynthesisStart, Data: llm})
 }
 
-func (h *ChannelHandler) OnPipelineComplete(results []StepResult) {
-	h.Events <- Event{Type: EventPipelineComplete, Data: results}
+func (f FuncHandler) OnSynthesisComplete(ok bool) {
+	f(Event{Type: EventSynthesisComplete, Data: ok})
 }
 
-func (h *ChannelHandler) OnPipelineBanner(idea string, dir string, mode string, stepCount int, batchMode bool, resumeFrom int, maxRetries int, timeout int) {
-	h.Events <- Event{Type: EventPipelineBanner, Data: BannerData{
-		Idea: idea, Dir: dir, Mode: mode, StepCount: stepCount,
-		BatchMode: batchMode, ResumeFrom: resumeFrom, MaxRetries: maxRetries, Timeout: timeout,
-	}}
+func (f FuncHandler) OnPipelineComplete(results []StepResult) {
+	f(Event{Type: EventPipelineComplete, Data: results})
+}
+
+// NewConsoleHandler returns the handler behind CLI output: each event is
+// rendered with EventLines and written to w (stdout when nil).
+func NewConsoleHandler(w io.Writer) FuncHandler {
+	if w == nil {
+		w = os.Stdout
+	}
+	return func(e Event) {
+		for _, line := range EventLines(e) {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// ChannelHandler sends events through a channel for TUI integration.
+type ChannelHandler struct {
+	FuncHandler
+	Events chan Event
+}
+
+// NewChannelHandler sends events on events until done is closed (pass the
+// run's ctx.Done() so a cancelled run never blocks on a reader that left).
+func NewChannelHandler(events chan Event, done <-chan struct{}) *ChannelHandler {
+	h := &ChannelHandler{Events: events}
+	h.FuncHandler = func(e Event) {
+		select {
+		case h.Events <- e:
+		case <-done:
+		}
+	}
+	return h
+}
+
+// handlerOrConsole returns h, or a stdout console handler when h is nil.
+func handlerOrConsole(h EventHandler) EventHandler {
+	if h == nil {
+		return NewConsoleHandler(nil)
+	}
+	return h
+}
+
+// logLines formats a message and sends it to h one line at a time, so
+// "\n>>> X\n" becomes a blank line followed by ">>> X".
+func logLines(h EventHandler, format string, args ...interface{}) {
+	msg := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	for _, line := range strings.Split(msg, "\n") {
+		h.OnLog(line)
+	}
 }
 
 // Ensure both handlers satisfy the interface at compile time.
-var _ EventHandler = (*ConsoleHandler)(nil)
+var _ EventHandler = FuncHandler(nil)
 var _ EventHandler = (*ChannelHandler)(nil)
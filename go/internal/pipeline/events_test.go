@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func TestEventLines(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   Event
+		want []string
+	}{
+		{"log", Event{Type: EventLog, Data: "hello"}, []string{"hello"}},
+		{"phase start", Event{Type: EventPhaseStart, Data: PhaseVernHole}, nil},
+		{"step start", Event{Type: EventStepStart, Data: StepStartData{StepNum: 2, Total: 5, Name: "Refinement", LLM: "codex"}},
+			[]string{"", ">>> Pass 2/5: Refinement (codex)"}},
+		{"step ok", Event{Type: EventStepComplete, Data: StepResult{Status: "ok", LLMUsed: "claude", OutputBytes: 42, DurationMS: 3400}},
+			[]string{"    OK (claude, 42 bytes, 3s)"}},
+		{"step fallback ok", Event{Type: EventStepComplete, Data: StepResult{Status: "ok", FellBack: true, OriginalLLM: "codex", LLMUsed: "claude", OutputBytes: 7}},
+			[]string{"    OK (codex→claude, 7 bytes, 0s)"}},
+		{"step skipped", Event{Type: EventStepComplete, Data: StepResult{Status: "skipped"}}, nil},
+		{"fallback timeout", Event{Type: EventStepFallback, Data: StepFallbackData{From: "codex", To: "claude", Reason: FallbackTimeout}},
+			[]string{"    Timeout on codex — falling back to claude"}},
+		{"vern start", Event{Type: EventVernStart, Data: VernStartData{Index: 0, Total: 3, Vern: council.Vern{Name: "Startup Vern"}, LLM: "gemini"}},
+			[]string{">>> Vern 1/3: Startup Vern (gemini)"}},
+		{"vern failed", Event{Type: EventVernComplete, Data: VernCompleteData{Total: 3, Result: VernHoleResult{Index: 2, Vern: council.Vern{ID: "yolo"}, ExitCode: 1}}},
+			[]string{"    FAILED (yolo, exit 1, Vern 3/3) — excluding from synthesis"}},
+	}
+	for _, tt := range tests {
+		if got := EventLines(tt.ev); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: EventLines = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLogLines(t *testing.T) {
+	var buf bytes.Buffer
+	logLines(NewConsoleHandler(&buf), "\n>>> %s\n", "Synthesizing")
+	if got, want := buf.String(), "\n>>> Synthesizing\n"; got != want {
+		t.Errorf("console output = %q, want %q", got, want)
+	}
+
+	var lines []string
+	logLines(FuncHandler(func(e Event) { lines = append(lines, e.Data.(string)) }), "a\nb\n")
+	if !reflect.DeepEqual(lines, []string{"a", "b"}) {
+		t.Errorf("lines = %q", lines)
+	}
+}
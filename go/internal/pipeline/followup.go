@@ -0,0 +1,226 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+)
+
+// FollowUpDir is where follow-up questions and answers are kept, inside a
+// VernHole output dir.
+const FollowUpDir = "followups"
+
+// FollowUpTranscript is the running Q&A log in FollowUpDir.
+const FollowUpTranscript = "transcript.md"
+
+// maxTranscriptContext caps how much of the earlier transcript is fed back
+// to the Verns, keeping the most recent exchanges.
+const maxTranscriptContext = 12000
+
+// FollowUpOptions configures AskVernHole.
+type FollowUpOptions struct {
+	Ctx         context.Context // optional: cancelled on TUI quit
+	Dir         string          // output dir of a finished VernHole run
+	Vern        string          // optional: ask only this Vern ID (default: the whole council)
+	Question    string
+	Idea        string // required only when Dir has no council.json
+	AgentsDir   string
+	Timeout     int          // seconds (0: llm.RunOptions' default)
+	OverrideLLM string       // override all Vern LLMs (default: the one recorded in council.json)
+	Events      EventHandler // optional: progress events (nil prints to stdout)
+}
+
+// FollowUpAnswer is one Vern's answer to a follow-up question.
+type FollowUpAnswer struct {
+	Vern      council.Vern
+	LLM       string
+	Answer    string
+	Succeeded bool
+	Error     string
+}
+
+// AskVernHole puts a follow-up question to the council of a finished
+// VernHole run, or to one Vern. Each Vern answers with its own latest output,
+// the synthesis, and earlier follow-ups as context. Answers are appended to
+// followups/transcript.md, whose path is returned.
+func AskVernHole(opts FollowUpOptions) ([]FollowUpAnswer, string, error) {
+	events := handlerOrConsole(opts.Events)
+	question := strings.TrimSpace(opts.Question)
+	if question == "" {
+		return nil, "", fmt.Errorf("question is required")
+	}
+
+	synthesis, err := os.ReadFile(filepath.Join(opts.Dir, "synthesis.md"))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s has no synthesis.md; finish (or --retry-failed) the VernHole first", opts.Dir)
+	}
+	manifest, selected, err := loadCouncil(opts.Dir, opts.Idea, opts.AgentsDir)
+	if errors.Is(err, errIdeaUnknown) {
+		return nil, "", fmt.Errorf("%w; pass it with --idea", err)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	latest := latestPositions(loadHistory(opts.Dir, manifest.Rounds, selected), len(selected))
+
+	asked := make([]int, 0, len(selected))
+	for i, v := range selected {
+		if opts.Vern == "" || v.ID == opts.Vern {
+			asked = append(asked, i)
+		}
+	}
+	if len(asked) == 0 {
+		ids := make([]string, len(selected))
+		for i, v := range selected {
+			ids[i] = v.ID
+		}
+		return nil, "", fmt.Errorf("%q is not on this council (members: %s)", opts.Vern, strings.Join(ids, ", "))
+	}
+
+	overrideLLM := manifest.OverrideLLM
+	if opts.OverrideLLM != "" {
+		overrideLLM = opts.OverrideLLM
+	}
+	transcriptPath := filepath.Join(opts.Dir, FollowUpDir, FollowUpTranscript)
+	earlier, _ := os.ReadFile(transcriptPath)
+
+	target := "the council"
+	if opts.Vern != "" {
+		target = selected[asked[0]].Name
+	}
+	logLines(events, "=== VERNHOLE FOLLOW-UP ===\nAsking %s: %s\n", target, question)
+
+	answers := make([]FollowUpAnswer, len(asked))
+	var wg sync.WaitGroup
+	for n, idx := range asked {
+		wg.Add(1)
+		go func(n, idx int) {
+			defer wg.Done()
+			v := selected[idx]
+			vernLLM := v.LLM
+			if overrideLLM != "" {
+				vernLLM = overrideLLM
+			}
+			events.OnVernStart(VernStartData{Index: idx, Total: len(selected), Vern: v, LLM: vernLLM})
+
+			result, err := llm.Run(llm.RunOptions{
+				Ctx:       opts.Ctx,
+				LLM:       vernLLM,
+				Prompt:    followUpPrompt(manifest.Idea, latest[idx], llm.StripSignOff(string(synthesis)), string(earlier), question),
+				Persona:   v.ID,
+				Timeout:   time.Duration(opts.Timeout) * time.Second,
+				AgentsDir: opts.AgentsDir,
+				Step:      "followup",
+				JokesFile: jokesFile(opts.Dir),
+			})
+
+			a := FollowUpAnswer{Vern: v, LLM: vernLLM}
+			r := VernHoleResult{Index: idx, Vern: v, ExitCode: 1}
+			if err == nil && result.ExitCode == 0 && strings.TrimSpace(result.Output) != "" {
+				a.Answer, a.Succeeded = strings.TrimSpace(result.Output), true
+				r.Output, r.Succeeded, r.ExitCode = result.Output, true, 0
+			} else {
+				if result != nil {
+					r.ExitCode = result.ExitCode
+				}
+				if result != nil && result.Stderr != "" {
+					a.Error = llm.FirstLine(result.Stderr)
+				} else if err != nil {
+					a.Error = err.Error()
+				} else {
+					a.Error = "empty answer"
+				}
+				r.Error = a.Error
+			}
+			events.OnVernComplete(VernCompleteData{Total: len(selected), LLM: vernLLM, Result: r})
+			answers[n] = a
+		}(n, idx)
+	}
+	wg.Wait()
+
+	if err := appendFollowUp(transcriptPath, question, target, answers); err != nil {
+		return answers, transcriptPath, err
+	}
+	for _, a := range answers {
+		if a.Succeeded {
+			return answers, transcriptPath, nil
+		}
+	}
+	return answers, transcriptPath, fmt.Errorf("no Vern answered the follow-up")
+}
+
+// followUpPrompt builds a Vern's follow-up prompt from its own latest
+// position, the synthesis, and the tail of the earlier transcript.
+func followUpPrompt(idea string, own VernHoleResult, synthesis, earlier, question string) string {
+	var b strings.Builder
+	b.WriteString("You took part in a VernHole council on the idea below. The council has finished; you are now answering a follow-up question about its conclusions.\n\n")
+	b.WriteString("=== ORIGINAL IDEA ===\n" + strings.TrimSpace(idea) + "\n=== END ORIGINAL IDEA ===\n\n")
+	if own.Succeeded {
+		b.WriteString("=== YOUR EARLIER ANALYSIS ===\n" + strings.TrimSpace(own.Output) + "\n=== END YOUR EARLIER ANALYSIS ===\n\n")
+	} else {
+		b.WriteString("(Your earlier analysis is unavailable; your run failed. Answer from the synthesis.)\n\n")
+	}
+	b.WriteString("=== COUNCIL SYNTHESIS ===\n" + strings.TrimSpace(synthesis) + "\n=== END COUNCIL SYNTHESIS ===\n\n")
+	if earlier = strings.TrimSpace(earlier); earlier != "" {
+		if len(earlier) > maxTranscriptContext {
+			earlier = "...\n" + transcriptTail(earlier, maxTranscriptContext)
+		}
+		b.WriteString("=== EARLIER FOLLOW-UPS ===\n" + earlier + "\n=== END EARLIER FOLLOW-UPS ===\n\n")
+	}
+	b.WriteString("=== QUESTION ===\n" + question + "\n=== END QUESTION ===\n\n")
+	b.WriteString("Answer in character. Build on your earlier position, or say plainly where the question changes your mind. Be direct and concise.")
+	return b.String()
+}
+
+// transcriptTail returns at most max bytes from the end of s, starting at a
+// line boundary when there is one, and never inside a multi-byte rune.
+func transcriptTail(s string, max int) string {
+	tail := s[len(s)-max:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		return tail[i+1:]
+	}
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return tail
+}
+
+// appendFollowUp adds one question and its answers to the transcript.
+func appendFollowUp(path, question, target string, answers []FollowUpAnswer) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", FollowUpDir, err)
+	}
+	var b strings.Builder
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		b.WriteString("# VernHole Follow-ups\n")
+	}
+	fmt.Fprintf(&b, "\n## Q: %s\n\n", question)
+	fmt.Fprintf(&b, "_Asked %s on %s_\n", target, time.Now().Format("2006-01-02 15:04"))
+	for _, a := range answers {
+		fmt.Fprintf(&b, "\n### %s (%s)\n\n", a.Vern.Name, a.LLM)
+		if a.Succeeded {
+			b.WriteString(a.Answer + "\n")
+		} else {
+			fmt.Fprintf(&b, "_No answer: %s_\n", a.Error)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("open transcript: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("write transcript: %w", err)
+	}
+	return nil
+}
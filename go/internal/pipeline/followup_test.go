@@ -0,0 +1,157 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func TestFollowUpPrompt(t *testing.T) {
+	own := VernHoleResult{Succeeded: true, Output: "Ship the MVP first."}
+	prompt := followUpPrompt("a todo app", own, "The council agrees.", "## Q: earlier?", "What about auth?")
+
+	for _, want := range []string{
+		"=== ORIGINAL IDEA ===\na todo app",
+		"=== YOUR EARLIER ANALYSIS ===\nShip the MVP first.",
+		"=== COUNCIL SYNTHESIS ===\nThe council agrees.",
+		"=== EARLIER FOLLOW-UPS ===\n## Q: earlier?",
+		"=== QUESTION ===\nWhat about auth?",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(prompt, verdictInstructions) {
+		t.Error("follow-up prompt should not ask for a verdict")
+	}
+
+	prompt = followUpPrompt("a todo app", VernHoleResult{}, "s", "", "q")
+	if strings.Contains(prompt, "YOUR EARLIER ANALYSIS") || strings.Contains(prompt, "EARLIER FOLLOW-UPS") {
+		t.Errorf("empty sections should be omitted:\n%s", prompt)
+	}
+
+	long := strings.Repeat("x", maxTranscriptContext+100)
+	prompt = followUpPrompt("idea", own, "s", long, "q")
+	if strings.Count(prompt, "x") > maxTranscriptContext {
+		t.Error("earlier transcript should be truncated")
+	}
+}
+
+func TestTranscriptTail(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{"line boundary", "first line\nsecond\nthird", 12, "third"},
+		{"no newline", "abcdef", 3, "def"},
+		{"mid rune", "a—b—c", 6, "b—c"}, // the cut lands inside the first em-dash
+	}
+	for _, tt := range tests {
+		got := transcriptTail(tt.s, tt.max)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("%s: transcriptTail(%q, %d) = %q, want %q", tt.name, tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestAppendFollowUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FollowUpDir, FollowUpTranscript)
+	answers := []FollowUpAnswer{
+		{Vern: council.Vern{Name: "Startup Vern"}, LLM: "claude", Answer: "Use magic links.", Succeeded: true},
+		{Vern: council.Vern{Name: "Paranoid Vern"}, LLM: "codex", Error: "timed out"},
+	}
+	if err := appendFollowUp(path, "What about auth?", "the council", answers); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendFollowUp(path, "And billing?", "Startup Vern", answers[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if strings.Count(got, "# VernHole Follow-ups") != 1 {
+		t.Errorf("header should be written once:\n%s", got)
+	}
+	for _, want := range []string{
+		"## Q: What about auth?",
+		"### Startup Vern (claude)\n\nUse magic links.",
+		"### Paranoid Vern (codex)\n\n_No answer: timed out_",
+		"## Q: And billing?",
+		"_Asked Startup Vern on ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("transcript missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "What about auth?") > strings.Index(got, "And billing?") {
+		t.Error("questions should be appended in order")
+	}
+}
+
+func TestAskVernHole_Validation(t *testing.T) {
+	dir := t.TempDir()
+	manifest := &CouncilManifest{
+		Idea:    "a todo app",
+		Rounds:  1,
+		Members: []ManifestMember{{Index: 1, ID: "startup", Name: "Startup Vern", LLM: "claude"}},
+	}
+	if err := WriteManifest(filepath.Join(dir, ManifestFile), manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		synth    bool
+		vern     string
+		question string
+		wantErr  string
+	}{
+		{"no question", true, "", "  ", "question is required"},
+		{"no synthesis", false, "", "why?", "no synthesis.md"},
+		{"unknown vern", true, "yolo", "why?", "members: startup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synth := filepath.Join(dir, "synthesis.md")
+			os.Remove(synth)
+			if tt.synth {
+				writeFile(t, synth, "# Synthesis")
+			}
+			_, _, err := AskVernHole(FollowUpOptions{
+				Dir:       dir,
+				Vern:      tt.vern,
+				Question:  tt.question,
+				AgentsDir: t.TempDir(),
+				Events:    FuncHandler(func(Event) {}),
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAskVernHole_NoManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "synthesis.md"), "# Synthesis")
+	writeFile(t, filepath.Join(dir, "01-startup.md"), "Ship it.")
+
+	_, _, err := AskVernHole(FollowUpOptions{
+		Dir:       dir,
+		Question:  "why?",
+		AgentsDir: t.TempDir(),
+		Events:    FuncHandler(func(Event) {}),
+	})
+	if err == nil || !strings.Contains(err.Error(), "--idea") {
+		t.Errorf("err = %v, want a hint to pass --idea", err)
+	}
+}
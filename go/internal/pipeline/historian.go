@@ -15,15 +15,15 @@ import (
 
 // HistorianOptions configures a Historian invocation.
 type HistorianOptions struct {
-	Ctx        context.Context
-	TargetDir  string // Directory to index (input/ for pipeline, user-specified for standalone)
-	OutputFile string // Where to write input-history.md
-	PromptFile string // Optional: prompt.md to update with reference to the index
-	AgentsDir  string
-	Timeout    int // seconds
-	LLMName     string // override LLM (default: gemini)
-	QuietStderr bool   // suppress stderr (TUI mode)
-	OnLog       func(string)
+	Ctx         context.Context
+	TargetDir   string // Directory to index (input/ for pipeline, user-specified for standalone)
+	OutputFile  string // Where to write input-history.md
+	PromptFile  string // Optional: prompt.md to update with reference to the index
+	AgentsDir   string
+	Timeout     int          // seconds
+	LLMName     string       // override LLM (default: gemini)
+	QuietStderr bool         // suppress stderr (TUI mode)
+	Events      EventHandler // optional: progress events (nil prints to stdout)
 }
 
 // HistorianResult holds the outcome of a Historian run.
@@ -39,6 +39,19 @@ type HistorianResult struct {
 // RunHistorian scans a directory, builds a prompt from its contents, calls an LLM
 // (preferring gemini for its large context window), and writes input-history.md.
 func RunHistorian(opts HistorianOptions) (*HistorianResult, error) {
+	opts.Events = handlerOrConsole(opts.Events)
+	opts.Events.OnPhaseStart(PhaseHistorian)
+	result, err := runHistorian(opts)
+	status := phaseStatus(err)
+	if err == nil && result.Skipped {
+		status = "skipped"
+	}
+	opts.Events.OnPhaseComplete(PhaseHistorian, status)
+	return result, err
+}
+
+func runHistorian(opts HistorianOptions) (*HistorianResult, error) {
+	logFn := opts.Events.OnLog
 	if opts.Ctx == nil {
 		opts.Ctx = context.Background()
 	}
@@ -70,10 +83,6 @@ func RunHistorian(opts HistorianOptions) (*HistorianResult, error) {
 	}
 
 	if fileCount == 0 {
-		logFn := opts.OnLog
-		if logFn == nil {
-			logFn = func(string) {}
-		}
 		logFn(fmt.Sprintf("Historian: prompt only — no files to index in %s (skipped)", opts.TargetDir))
 		return &HistorianResult{Skipped: true}, nil
 	}
@@ -81,11 +90,6 @@ func RunHistorian(opts HistorianOptions) (*HistorianResult, error) {
 	// Resolve LLM: prefer gemini, fall back if unavailable
 	llmName, fellBack := resolveHistorianLLM(opts.LLMName)
 
-	logFn := opts.OnLog
-	if logFn == nil {
-		logFn = func(string) {}
-	}
-
 	if fellBack {
 		logFn(fmt.Sprintf("WARNING: Gemini is not installed. Falling back to %s.", llmName))
 		logFn("The Historian is designed for Gemini's 2M token context window.")
@@ -175,6 +179,7 @@ Tag key items inline as they appear:
 		AllowFileRead: true,
 		WorkingDir:    absDir,
 		QuietStderr:   opts.QuietStderr,
+		Step:          "historian",
 	})
 	if err != nil {
 		return nil, fmt.Errorf("historian LLM call failed: %w", err)
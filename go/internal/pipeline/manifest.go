@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+// ManifestFile records how a VernHole council was chosen, so a run can be
+// reproduced (vern hole --seed) or inspected in a bug report.
+const ManifestFile = "council.json"
+
+// ManifestMember is one summoned Vern, in output-file order.
+type ManifestMember struct {
+	Index  int    `json:"index"` // 1-based; matches the NN- file prefix
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	LLM    string `json:"llm"`
+	Reason string `json:"reason,omitempty"` // why smart selection picked this Vern
+}
+
+// CouncilManifest is the council.json written to a VernHole output dir.
+type CouncilManifest struct {
+	Idea        string           `json:"idea"`
+	Council     string           `json:"council"`           // tier name or bare count as requested
+	Display     string           `json:"display,omitempty"` // council display name
+	Seed        int64            `json:"seed"`
+	Rounds      int              `json:"rounds"`
+	Rationale   string           `json:"rationale,omitempty"` // smart selection summary
+	OverrideLLM string           `json:"override_llm,omitempty"`
+	Context     string           `json:"context,omitempty"` // prior discovery plan fed to every Vern
+	Members     []ManifestMember `json:"members"`
+}
+
+func newManifest(opts *VernHoleOptions, tierName, display string, seed int64, rounds int, selected []council.Vern) *CouncilManifest {
+	m := &CouncilManifest{
+		Idea:        opts.Idea,
+		Council:     tierName,
+		Display:     display,
+		Seed:        seed,
+		Rounds:      rounds,
+		OverrideLLM: opts.OverrideLLM,
+		Context:     opts.Context,
+		Members:     []ManifestMember{},
+	}
+	for i, v := range selected {
+		m.Members = append(m.Members, ManifestMember{Index: i + 1, ID: v.ID, Name: v.Name, LLM: v.LLM})
+	}
+	return m
+}
+
+// WriteManifest writes the council manifest as indented JSON.
+func WriteManifest(path string, m *CouncilManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest loads a council.json written by a previous VernHole run.
+func ReadManifest(path string) (*CouncilManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m CouncilManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &m, nil
+}
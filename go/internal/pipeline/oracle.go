@@ -22,7 +22,7 @@ type OracleConsultOptions struct {
 	AgentsDir    string
 	SynthesisLLM string
 	Timeout      int
-	OnLog        func(string)
+	Events       EventHandler // optional: progress events (nil prints to stdout)
 }
 
 // OracleApplyOptions configures a standalone Oracle apply run.
@@ -34,32 +34,32 @@ type OracleApplyOptions struct {
 	AgentsDir    string
 	SynthesisLLM string
 	Timeout      int
-	OnLog        func(string)
+	Events       EventHandler // optional: progress events (nil prints to stdout)
 }
 
-// oracleLog prints to stdout in CLI mode, or routes to OnLog callback.
-func oracleLog(onLog func(string), format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if onLog != nil {
-		trimmed := strings.TrimSpace(msg)
-		if trimmed != "" {
-			onLog(trimmed)
-		}
-	} else {
-		fmt.Print(msg)
-	}
+// oracleLog sends progress text to the run's event handler.
+func oracleLog(events EventHandler, format string, args ...interface{}) {
+	logLines(handlerOrConsole(events), format, args...)
 }
 
 // RunOracleConsult generates an Oracle vision from VernHole synthesis + VTS tasks.
 func RunOracleConsult(opts OracleConsultOptions) error {
+	opts.Events = handlerOrConsole(opts.Events)
+	opts.Events.OnPhaseStart(PhaseOracle)
+	err := consultOracle(opts)
+	opts.Events.OnPhaseComplete(PhaseOracle, phaseStatus(err))
+	return err
+}
+
+func consultOracle(opts OracleConsultOptions) error {
 	synthesisFile := filepath.Join(opts.SynthesisDir, "synthesis.md")
 	data, err := os.ReadFile(synthesisFile)
 	if err != nil {
 		return fmt.Errorf("no synthesis file found at %s: %w", synthesisFile, err)
 	}
 
-	oracleLog(opts.OnLog, "=== CONSULTING THE ORACLE ===\n")
-	oracleLog(opts.OnLog, "Reading the VernHole synthesis and VTS tasks...\n")
+	oracleLog(opts.Events, "=== CONSULTING THE ORACLE ===\n")
+	oracleLog(opts.Events, "Reading the VernHole synthesis and VTS tasks...\n")
 
 	// Build VTS task contents
 	var vtsIndex, vtsContents strings.Builder
@@ -73,10 +73,10 @@ func RunOracleConsult(opts OracleConsultOptions) error {
 			vtsCount++
 			vtsIndex.WriteString(fmt.Sprintf("\n- %s", e.Name()))
 			vtsData, _ := os.ReadFile(filepath.Join(opts.VTSDir, e.Name()))
-			vtsContents.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", e.Name(), string(vtsData)))
+			vtsContents.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", e.Name(), llm.StripSignOff(string(vtsData))))
 		}
 	}
-	oracleLog(opts.OnLog, "Found %d VTS task files in %s\n", vtsCount, opts.VTSDir)
+	oracleLog(opts.Events, "Found %d VTS task files in %s\n", vtsCount, opts.VTSDir)
 
 	// Build the prompt — adapt instructions based on whether VTS tasks exist
 	var instructions string
@@ -128,7 +128,7 @@ VTS TASK FILES:
 %s
 
 VERNHOLE SYNTHESIS:
-%s`, instructions, opts.Idea, vtsIndex.String(), vtsContents.String(), string(data))
+%s`, instructions, opts.Idea, vtsIndex.String(), vtsContents.String(), llm.StripSignOff(string(data)))
 
 	synthesisLLM := opts.SynthesisLLM
 	if synthesisLLM == "" {
@@ -161,6 +161,8 @@ VERNHOLE SYNTHESIS:
 		Persona:    "oracle",
 		Timeout:    time.Duration(timeout) * time.Second,
 		AgentsDir:  opts.AgentsDir,
+		Step:       "oracle",
+		JokesFile:  jokesFile(filepath.Dir(outputFile)),
 	})
 
 	if err != nil || result.ExitCode != 0 {
@@ -171,19 +173,27 @@ VERNHOLE SYNTHESIS:
 		return fmt.Errorf("oracle consult failed (exit %d)", result.ExitCode)
 	}
 
-	oracleLog(opts.OnLog, "\nOracle vision written to: %s\n", outputFile)
+	oracleLog(opts.Events, "\nOracle vision written to: %s\n", outputFile)
 	return nil
 }
 
 // RunOracleApply has Architect Vern rewrite VTS tasks based on Oracle's vision.
 func RunOracleApply(opts OracleApplyOptions) error {
+	opts.Events = handlerOrConsole(opts.Events)
+	opts.Events.OnPhaseStart(PhaseOracleApply)
+	err := applyOracle(opts)
+	opts.Events.OnPhaseComplete(PhaseOracleApply, phaseStatus(err))
+	return err
+}
+
+func applyOracle(opts OracleApplyOptions) error {
 	oracleData, err := os.ReadFile(opts.VisionFile)
 	if err != nil {
 		return fmt.Errorf("no oracle vision file found at %s: %w", opts.VisionFile, err)
 	}
 
-	oracleLog(opts.OnLog, "=== ORACLE APPLYING VISION ===\n")
-	oracleLog(opts.OnLog, "Architect Vern is rewriting VTS tasks...\n")
+	oracleLog(opts.Events, "=== ORACLE APPLYING VISION ===\n")
+	oracleLog(opts.Events, "Architect Vern is rewriting VTS tasks...\n")
 
 	// Build existing VTS contents
 	var vtsContents strings.Builder
@@ -193,7 +203,7 @@ func RunOracleApply(opts OracleApplyOptions) error {
 			continue
 		}
 		data, _ := os.ReadFile(filepath.Join(opts.VTSDir, e.Name()))
-		vtsContents.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", e.Name(), string(data)))
+		vtsContents.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", e.Name(), llm.StripSignOff(string(data))))
 	}
 
 	architectPrompt := fmt.Sprintf(`You are Architect Vern. The Oracle has spoken. Apply the Oracle's vision to produce an updated task breakdown.
@@ -208,7 +218,7 @@ ORACLE'S VISION:
 EXISTING VTS TASKS:
 %s
 
-Produce the complete updated task breakdown. Include ALL tasks (not just changed ones).`, string(oracleData), vtsContents.String())
+Produce the complete updated task breakdown. Include ALL tasks (not just changed ones).`, llm.StripSignOff(string(oracleData)), vtsContents.String())
 
 	synthesisLLM := opts.SynthesisLLM
 	if synthesisLLM == "" {
@@ -241,6 +251,8 @@ Produce the complete updated task breakdown. Include ALL tasks (not just changed
 		Persona:    "architect",
 		Timeout:    time.Duration(timeout) * time.Second,
 		AgentsDir:  opts.AgentsDir,
+		Step:       "oracle-apply",
+		JokesFile:  jokesFile(filepath.Dir(outputFile)),
 	})
 
 	if err != nil || result.ExitCode != 0 || IsFailedOutput(outputFile) {
@@ -251,6 +263,9 @@ Produce the complete updated task breakdown. Include ALL tasks (not just changed
 		return fmt.Errorf("oracle apply failed (exit %d)", result.ExitCode)
 	}
 
+	// Snapshot the current task set for the changelog before it is replaced
+	oldTasks, _ := vts.ReadDir(opts.VTSDir)
+
 	// Clear old VTS files and re-process
 	for _, e := range entries {
 		if strings.HasPrefix(e.Name(), "vts-") && strings.HasSuffix(e.Name(), ".md") {
@@ -258,7 +273,7 @@ Produce the complete updated task breakdown. Include ALL tasks (not just changed
 		}
 	}
 
-	oracleLog(opts.OnLog, "\n>>> Re-splitting updated architect breakdown into VTS task files...\n")
+	oracleLog(opts.Events, "\n>>> Re-splitting updated architect breakdown into VTS task files...\n")
 
 	// Process VTS from updated breakdown
 	archData, err := os.ReadFile(outputFile)
@@ -268,14 +283,25 @@ Produce the complete updated task breakdown. Include ALL tasks (not just changed
 
 	tasks, header, footer := vts.ParseArchitectOutput(string(archData))
 	if len(tasks) > 0 {
-		if err := vts.WriteVTSFiles(tasks, opts.VTSDir, "oracle", filepath.Base(outputFile), opts.OnLog); err != nil {
-			oracleLog(opts.OnLog, "  Error writing VTS files: %v\n", err)
+		if err := vts.WriteVTSFiles(tasks, opts.VTSDir, "oracle", filepath.Base(outputFile), opts.Events.OnLog); err != nil {
+			oracleLog(opts.Events, "  Error writing VTS files: %v\n", err)
+		}
+		if err := vts.WriteSummary(tasks, outputFile, header, footer, "", opts.Events.OnLog); err != nil {
+			oracleLog(opts.Events, "  Error writing summary: %v\n", err)
 		}
-		if err := vts.WriteSummary(tasks, outputFile, header, footer, "", opts.OnLog); err != nil {
-			oracleLog(opts.OnLog, "  Error writing summary: %v\n", err)
+
+		newTasks, err := vts.ReadDir(opts.VTSDir)
+		if err == nil {
+			changelog := filepath.Join(filepath.Dir(opts.VTSDir), vts.ChangelogFile)
+			if d, err := vts.WriteChangelog(oldTasks, newTasks, changelog, "before oracle-apply", "after oracle-apply"); err != nil {
+				oracleLog(opts.Events, "  Error writing changelog: %v\n", err)
+			} else {
+				oracleLog(opts.Events, "  Changelog: %d added, %d removed, %d changed → %s\n",
+					len(d.Added), len(d.Removed), len(d.Changed), changelog)
+			}
 		}
 	}
 
-	oracleLog(opts.OnLog, "\nOracle's vision applied. Updated VTS files in: %s\n", opts.VTSDir)
+	oracleLog(opts.Events, "\nOracle's vision applied. Updated VTS files in: %s\n", opts.VTSDir)
 	return nil
 }
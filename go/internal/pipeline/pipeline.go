@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
 	"github.com/jdonohoo/vern-bot/go/internal/llm"
 	"github.com/jdonohoo/vern-bot/go/internal/vts"
 )
@@ -27,6 +28,8 @@ type Options struct {
 	MaxRetries        int
 	VernHoleCount     int
 	VernHoleCouncil   string
+	VernHoleRounds    int
+	VernHoleSeed      int64
 	OracleFlag        bool
 	OracleApplyFlag   bool
 	ExtraContextFiles []string
@@ -35,7 +38,9 @@ type Options struct {
 	Timeout           int          // seconds
 	LLMMode           string       // override config's llm_mode
 	SingleLLM         string       // shorthand for single_llm mode with this LLM
-	OnLog             func(string) // optional callback for progress lines
+	Evaluate          bool         // score outputs with the judge (also enabled by config)
+	EvalThreshold     float64      // re-run steps scoring below this (overrides config)
+	Events            EventHandler // optional: progress events (nil prints to stdout; set also quiets LLM stderr)
 }
 
 // Pipeline orchestrates the discovery pipeline execution.
@@ -50,19 +55,16 @@ type Pipeline struct {
 	statusPath    string    // path to pipeline-status.md
 	startTime     time.Time // pipeline start time
 	mode          string    // pipeline mode (default/expanded)
+	events        EventHandler
+	quiet         bool        // suppress LLM stderr (events go to the TUI)
+	eval          *evaluation // nil unless evaluation is on
+	vernholeScore *judge.Score
+	vtsScore      *judge.Score
 }
 
-// printf prints to stdout in CLI mode, or routes to OnLog in TUI mode.
+// printf sends progress text to the pipeline's event handler.
 func (p *Pipeline) printf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if p.opts.OnLog != nil {
-		trimmed := strings.TrimSpace(msg)
-		if trimmed != "" {
-			p.opts.OnLog(trimmed)
-		}
-	} else {
-		fmt.Print(msg)
-	}
+	logLines(p.events, format, args...)
 }
 
 // Run executes the full discovery pipeline.
@@ -106,6 +108,9 @@ func Run(opts Options) error {
 		cfg:     cfg,
 		steps:   steps,
 		results: make([]StepResult, len(steps)),
+		events:  handlerOrConsole(opts.Events),
+		quiet:   opts.Events != nil,
+		eval:    newEvaluation(cfg, opts),
 	}
 
 	return p.execute(mode)
@@ -140,30 +145,15 @@ func (p *Pipeline) execute(mode string) error {
 	p.startTime = time.Now()
 	p.mode = mode
 
-	// Banner — concise in TUI mode (header already shows folder/mode/LLM),
-	// full details in CLI mode.
-	p.printf("=== VERN DISCOVERY PIPELINE ===\n")
-	if opts.OnLog != nil {
-		// TUI: show prompt path + input file count, not the raw idea
-		p.printf("Prompt: input/prompt.md\n")
-	} else {
-		// CLI: show full idea
-		p.printf("Idea: %s\n", opts.Idea)
-		p.printf("Discovery folder: %s\n", opts.DiscoveryDir)
-		p.printf("Output: Vern Task Spec (VTS)\n")
-	}
-	p.printf("Pipeline: %s (%d steps)\n", mode, len(p.steps))
-	if opts.ResumeFrom > 0 {
-		p.printf("Resuming from: step %d\n", opts.ResumeFrom)
-	}
-	p.printf("Retries: %d | Timeout: %ds\n", opts.MaxRetries, opts.Timeout)
-	p.printf("\n")
-
-	// Print pipeline steps with step numbers for coloring
-	for _, step := range p.steps {
-		p.printf("[step] %d. %s → %s\n", step.Step, step.Name, step.LLM)
-	}
-	p.printf("\n")
+	p.events.OnPipelineBanner(BannerData{
+		Idea:       opts.Idea,
+		Dir:        opts.DiscoveryDir,
+		Mode:       mode,
+		Steps:      p.steps,
+		ResumeFrom: opts.ResumeFrom,
+		MaxRetries: opts.MaxRetries,
+		Timeout:    opts.Timeout,
+	})
 
 	p.log("=== Pipeline started ===")
 	p.log("Idea: %s", opts.Idea)
@@ -217,8 +207,8 @@ func (p *Pipeline) execute(mode string) error {
 				PromptFile:   promptFile,
 				AgentsDir:    opts.AgentsDir,
 				Timeout:      opts.Timeout,
-				OnLog:        opts.OnLog,
-				QuietStderr:  opts.OnLog != nil,
+				Events:       p.events,
+				QuietStderr:  p.quiet,
 			})
 
 			if hErr != nil {
@@ -243,6 +233,7 @@ func (p *Pipeline) execute(mode string) error {
 			}
 		} else {
 			p.printf("Historian index already exists, skipping pre-step.\n")
+			p.events.OnPhaseComplete(PhaseHistorian, "skipped")
 			p.log("Historian pre-step: SKIPPED (input-history.md already exists)")
 		}
 	}
@@ -269,6 +260,7 @@ func (p *Pipeline) execute(mode string) error {
 					OutputFile: outputFile,
 					Status:     "skipped",
 				}
+				p.events.OnStepComplete(p.results[idx])
 				// Track consolidation file
 				if step.ContextMode == "all_previous" {
 					p.consolidation = outputFile
@@ -279,7 +271,7 @@ func (p *Pipeline) execute(mode string) error {
 			p.log("Step %d (%s): re-running (no valid output for resume)", stepNum, step.Name)
 		}
 
-		p.printf("\n>>> Pass %d/%d: %s (%s)\n", stepNum, len(p.steps), step.Name, step.LLM)
+		p.events.OnStepStart(StepStartData{StepNum: stepNum, Total: len(p.steps), Name: step.Name, LLM: step.LLM})
 
 		// Build prompt based on context mode
 		runPrompt := p.buildStepPrompt(step, idx)
@@ -289,179 +281,23 @@ func (p *Pipeline) execute(mode string) error {
 			p.consolidation = outputFile
 		}
 
-		// Retry loop with configurable fallback
-		succeeded := false
-		originalLLM := step.LLM
-		// Apply single_llm override if active
-		if override := p.cfg.GetOverrideLLM(); override != "" {
-			originalLLM = override
-		}
-		retryLLM := originalLLM
-		retryPersona := step.Persona
-		fellBack := false
-		totalAttempts := opts.MaxRetries + 1
-		var lastExitCode int
-		var attemptCount int
-		var duration time.Duration
-		fallbackLLM := p.cfg.GetFallbackLLM(originalLLM)
-
-		var actualLLM string
-		var lastStderr string
-		for attempt := 1; attempt <= totalAttempts; attempt++ {
-			if attempt > 1 {
-				p.printf("    Retry %d/%d for step %d (%s) with %s...\n", attempt-1, opts.MaxRetries, stepNum, step.Name, retryLLM)
-				p.log("Step %d (%s): retry %d/%d with %s", stepNum, step.Name, attempt-1, opts.MaxRetries, retryLLM)
-			}
-
-			result, runErr := llm.Run(llm.RunOptions{
-				Ctx:         opts.Ctx,
-				LLM:         retryLLM,
-				Prompt:      runPrompt,
-				OutputFile:  outputFile,
-				Persona:     retryPersona,
-				Timeout:     time.Duration(opts.Timeout) * time.Second,
-				AgentsDir:   opts.AgentsDir,
-				QuietStderr: opts.OnLog != nil,
-			})
-
-			lastExitCode = result.ExitCode
-			attemptCount = attempt
-			duration = result.Duration
-			actualLLM = result.LLMUsed
-			if result.Stderr != "" {
-				lastStderr = result.Stderr
-			} else if runErr != nil {
-				lastStderr = runErr.Error()
-			}
-
-			// Detect silent LLM swap by resolveLLM (e.g. CLI not found)
-			if actualLLM != "" && actualLLM != retryLLM && !fellBack {
-				p.printf("    %s not available — resolved to %s\n", retryLLM, actualLLM)
-				p.log("Step %d (%s): %s resolved to %s (CLI not found)", stepNum, step.Name, retryLLM, actualLLM)
-				fellBack = true
-			}
-
-			if result.ExitCode == 0 && !IsFailedOutput(outputFile) {
-				succeeded = true
-				break
-			}
-
-			// On timeout with a non-fallback LLM, switch to fallback immediately
-			if result.ExitCode == llm.ExitTimeout && fallbackLLM != "" && retryLLM != fallbackLLM {
-				p.printf("    Timeout on %s — falling back to %s\n", retryLLM, fallbackLLM)
-				p.log("Step %d (%s): timeout on %s after %s, falling back to %s", stepNum, step.Name, retryLLM, result.Duration.Truncate(time.Second), fallbackLLM)
-				retryLLM = fallbackLLM
-				fellBack = true
-			}
+		p.results[idx] = p.runStep(step, runPrompt, outputFile)
+		if p.eval != nil && p.results[idx].Status == "ok" {
+			p.results[idx] = p.evaluateStep(step, runPrompt, p.results[idx])
 		}
-
-		// Fallback: if all retries failed and we have a fallback configured, try it as final safety net
-		if !succeeded && fallbackLLM != "" && retryLLM != fallbackLLM {
-			p.printf("    %s failed after %d attempt(s) — falling back to %s\n", originalLLM, totalAttempts, fallbackLLM)
-			p.log("Step %d (%s): %s FAILED after %d attempt(s) (last exit %d), falling back to %s",
-				stepNum, step.Name, originalLLM, totalAttempts, lastExitCode, fallbackLLM)
-
-			retryLLM = fallbackLLM
-			fellBack = true
-
-			result, runErr := llm.Run(llm.RunOptions{
-				Ctx:         opts.Ctx,
-				LLM:         fallbackLLM,
-				Prompt:      runPrompt,
-				OutputFile:  outputFile,
-				Persona:     retryPersona,
-				Timeout:     time.Duration(opts.Timeout) * time.Second,
-				AgentsDir:   opts.AgentsDir,
-				QuietStderr: opts.OnLog != nil,
-			})
-
-			attemptCount++
-			lastExitCode = result.ExitCode
-			duration = result.Duration
-			actualLLM = result.LLMUsed
-			if result.Stderr != "" {
-				lastStderr = result.Stderr
-			} else if runErr != nil {
-				lastStderr = runErr.Error()
-			}
-
-			if result.ExitCode == 0 && !IsFailedOutput(outputFile) {
-				succeeded = true
-				p.printf("    %s fallback succeeded\n", fallbackLLM)
-				p.log("Step %d (%s): %s fallback OK after %s failed", stepNum, step.Name, fallbackLLM, originalLLM)
-			} else {
-				p.log("Step %d (%s): %s fallback also FAILED (exit %d)", stepNum, step.Name, fallbackLLM, result.ExitCode)
-			}
-		}
-
-		// Use the LLM that actually ran for display and tracking
-		usedLLM := retryLLM
-		if actualLLM != "" {
-			usedLLM = actualLLM
-		}
-
-		if succeeded {
-			outputBytes := fileSize(outputFile)
-			if fellBack {
-				p.printf("    OK (%s→%s, %d bytes, %s)\n", originalLLM, usedLLM, outputBytes, duration.Truncate(time.Second))
-				p.log("Step %d (%s): OK via %s (original=%s, attempt %d, %d bytes)", stepNum, step.Name, usedLLM, originalLLM, attemptCount, outputBytes)
-			} else {
-				p.printf("    OK (%s, %d bytes, %s)\n", usedLLM, outputBytes, duration.Truncate(time.Second))
-				p.log("Step %d (%s): OK (exit %d, attempt %d, llm=%s, %d bytes)", stepNum, step.Name, lastExitCode, attemptCount, usedLLM, outputBytes)
-			}
-			p.results[idx] = StepResult{
-				StepNum:     stepNum,
-				Name:        step.Name,
-				OutputFile:  outputFile,
-				Status:      "ok",
-				ExitCode:    lastExitCode,
-				Attempts:    attemptCount,
-				LLMUsed:     usedLLM,
-				OriginalLLM: originalLLM,
-				FellBack:    fellBack,
-				DurationMS:  duration.Milliseconds(),
-				OutputBytes: outputBytes,
-			}
-		} else {
-			stderrSnippet := llm.FirstLine(lastStderr)
-			if stderrSnippet != "" {
-				p.printf("    FAILED after %d attempts (last exit: %d): %s\n", attemptCount, lastExitCode, stderrSnippet)
-				p.log("Step %d (%s): FAILED (exit %d, %d attempts, original=%s, final=%s): %s", stepNum, step.Name, lastExitCode, attemptCount, originalLLM, usedLLM, stderrSnippet)
-			} else {
-				p.printf("    FAILED after %d attempts (last exit: %d)\n", attemptCount, lastExitCode)
-				p.log("Step %d (%s): FAILED (exit %d, %d attempts, original=%s, final=%s)", stepNum, step.Name, lastExitCode, attemptCount, originalLLM, usedLLM)
-			}
-
-			// Write failure marker
-			failureContent := fmt.Sprintf("# STEP FAILED\n\nStep %d (%s) failed after %d attempt(s).\nOriginal LLM: %s\nFinal LLM: %s (fallback)\nLast exit code: %d\n\nRe-run with: --resume-from %d\n",
-				stepNum, step.Name, attemptCount, originalLLM, usedLLM, lastExitCode, stepNum)
-			if lastStderr != "" {
-				failureContent += fmt.Sprintf("\n## Stderr\n\n```\n%s\n```\n", lastStderr)
-			}
-			os.WriteFile(outputFile, []byte(failureContent), 0644)
-
+		if p.results[idx].Status == "failed" {
 			failedSteps = append(failedSteps, stepNum)
-			p.results[idx] = StepResult{
-				StepNum:     stepNum,
-				Name:        step.Name,
-				OutputFile:  outputFile,
-				Status:      "failed",
-				ExitCode:    lastExitCode,
-				Attempts:    attemptCount,
-				LLMUsed:     usedLLM,
-				OriginalLLM: originalLLM,
-				FellBack:    fellBack,
-				DurationMS:  duration.Milliseconds(),
-				OutputBytes: 0,
-				ErrorDetail: stderrSnippet,
-			}
 		}
 
+		p.events.OnStepComplete(p.results[idx])
+
 		// Write structured JSON log entry + update status file
 		p.logJSON(p.results[idx])
 		p.writeStatus("running", failedSteps)
 	}
 
+	p.events.OnPipelineComplete(p.results)
+
 	// Pipeline summary
 	if len(failedSteps) > 0 {
 		p.printf("\nWARNING: %d step(s) failed: %v\n", len(failedSteps), failedSteps)
@@ -480,6 +316,10 @@ func (p *Pipeline) execute(mode string) error {
 		p.log("VTS post-processing: SKIPPED (architect step failed)")
 	} else {
 		p.processVTS(lastResult.OutputFile, vtsDir, "discovery")
+		if p.eval != nil {
+			p.vtsScore = p.scoreVTS(vtsDir)
+			p.writeStatus("pipeline_complete", failedSteps)
+		}
 	}
 
 	// Directory structure output
@@ -499,6 +339,180 @@ func (p *Pipeline) execute(mode string) error {
 	return nil
 }
 
+// runStep runs one pipeline step with retries and LLM fallback. On failure
+// the output file is replaced with a failure marker.
+func (p *Pipeline) runStep(step config.PipelineStep, runPrompt, outputFile string) StepResult {
+	opts := p.opts
+	stepNum := step.Step
+
+	// Retry loop with configurable fallback
+	succeeded := false
+	originalLLM := step.LLM
+	// Apply single_llm override if active
+	if override := p.cfg.GetOverrideLLM(); override != "" {
+		originalLLM = override
+	}
+	retryLLM := originalLLM
+	retryPersona := step.Persona
+	fellBack := false
+	totalAttempts := opts.MaxRetries + 1
+	var lastExitCode int
+	var attemptCount int
+	var duration time.Duration
+	fallbackLLM := p.cfg.GetFallbackLLM(originalLLM)
+
+	var actualLLM string
+	var lastStderr string
+	for attempt := 1; attempt <= totalAttempts; attempt++ {
+		if attempt > 1 {
+			p.events.OnStepRetry(StepRetryData{StepNum: stepNum, Name: step.Name, Attempt: attempt - 1, MaxRetries: opts.MaxRetries, LLM: retryLLM})
+			p.log("Step %d (%s): retry %d/%d with %s", stepNum, step.Name, attempt-1, opts.MaxRetries, retryLLM)
+		}
+
+		result, runErr := llm.Run(llm.RunOptions{
+			Ctx:         opts.Ctx,
+			LLM:         retryLLM,
+			Prompt:      runPrompt,
+			OutputFile:  outputFile,
+			Persona:     retryPersona,
+			Timeout:     time.Duration(opts.Timeout) * time.Second,
+			AgentsDir:   opts.AgentsDir,
+			QuietStderr: p.quiet,
+			Step:        "discovery",
+			JokesFile:   jokesFile(opts.DiscoveryDir),
+		})
+
+		lastExitCode = result.ExitCode
+		attemptCount = attempt
+		duration = result.Duration
+		actualLLM = result.LLMUsed
+		if result.Stderr != "" {
+			lastStderr = result.Stderr
+		} else if runErr != nil {
+			lastStderr = runErr.Error()
+		}
+
+		// Detect silent LLM swap by resolveLLM (e.g. CLI not found)
+		if actualLLM != "" && actualLLM != retryLLM && !fellBack {
+			p.events.OnStepFallback(StepFallbackData{StepNum: stepNum, From: retryLLM, To: actualLLM, Reason: FallbackUnavailable})
+			p.log("Step %d (%s): %s resolved to %s (CLI not found)", stepNum, step.Name, retryLLM, actualLLM)
+			fellBack = true
+		}
+
+		if result.ExitCode == 0 && !IsFailedOutput(outputFile) {
+			succeeded = true
+			break
+		}
+
+		// On timeout with a non-fallback LLM, switch to fallback immediately
+		if result.ExitCode == llm.ExitTimeout && fallbackLLM != "" && retryLLM != fallbackLLM {
+			p.events.OnStepFallback(StepFallbackData{StepNum: stepNum, From: retryLLM, To: fallbackLLM, Reason: FallbackTimeout, Attempts: attempt})
+			p.log("Step %d (%s): timeout on %s after %s, falling back to %s", stepNum, step.Name, retryLLM, result.Duration.Truncate(time.Second), fallbackLLM)
+			retryLLM = fallbackLLM
+			fellBack = true
+		}
+	}
+
+	// Fallback: if all retries failed and we have a fallback configured, try it as final safety net
+	if !succeeded && fallbackLLM != "" && retryLLM != fallbackLLM {
+		p.events.OnStepFallback(StepFallbackData{StepNum: stepNum, From: originalLLM, To: fallbackLLM, Reason: FallbackFailed, Attempts: totalAttempts})
+		p.log("Step %d (%s): %s FAILED after %d attempt(s) (last exit %d), falling back to %s",
+			stepNum, step.Name, originalLLM, totalAttempts, lastExitCode, fallbackLLM)
+
+		retryLLM = fallbackLLM
+		fellBack = true
+
+		result, runErr := llm.Run(llm.RunOptions{
+			Ctx:         opts.Ctx,
+			LLM:         fallbackLLM,
+			Prompt:      runPrompt,
+			OutputFile:  outputFile,
+			Persona:     retryPersona,
+			Timeout:     time.Duration(opts.Timeout) * time.Second,
+			AgentsDir:   opts.AgentsDir,
+			QuietStderr: p.quiet,
+			Step:        "discovery",
+			JokesFile:   jokesFile(opts.DiscoveryDir),
+		})
+
+		attemptCount++
+		lastExitCode = result.ExitCode
+		duration = result.Duration
+		actualLLM = result.LLMUsed
+		if result.Stderr != "" {
+			lastStderr = result.Stderr
+		} else if runErr != nil {
+			lastStderr = runErr.Error()
+		}
+
+		if result.ExitCode == 0 && !IsFailedOutput(outputFile) {
+			succeeded = true
+			p.printf("    %s fallback succeeded\n", fallbackLLM)
+			p.log("Step %d (%s): %s fallback OK after %s failed", stepNum, step.Name, fallbackLLM, originalLLM)
+		} else {
+			p.log("Step %d (%s): %s fallback also FAILED (exit %d)", stepNum, step.Name, fallbackLLM, result.ExitCode)
+		}
+	}
+
+	// Use the LLM that actually ran for display and tracking
+	usedLLM := retryLLM
+	if actualLLM != "" {
+		usedLLM = actualLLM
+	}
+
+	if succeeded {
+		outputBytes := fileSize(outputFile)
+		if fellBack {
+			p.log("Step %d (%s): OK via %s (original=%s, attempt %d, %d bytes)", stepNum, step.Name, usedLLM, originalLLM, attemptCount, outputBytes)
+		} else {
+			p.log("Step %d (%s): OK (exit %d, attempt %d, llm=%s, %d bytes)", stepNum, step.Name, lastExitCode, attemptCount, usedLLM, outputBytes)
+		}
+		return StepResult{
+			StepNum:     stepNum,
+			Name:        step.Name,
+			OutputFile:  outputFile,
+			Status:      "ok",
+			ExitCode:    lastExitCode,
+			Attempts:    attemptCount,
+			LLMUsed:     usedLLM,
+			OriginalLLM: originalLLM,
+			FellBack:    fellBack,
+			DurationMS:  duration.Milliseconds(),
+			OutputBytes: outputBytes,
+		}
+	}
+
+	stderrSnippet := llm.FirstLine(lastStderr)
+	if stderrSnippet != "" {
+		p.log("Step %d (%s): FAILED (exit %d, %d attempts, original=%s, final=%s): %s", stepNum, step.Name, lastExitCode, attemptCount, originalLLM, usedLLM, stderrSnippet)
+	} else {
+		p.log("Step %d (%s): FAILED (exit %d, %d attempts, original=%s, final=%s)", stepNum, step.Name, lastExitCode, attemptCount, originalLLM, usedLLM)
+	}
+
+	// Write failure marker
+	failureContent := fmt.Sprintf("# STEP FAILED\n\nStep %d (%s) failed after %d attempt(s).\nOriginal LLM: %s\nFinal LLM: %s (fallback)\nLast exit code: %d\n\nRe-run with: --resume-from %d\n",
+		stepNum, step.Name, attemptCount, originalLLM, usedLLM, lastExitCode, stepNum)
+	if lastStderr != "" {
+		failureContent += fmt.Sprintf("\n## Stderr\n\n```\n%s\n```\n", lastStderr)
+	}
+	os.WriteFile(outputFile, []byte(failureContent), 0644)
+
+	return StepResult{
+		StepNum:     stepNum,
+		Name:        step.Name,
+		OutputFile:  outputFile,
+		Status:      "failed",
+		ExitCode:    lastExitCode,
+		Attempts:    attemptCount,
+		LLMUsed:     usedLLM,
+		OriginalLLM: originalLLM,
+		FellBack:    fellBack,
+		DurationMS:  duration.Milliseconds(),
+		OutputBytes: 0,
+		ErrorDetail: stderrSnippet,
+	}
+}
+
 func (p *Pipeline) buildStepPrompt(step config.PipelineStep, idx int) string {
 	switch step.ContextMode {
 	case "prompt_only":
@@ -595,14 +609,14 @@ func (p *Pipeline) processVTS(architectFile string, vtsDir string, source string
 
 	p.printf("\n>>> Splitting architect breakdown into VTS task files...\n")
 
-	tasks, header, footer := vts.ParseArchitectOutput(string(data))
+	tasks, header, footer := vts.ParseArchitectOutput(llm.StripSignOff(string(data)))
 	if len(tasks) == 0 {
 		p.printf("  No tasks found in architect breakdown, skipping split\n")
 		return
 	}
 
-	// Route VTS output through pipeline's printf (TUI-safe)
-	onLog := p.opts.OnLog
+	// Route VTS output through the pipeline's event handler
+	onLog := p.events.OnLog
 
 	if err := vts.WriteVTSFiles(tasks, vtsDir, source, filepath.Base(architectFile), onLog); err != nil {
 		p.printf("  Error writing VTS files: %v\n", err)
@@ -643,13 +657,16 @@ func (p *Pipeline) runVernHole() {
 		Idea:         opts.Idea,
 		OutputDir:    vernholeDir,
 		Council:      opts.VernHoleCouncil,
+		Councils:     p.cfg.VernHole.Councils,
 		Count:        opts.VernHoleCount,
 		Context:      consolFile,
 		AgentsDir:    opts.AgentsDir,
 		Timeout:      opts.Timeout,
 		SynthesisLLM: p.cfg.GetSynthesisLLM(),
 		OverrideLLM:  p.cfg.GetOverrideLLM(),
-		OnLog:        opts.OnLog,
+		Rounds:       opts.VernHoleRounds,
+		Seed:         opts.VernHoleSeed,
+		Events:       p.events,
 	})
 	if err != nil {
 		p.printf("\nWARNING: VernHole failed: %v\n", err)
@@ -660,6 +677,11 @@ func (p *Pipeline) runVernHole() {
 
 	p.log("VernHole: OK")
 
+	if p.eval != nil {
+		p.printf("\n>>> Evaluating the VernHole synthesis...\n")
+		p.vernholeScore = p.scoreOutput("VernHole synthesis", opts.Idea, filepath.Join(vernholeDir, "synthesis.md"))
+	}
+
 	// Oracle integration
 	if opts.OracleFlag {
 		p.runOracle(vernholeDir)
@@ -683,7 +705,7 @@ func (p *Pipeline) runOracle(vernholeDir string) {
 		AgentsDir:    opts.AgentsDir,
 		SynthesisLLM: p.cfg.GetSynthesisLLM(),
 		Timeout:      opts.Timeout,
-		OnLog:        opts.OnLog,
+		Events:       p.events,
 	})
 	if err != nil {
 		p.printf("\nWARNING: Oracle step failed\n")
@@ -712,7 +734,7 @@ func (p *Pipeline) applyOracleVision(oracleVisionFile string) {
 		AgentsDir:    opts.AgentsDir,
 		SynthesisLLM: p.cfg.GetSynthesisLLM(),
 		Timeout:      opts.Timeout,
-		OnLog:        opts.OnLog,
+		Events:       p.events,
 	})
 	if err != nil {
 		p.printf("\nWARNING: Oracle apply step failed\n")
@@ -766,6 +788,22 @@ func (p *Pipeline) printDirectoryStructure() {
 	}
 }
 
+// jokesFile returns where sign-offs from runs writing into dir are
+// collected: the enclosing discovery's jokes.md (a folder with input/ and
+// output/, up to two levels up), else jokes.md in dir itself.
+func jokesFile(dir string) string {
+	d := dir
+	for i := 0; i < 3; i++ {
+		in, inErr := os.Stat(filepath.Join(d, "input"))
+		out, outErr := os.Stat(filepath.Join(d, "output"))
+		if inErr == nil && outErr == nil && in.IsDir() && out.IsDir() {
+			return filepath.Join(d, "jokes.md")
+		}
+		d = filepath.Dir(d)
+	}
+	return filepath.Join(dir, "jokes.md")
+}
+
 func readFileIfExists(path string) ([]byte, error) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, err
@@ -805,8 +843,13 @@ func (p *Pipeline) writeStatus(phase string, failedSteps []int) {
 
 	// Step results table
 	b.WriteString("## Pipeline Steps\n\n")
-	b.WriteString("| Step | Name | LLM | Status | Duration | Size |\n")
-	b.WriteString("|------|------|-----|--------|----------|------|\n")
+	if p.eval != nil {
+		b.WriteString("| Step | Name | LLM | Status | Duration | Size | Score |\n")
+		b.WriteString("|------|------|-----|--------|----------|------|-------|\n")
+	} else {
+		b.WriteString("| Step | Name | LLM | Status | Duration | Size |\n")
+		b.WriteString("|------|------|-----|--------|----------|------|\n")
+	}
 
 	completedSteps := 0
 	for _, r := range p.results {
@@ -847,8 +890,18 @@ func (p *Pipeline) writeStatus(phase string, failedSteps []int) {
 			llmCol = fmt.Sprintf("~~%s~~ → %s", r.OriginalLLM, r.LLMUsed)
 		}
 
-		b.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s | %s |\n",
-			r.StepNum, r.Name, llmCol, status, dur, size))
+		row := fmt.Sprintf("| %d | %s | %s | %s | %s | %s |", r.StepNum, r.Name, llmCol, status, dur, size)
+		if p.eval != nil {
+			score := "-"
+			if r.Score != nil {
+				score = fmt.Sprintf("%.1f", r.Score.Overall)
+				if r.Reruns > 0 {
+					score += fmt.Sprintf(" (%d re-run)", r.Reruns)
+				}
+			}
+			row += " " + score + " |"
+		}
+		b.WriteString(row + "\n")
 	}
 
 	b.WriteString(fmt.Sprintf("\n**Progress:** %d/%d steps complete\n", completedSteps, len(p.steps)))
@@ -901,5 +954,7 @@ func (p *Pipeline) writeStatus(phase string, failedSteps []int) {
 		}
 	}
 
+	p.writeQuality(&b)
+
 	os.WriteFile(p.statusPath, []byte(b.String()), 0644)
 }
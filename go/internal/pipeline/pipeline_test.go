@@ -53,3 +53,27 @@ func TestSlugify(t *testing.T) {
 		}
 	}
 }
+
+func TestJokesFile(t *testing.T) {
+	discovery := t.TempDir()
+	for _, d := range []string{"input", "output/vts", "vernhole"} {
+		os.MkdirAll(filepath.Join(discovery, d), 0755)
+	}
+	standalone := t.TempDir()
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{discovery, filepath.Join(discovery, "jokes.md")},
+		{filepath.Join(discovery, "output"), filepath.Join(discovery, "jokes.md")},
+		{filepath.Join(discovery, "output", "vts"), filepath.Join(discovery, "jokes.md")},
+		{filepath.Join(discovery, "vernhole"), filepath.Join(discovery, "jokes.md")},
+		{standalone, filepath.Join(standalone, "jokes.md")},
+	}
+	for _, tt := range tests {
+		if got := jokesFile(tt.dir); got != tt.want {
+			t.Errorf("jokesFile(%s) = %s, want %s", tt.dir, got, tt.want)
+		}
+	}
+}
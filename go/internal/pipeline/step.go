@@ -3,22 +3,26 @@ package pipeline
 import (
 	"regexp"
 	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/judge"
 )
 
 // StepResult tracks the outcome of a pipeline step.
 type StepResult struct {
-	StepNum     int    `json:"step"`
-	Name        string `json:"name"`
-	OutputFile  string `json:"output_file"`
-	Status      string `json:"status"` // "ok", "failed", "skipped"
-	ExitCode    int    `json:"exit_code"`
-	Attempts    int    `json:"attempts"`
-	LLMUsed     string `json:"llm_used"`
-	OriginalLLM string `json:"original_llm,omitempty"` // configured LLM before fallback
-	FellBack    bool   `json:"fell_back,omitempty"`     // true if fell back to fallback LLM
-	DurationMS  int64  `json:"duration_ms"`
-	OutputBytes int64  `json:"output_bytes"`
-	ErrorDetail string `json:"error_detail,omitempty"` // stderr snippet on failure
+	StepNum     int          `json:"step"`
+	Name        string       `json:"name"`
+	OutputFile  string       `json:"output_file"`
+	Status      string       `json:"status"` // "ok", "failed", "skipped"
+	ExitCode    int          `json:"exit_code"`
+	Attempts    int          `json:"attempts"`
+	LLMUsed     string       `json:"llm_used"`
+	OriginalLLM string       `json:"original_llm,omitempty"` // configured LLM before fallback
+	FellBack    bool         `json:"fell_back,omitempty"`    // true if fell back to fallback LLM
+	DurationMS  int64        `json:"duration_ms"`
+	OutputBytes int64        `json:"output_bytes"`
+	ErrorDetail string       `json:"error_detail,omitempty"` // stderr snippet on failure
+	Score       *judge.Score `json:"score,omitempty"`        // evaluator score (when evaluation is on)
+	Reruns      int          `json:"reruns,omitempty"`       // re-runs triggered by a low score
 }
 
 // IsFailedOutput checks if a file is a failure marker or empty/missing.
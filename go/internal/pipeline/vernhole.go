@@ -3,12 +3,14 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/jdonohoo/vern-bot/go/internal/config"
 	"github.com/jdonohoo/vern-bot/go/internal/council"
 	"github.com/jdonohoo/vern-bot/go/internal/llm"
 )
@@ -19,13 +21,16 @@ type VernHoleOptions struct {
 	Idea         string
 	OutputDir    string
 	Council      string
+	Councils     map[string]config.CouncilConfig // custom councils from config (plus .vern/councils/*.json)
 	Count        int
-	Context      string       // path to context file
+	Context      string // path to context file
 	AgentsDir    string
-	Timeout      int          // seconds
+	Timeout      int          // seconds (0: llm.RunOptions' default)
 	SynthesisLLM string       // LLM for synthesis step (default: claude)
 	OverrideLLM  string       // override all Vern LLMs (single_llm mode)
-	OnLog        func(string) // optional callback for progress lines
+	Rounds       int          // debate rounds; <= 1 runs each Vern once, independently
+	Seed         int64        // council selection seed; 0 picks one (recorded in council.json)
+	Events       EventHandler // optional: progress events (nil prints to stdout)
 }
 
 // VernHoleResult holds per-Vern results.
@@ -36,23 +41,32 @@ type VernHoleResult struct {
 	ExitCode   int
 	Succeeded  bool
 	OutputFile string
+	Error      string // first line of stderr (or the run error) when failed
 }
 
-// vernOutput prints to stdout in CLI mode, or routes to OnLog in TUI mode.
+// vernOutput sends progress text to the run's event handler.
 func vernOutput(opts *VernHoleOptions, format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if opts.OnLog != nil {
-		trimmed := strings.TrimSpace(msg)
-		if trimmed != "" {
-			opts.OnLog(trimmed)
-		}
-	} else {
-		fmt.Print(msg)
-	}
+	logLines(handlerOrConsole(opts.Events), format, args...)
 }
 
 // RunVernHole executes a VernHole session with parallel Vern execution.
 func RunVernHole(opts VernHoleOptions) error {
+	opts.Events = handlerOrConsole(opts.Events)
+	opts.Events.OnPhaseStart(PhaseVernHole)
+	err := summonVernHole(opts)
+	opts.Events.OnPhaseComplete(PhaseVernHole, phaseStatus(err))
+	return err
+}
+
+// phaseStatus maps a phase's error to its OnPhaseComplete status.
+func phaseStatus(err error) string {
+	if err != nil {
+		return "failed"
+	}
+	return "ok"
+}
+
+func summonVernHole(opts VernHoleOptions) error {
 	roster := council.ScanRoster(opts.AgentsDir)
 
 	// Determine council
@@ -64,18 +78,33 @@ func RunVernHole(opts VernHoleOptions) error {
 		tierName = "random"
 	}
 
-	selected, councilName := council.ResolveCouncil(tierName, roster, 3)
-	numVerns := len(selected)
-
-	// Load context
-	contextBlock := ""
-	if opts.Context != "" {
-		data, err := os.ReadFile(opts.Context)
-		if err == nil && len(data) > 0 {
-			contextBlock = "\n\n=== PRIOR DISCOVERY PLAN ===\nThe following plan was synthesised from a full Vern Discovery Pipeline run on this idea. Use it as context, but bring your own unique perspective. Challenge it, build on it, tear it apart — whatever your persona demands.\n\n" + string(data) + "\n\n=== END PRIOR DISCOVERY PLAN ==="
-			vernOutput(&opts, "Context loaded from: %s\n\n", opts.Context)
+	tiers, err := council.LoadTiers(opts.Councils, council.UserCouncilDir(), council.ProjectCouncilDir)
+	if err != nil {
+		return err
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var selected []council.Vern
+	var councilName, rationale string
+	var picks []council.SmartPick
+	if tier, ok := tiers[tierName]; ok && tier.Smart {
+		selected, picks = council.SelectSmart(tier, opts.Idea, roster, rng)
+		if len(selected) == 0 {
+			return fmt.Errorf("council %s: no Verns available", tierName)
+		}
+		councilName, rationale = tier.Display, council.SmartRationale(opts.Idea)
+	} else {
+		selected, councilName, err = council.ResolveCouncil(tierName, tiers, roster, 3, rng)
+		if err != nil {
+			return err
 		}
 	}
+	numVerns := len(selected)
+
+	contextBlock := loadContextBlock(&opts)
 
 	// Banner
 	vernOutput(&opts, "=== WELCOME TO THE VERNHOLE ===\n")
@@ -84,28 +113,227 @@ func RunVernHole(opts VernHoleOptions) error {
 	if councilName != "" {
 		vernOutput(&opts, "Council: %s\n", councilName)
 	}
+	vernOutput(&opts, "Seed: %d\n", seed)
+	if rationale != "" {
+		vernOutput(&opts, "Smart selection: %s\n", rationale)
+		for _, p := range picks {
+			vernOutput(&opts, "  - %s: %s\n", p.Vern.Name, p.Reason)
+		}
+	}
 	vernOutput(&opts, "Summoning %d Verns...\n\n", numVerns)
 
 	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
 		return fmt.Errorf("create output dir: %w", err)
 	}
 
-	timeout := opts.Timeout
-	if timeout == 0 {
-		timeout = 1200
+	rounds := max(opts.Rounds, 1)
+	manifest := newManifest(&opts, tierName, councilName, seed, rounds, selected)
+	manifest.Rationale = rationale
+	for i, p := range picks {
+		manifest.Members[i].Reason = p.Reason
+	}
+	if err := WriteManifest(filepath.Join(opts.OutputDir, ManifestFile), manifest); err != nil {
+		vernOutput(&opts, "WARNING: %v\n", err)
+	}
+
+	basePrompt := vernPrompt(opts.Idea, contextBlock)
+
+	// Round 1: every Vern answers independently. In debate mode each later
+	// round shows every Vern a digest of the others' latest positions.
+	var history [][]VernHoleResult
+	latest := make([]VernHoleResult, numVerns)
+	for round := 1; round <= rounds; round++ {
+		roundDir := opts.OutputDir
+		if rounds > 1 {
+			roundDir = filepath.Join(opts.OutputDir, RoundDirName(round))
+			if err := os.MkdirAll(roundDir, 0755); err != nil {
+				return fmt.Errorf("create round dir: %w", err)
+			}
+			vernOutput(&opts, "=== ROUND %d/%d: %s ===\n", round, rounds, roundTitle(round, rounds))
+		}
+
+		results := runVernRound(&opts, selected, nil, roundDir, func(idx int) string {
+			if round == 1 {
+				return basePrompt + verdictInstructions
+			}
+			return debatePrompt(basePrompt, round, rounds, debateDigest(latest, idx)) + verdictInstructions
+		})
+		history = append(history, results)
+
+		for i, r := range results {
+			if r.Succeeded {
+				latest[i] = r
+			}
+		}
+		if round < rounds && countSucceeded(latest) < 2 {
+			vernOutput(&opts, "\n>>> Fewer than 2 Verns have a position — ending the debate after round %d\n\n", round)
+			break
+		}
+	}
+
+	return finishVernHole(&opts, history, latest, contextBlock)
+}
+
+// loadContextBlock reads opts.Context into a prompt section, or "" if unset.
+func loadContextBlock(opts *VernHoleOptions) string {
+	if opts.Context == "" {
+		return ""
+	}
+	data, err := os.ReadFile(opts.Context)
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	vernOutput(opts, "Context loaded from: %s\n\n", opts.Context)
+	return "\n\n=== PRIOR DISCOVERY PLAN ===\nThe following plan was synthesised from a full Vern Discovery Pipeline run on this idea. Use it as context, but bring your own unique perspective. Challenge it, build on it, tear it apart — whatever your persona demands.\n\n" + string(data) + "\n\n=== END PRIOR DISCOVERY PLAN ==="
+}
+
+// vernPrompt is the opening-round prompt every Vern receives.
+func vernPrompt(idea, contextBlock string) string {
+	return fmt.Sprintf("Analyze this idea from your unique perspective. Be true to your persona.\n\nOriginal idea: %s%s", idea, contextBlock)
+}
+
+// finishVernHole tallies votes, synthesizes each Vern's latest position (and
+// the debate transcript when there were several rounds) into synthesis.md,
+// and prints the summary. It fails only when no Vern has a position.
+func finishVernHole(opts *VernHoleOptions, history [][]VernHoleResult, latest []VernHoleResult, contextBlock string) error {
+	numVerns := len(latest)
+
+	// Collect results: each Vern's final position
+	var allOutputs strings.Builder
+	succeededCount := 0
+	var failedVerns []string
+
+	if len(history) > 1 {
+		allOutputs.WriteString(debateTranscript(history))
+	}
+	for _, r := range latest {
+		if r.Succeeded {
+			if len(history) == 1 {
+				allOutputs.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", r.Vern.Desc, r.Output))
+			}
+			succeededCount++
+		}
+	}
+	for i, r := range history[len(history)-1] {
+		if !latest[i].Succeeded {
+			failedVerns = append(failedVerns, r.Vern.ID)
+		}
+	}
+
+	if len(failedVerns) > 0 {
+		vernOutput(opts, "\n>>> Failed Verns: %s\n\n", strings.Join(failedVerns, " "))
+	}
+
+	// Tally the verdict blocks from each Vern's final position
+	votes := TallyVotes(opts.Idea, latest)
+	if succeededCount > 0 {
+		if err := WriteVotes(filepath.Join(opts.OutputDir, VotesFile), votes); err != nil {
+			vernOutput(opts, "WARNING: %v\n", err)
+		} else {
+			vernOutput(opts, ">>> Council vote: %s (%d go / %d no-go, %d without a verdict)\n",
+				strings.ToUpper(votes.Consensus), votes.Go, votes.NoGo, len(votes.Missing))
+		}
+	}
+
+	// Synthesis
+	if succeededCount > 0 {
+		vernOutput(opts, ">>> Synthesizing the chaos (%d/%d Verns succeeded)...\n", succeededCount, numVerns)
+
+		missingNote := ""
+		if len(failedVerns) > 0 {
+			missingNote = fmt.Sprintf("\n\nNOTE: The following Verns failed and their perspectives are missing from this synthesis: %s\nConsider what perspectives might be absent and note any gaps.", strings.Join(failedVerns, " "))
+		}
+
+		instructions := "Synthesize these diverse perspectives into actionable insights. Identify common themes, interesting contradictions, and recommended paths forward."
+		if len(history) > 1 {
+			instructions = fmt.Sprintf("The Verns debated this idea over %d rounds. Synthesize the debate into actionable insights. Cover how positions shifted between opening and final rounds: who conceded what, which disagreements hardened, and which arguments changed minds. Then identify where the council converged, the contradictions that remain, and recommended paths forward.", len(history))
+		}
+		voteNote := ""
+		if len(votes.Verdicts) > 0 {
+			voteNote = fmt.Sprintf("\n\nCOUNCIL VOTE: %d go / %d no-go, average confidence %.1f/5. A consensus/dissent table is appended to your synthesis automatically — interpret the vote and the dissent rather than reproducing the table.",
+				votes.Go, votes.NoGo, votes.AvgConfidence)
+		}
+		synthesisPrompt := fmt.Sprintf("%s\n\nORIGINAL IDEA: %s\n%s\nTHE VERNS HAVE SPOKEN:\n%s%s%s",
+			instructions, opts.Idea, contextBlock, allOutputs.String(), missingNote, voteNote)
+
+		synthesisLLM := opts.SynthesisLLM
+		if synthesisLLM == "" {
+			synthesisLLM = "claude"
+		}
+
+		synthesisFile := filepath.Join(opts.OutputDir, "synthesis.md")
+		handlerOrConsole(opts.Events).OnSynthesisStart(synthesisLLM)
+		result, err := llm.Run(llm.RunOptions{
+			Ctx:        opts.Ctx,
+			LLM:        synthesisLLM,
+			Prompt:     synthesisPrompt,
+			OutputFile: synthesisFile,
+			Persona:    "vernhole-orchestrator",
+			Timeout:    time.Duration(opts.Timeout) * time.Second,
+			AgentsDir:  opts.AgentsDir,
+			Step:       "synthesis",
+			JokesFile:  jokesFile(opts.OutputDir),
+		})
+		ok := err == nil && result.ExitCode == 0
+		handlerOrConsole(opts.Events).OnSynthesisComplete(ok)
+		if !ok {
+			vernOutput(opts, "\nWARNING: Synthesis step failed\n")
+		} else if len(votes.Verdicts) > 0 {
+			if err := appendVoteTable(synthesisFile, votes); err != nil {
+				vernOutput(opts, "WARNING: %v\n", err)
+			}
+		}
+	} else {
+		vernOutput(opts, ">>> All Verns failed — skipping synthesis\n")
+		vernOutput(opts, "No perspectives to synthesize.\n")
+	}
+
+	// Summary
+	vernOutput(opts, "\n")
+	vernOutput(opts, "=== THE VERNHOLE HAS SPOKEN ===\n")
+	vernOutput(opts, "Files created in: %s\n", opts.OutputDir)
+	if len(failedVerns) > 0 {
+		vernOutput(opts, "Failed: %s\n", strings.Join(failedVerns, " "))
 	}
 
-	// Run all Verns in parallel
+	if succeededCount == 0 {
+		return fmt.Errorf("all Verns failed")
+	}
+
+	return nil
+}
+
+// digestLimit caps how much of each Vern's position is quoted to the others
+// in a debate round (and in the middle rounds of the synthesis transcript).
+const digestLimit = 3000
+
+// RoundDirName returns the subdirectory holding a debate round's outputs.
+func RoundDirName(round int) string {
+	return fmt.Sprintf("round-%02d", round)
+}
+
+// runVernRound runs the selected Verns in parallel, writing NN-id.md files
+// into dir. only limits the run to those indexes (nil runs everyone); the
+// other results are left zero. promptFor builds the prompt for the Vern at
+// the given index.
+func runVernRound(opts *VernHoleOptions, selected []council.Vern, only []int, dir string, promptFor func(idx int) string) []VernHoleResult {
+	numVerns := len(selected)
 	results := make([]VernHoleResult, numVerns)
+	events := handlerOrConsole(opts.Events)
 	var wg sync.WaitGroup
 
-	for i, v := range selected {
+	if only == nil {
+		for i := range selected {
+			only = append(only, i)
+		}
+	}
+	for _, i := range only {
+		v := selected[i]
 		wg.Add(1)
 		go func(idx int, vern council.Vern) {
 			defer wg.Done()
 
-			outputFile := filepath.Join(opts.OutputDir, fmt.Sprintf("%02d-%s.md", idx+1, vern.ID))
-			prompt := fmt.Sprintf("Analyze this idea from your unique perspective. Be true to your persona.\n\nOriginal idea: %s%s", opts.Idea, contextBlock)
+			outputFile := filepath.Join(dir, fmt.Sprintf("%02d-%s.md", idx+1, vern.ID))
 
 			// Apply single_llm override if set
 			vernLLM := vern.LLM
@@ -113,16 +341,18 @@ func RunVernHole(opts VernHoleOptions) error {
 				vernLLM = opts.OverrideLLM
 			}
 
-			vernOutput(&opts, ">>> Vern %d/%d: %s (%s)\n", idx+1, numVerns, vern.Name, vernLLM)
+			events.OnVernStart(VernStartData{Index: idx, Total: numVerns, Vern: vern, LLM: vernLLM})
 
 			result, err := llm.Run(llm.RunOptions{
 				Ctx:        opts.Ctx,
 				LLM:        vernLLM,
-				Prompt:     prompt,
+				Prompt:     promptFor(idx),
 				OutputFile: outputFile,
 				Persona:    vern.ID,
-				Timeout:    time.Duration(timeout) * time.Second,
+				Timeout:    time.Duration(opts.Timeout) * time.Second,
 				AgentsDir:  opts.AgentsDir,
+				Step:       "vernhole",
+				JokesFile:  jokesFile(opts.OutputDir),
 			})
 
 			r := VernHoleResult{
@@ -135,96 +365,102 @@ func RunVernHole(opts VernHoleOptions) error {
 				r.Output = result.Output
 				r.ExitCode = 0
 				r.Succeeded = true
-				vernOutput(&opts, "    OK (%s, %dB, Vern %d/%d)\n", vernLLM, len(result.Output), idx+1, numVerns)
 			} else {
-				exitCode := 1
+				r.ExitCode = 1
 				if result != nil {
-					exitCode = result.ExitCode
+					r.ExitCode = result.ExitCode
 				}
-				r.ExitCode = exitCode
-				errSnippet := ""
 				if result != nil && result.Stderr != "" {
-					errSnippet = llm.FirstLine(result.Stderr)
+					r.Error = llm.FirstLine(result.Stderr)
 				} else if err != nil {
-					errSnippet = err.Error()
-				}
-				if errSnippet != "" {
-					vernOutput(&opts, "    FAILED (%s, exit %d, Vern %d/%d): %s — excluding from synthesis\n", vern.ID, exitCode, idx+1, numVerns, errSnippet)
-				} else {
-					vernOutput(&opts, "    FAILED (%s, exit %d, Vern %d/%d) — excluding from synthesis\n", vern.ID, exitCode, idx+1, numVerns)
+					r.Error = err.Error()
 				}
+				// Mark the file so --retry-failed can find it, even if the LLM left partial output
+				failure := fmt.Sprintf("# STEP FAILED\n\nVern %s (%s) failed with exit code %d.\n%s\nRe-run with: vern hole --retry-failed %s\n",
+					vern.ID, vernLLM, r.ExitCode, r.Error, opts.OutputDir)
+				os.WriteFile(outputFile, []byte(failure), 0644)
 			}
+			events.OnVernComplete(VernCompleteData{Total: numVerns, LLM: vernLLM, Result: r})
 
 			results[idx] = r
 		}(i, v)
 	}
 
 	wg.Wait()
+	return results
+}
 
-	// Collect results
-	var allOutputs strings.Builder
-	succeededCount := 0
-	var failedVerns []string
-
+func countSucceeded(results []VernHoleResult) int {
+	n := 0
 	for _, r := range results {
 		if r.Succeeded {
-			allOutputs.WriteString(fmt.Sprintf("\n\n=== %s ===\n%s", r.Vern.Desc, r.Output))
-			succeededCount++
-		} else {
-			failedVerns = append(failedVerns, r.Vern.ID)
+			n++
 		}
 	}
+	return n
+}
 
-	if len(failedVerns) > 0 {
-		vernOutput(&opts, "\n>>> Failed Verns: %s\n\n", strings.Join(failedVerns, " "))
+func roundTitle(round, rounds int) string {
+	switch {
+	case round == 1:
+		return "OPENING POSITIONS"
+	case round == rounds:
+		return "FINAL POSITIONS"
+	default:
+		return "REBUTTALS"
 	}
+}
 
-	// Synthesis
-	if succeededCount > 0 {
-		vernOutput(&opts, ">>> Synthesizing the chaos (%d/%d Verns succeeded)...\n", succeededCount, numVerns)
-
-		missingNote := ""
-		if len(failedVerns) > 0 {
-			missingNote = fmt.Sprintf("\n\nNOTE: The following Verns failed and their perspectives are missing from this synthesis: %s\nConsider what perspectives might be absent and note any gaps.", strings.Join(failedVerns, " "))
-		}
-
-		synthesisPrompt := fmt.Sprintf("Synthesize these diverse perspectives into actionable insights. Identify common themes, interesting contradictions, and recommended paths forward.\n\nORIGINAL IDEA: %s\n%s\nTHE VERNS HAVE SPOKEN:\n%s%s",
-			opts.Idea, contextBlock, allOutputs.String(), missingNote)
-
-		synthesisLLM := opts.SynthesisLLM
-		if synthesisLLM == "" {
-			synthesisLLM = "claude"
+// debateDigest summarizes every other Vern's latest position for the Vern at
+// index self. Verns with no successful position yet are left out.
+func debateDigest(latest []VernHoleResult, self int) string {
+	var b strings.Builder
+	for i, r := range latest {
+		if i == self || !r.Succeeded {
+			continue
 		}
-
-		synthesisFile := filepath.Join(opts.OutputDir, "synthesis.md")
-		_, err := llm.Run(llm.RunOptions{
-			Ctx:        opts.Ctx,
-			LLM:        synthesisLLM,
-			Prompt:     synthesisPrompt,
-			OutputFile: synthesisFile,
-			Persona:    "vernhole-orchestrator",
-			Timeout:    time.Duration(timeout) * time.Second,
-			AgentsDir:  opts.AgentsDir,
-		})
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "\nWARNING: Synthesis step failed\n")
-		}
-	} else {
-		vernOutput(&opts, ">>> All Verns failed — skipping synthesis\n")
-		vernOutput(&opts, "No perspectives to synthesize.\n")
+		fmt.Fprintf(&b, "\n\n--- %s (%s) ---\n%s", r.Vern.Name, r.Vern.ID, truncateRunes(strings.TrimSpace(r.Output), digestLimit))
 	}
+	return b.String()
+}
 
-	// Summary
-	vernOutput(&opts, "\n")
-	vernOutput(&opts, "=== THE VERNHOLE HAS SPOKEN ===\n")
-	vernOutput(&opts, "Files created in: %s\n", opts.OutputDir)
-	if len(failedVerns) > 0 {
-		vernOutput(&opts, "Failed: %s\n", strings.Join(failedVerns, " "))
+// debatePrompt builds a later-round prompt: the original brief plus the
+// digest of the other Verns' positions, with instructions to engage them.
+func debatePrompt(basePrompt string, round, rounds int, digest string) string {
+	final := ""
+	if round == rounds {
+		final = " This is the final round: end with your settled position and what, if anything, changed your mind."
 	}
+	return fmt.Sprintf("%s\n\n=== DEBATE ROUND %d OF %d ===\nThe other Verns on the council have staked out these positions. Do not restate your earlier analysis. Engage them directly: name the Verns you disagree with and rebut their arguments, concede points where they are right, and refine your own position in light of theirs. Stay true to your persona — disagreement is the point.%s\n\n=== OTHER VERNS' POSITIONS ===%s\n\n=== END OF POSITIONS ===",
+		basePrompt, round, rounds, final, digest)
+}
 
-	if succeededCount == 0 {
-		return fmt.Errorf("all Verns failed")
+// debateTranscript renders every round for the synthesis prompt. Opening and
+// final rounds are quoted in full; middle rounds are truncated.
+func debateTranscript(history [][]VernHoleResult) string {
+	var b strings.Builder
+	for i, results := range history {
+		round := i + 1
+		fmt.Fprintf(&b, "\n\n##### ROUND %d: %s #####", round, roundTitle(round, len(history)))
+		for _, r := range results {
+			if !r.Succeeded {
+				continue
+			}
+			out := r.Output
+			if round != 1 && round != len(history) {
+				out = truncateRunes(out, digestLimit)
+			}
+			fmt.Fprintf(&b, "\n\n=== %s ===\n%s", r.Vern.Desc, out)
+		}
 	}
+	return b.String()
+}
 
-	return nil
+// truncateRunes shortens s to at most n runes, marking the cut.
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "\n[... truncated]"
 }
@@ -0,0 +1,286 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+)
+
+// VernHoleRetryOptions configures RetryVernHole.
+type VernHoleRetryOptions struct {
+	Ctx          context.Context // optional: cancelled on TUI quit
+	Dir          string          // output dir of a previous VernHole run
+	Idea         string          // required only when Dir has no council.json
+	Extra        []string        // persona IDs to add to the council
+	Context      string          // path to context file (default: the one recorded in council.json)
+	AgentsDir    string
+	Timeout      int          // seconds (0: llm.RunOptions' default)
+	SynthesisLLM string       // LLM for synthesis step (default: claude)
+	OverrideLLM  string       // override all Vern LLMs (default: the one recorded in council.json)
+	Events       EventHandler // optional: progress events (nil prints to stdout)
+}
+
+var vernFileRe = regexp.MustCompile(`^(\d{2})-(.+)\.md$`)
+
+// RetryVernHole re-runs only the Verns whose NN-id.md output is missing or
+// failed, adds any extra Verns, and regenerates synthesis.md (and votes.json)
+// from every available output. For debate runs only the last round is
+// retried, against the positions recorded in the round before it.
+func RetryVernHole(ropts VernHoleRetryOptions) error {
+	ropts.Events = handlerOrConsole(ropts.Events)
+	ropts.Events.OnPhaseStart(PhaseVernHole)
+	err := retryVernHole(ropts)
+	ropts.Events.OnPhaseComplete(PhaseVernHole, phaseStatus(err))
+	return err
+}
+
+func retryVernHole(ropts VernHoleRetryOptions) error {
+	manifestPath := filepath.Join(ropts.Dir, ManifestFile)
+	manifest, selected, err := loadCouncil(ropts.Dir, ropts.Idea, ropts.AgentsDir)
+	if errors.Is(err, errIdeaUnknown) {
+		return fmt.Errorf("%w; pass it as an argument", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	opts := VernHoleOptions{
+		Ctx:          ropts.Ctx,
+		Idea:         manifest.Idea,
+		OutputDir:    ropts.Dir,
+		Context:      manifest.Context,
+		AgentsDir:    ropts.AgentsDir,
+		Timeout:      ropts.Timeout,
+		SynthesisLLM: ropts.SynthesisLLM,
+		OverrideLLM:  manifest.OverrideLLM,
+		Rounds:       max(manifest.Rounds, 1),
+		Events:       ropts.Events,
+	}
+	if ropts.Context != "" {
+		opts.Context = ropts.Context
+	}
+	if ropts.OverrideLLM != "" {
+		opts.OverrideLLM = ropts.OverrideLLM
+	}
+
+	roster := council.ScanRoster(opts.AgentsDir)
+	var added []string
+	for _, id := range ropts.Extra {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := council.FindVern(selected, id); ok {
+			continue
+		}
+		v, ok := council.FindVern(roster, id)
+		if !ok {
+			return fmt.Errorf("unknown Vern %q", id)
+		}
+		selected = append(selected, v)
+		manifest.Members = append(manifest.Members, ManifestMember{
+			Index: len(selected), ID: v.ID, Name: v.Name, LLM: v.LLM, Reason: "added on retry",
+		})
+		added = append(added, v.ID)
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("%s: no Vern outputs found", ropts.Dir)
+	}
+
+	history := loadHistory(ropts.Dir, opts.Rounds, selected)
+	rounds := len(history)
+	final := history[rounds-1]
+	var retry []int
+	for i, r := range final {
+		if !r.Succeeded {
+			retry = append(retry, i)
+		}
+	}
+
+	contextBlock := loadContextBlock(&opts)
+
+	vernOutput(&opts, "=== RETURNING TO THE VERNHOLE ===\n")
+	vernOutput(&opts, "Idea: %s\n", opts.Idea)
+	vernOutput(&opts, "Output: %s\n", opts.OutputDir)
+	if len(added) > 0 {
+		vernOutput(&opts, "Adding: %s\n", strings.Join(added, " "))
+	}
+
+	if len(retry) == 0 {
+		vernOutput(&opts, "No failed Verns — regenerating synthesis only\n\n")
+	} else {
+		ids := make([]string, len(retry))
+		for i, idx := range retry {
+			ids[i] = selected[idx].ID
+		}
+		vernOutput(&opts, "Retrying %d Verns: %s\n\n", len(retry), strings.Join(ids, " "))
+
+		basePrompt := vernPrompt(opts.Idea, contextBlock)
+		var prior []VernHoleResult
+		if rounds > 1 {
+			prior = latestPositions(history[:rounds-1], len(selected))
+			vernOutput(&opts, "=== ROUND %d/%d: %s ===\n", rounds, opts.Rounds, roundTitle(rounds, opts.Rounds))
+		}
+		results := runVernRound(&opts, selected, retry, roundDir(ropts.Dir, rounds, opts.Rounds), func(idx int) string {
+			if rounds == 1 {
+				return basePrompt + verdictInstructions
+			}
+			return debatePrompt(basePrompt, rounds, opts.Rounds, debateDigest(prior, idx)) + verdictInstructions
+		})
+		for _, idx := range retry {
+			final[idx] = results[idx]
+		}
+	}
+
+	if err := WriteManifest(manifestPath, manifest); err != nil {
+		vernOutput(&opts, "WARNING: %v\n", err)
+	}
+
+	return finishVernHole(&opts, history, latestPositions(history, len(selected)), contextBlock)
+}
+
+// errIdeaUnknown is returned by loadCouncil for a run without council.json
+// when the caller didn't supply the idea.
+var errIdeaUnknown = errors.New("idea unknown (no council.json)")
+
+// loadCouncil reads the council of a previous VernHole run from council.json,
+// or from its NN-id.md files for runs that predate it. idea, when set,
+// overrides the recorded idea. Member LLMs are the ones recorded at summon time.
+func loadCouncil(dir, idea, agentsDir string) (*CouncilManifest, []council.Vern, error) {
+	manifest, err := ReadManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, nil, err
+		}
+		manifest, err = scanManifest(dir, idea)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if idea != "" {
+		manifest.Idea = idea
+	}
+	if manifest.Idea == "" {
+		return nil, nil, fmt.Errorf("%s: %w", dir, errIdeaUnknown)
+	}
+
+	roster := council.ScanRoster(agentsDir)
+	selected := make([]council.Vern, len(manifest.Members))
+	for i, m := range manifest.Members {
+		v, ok := council.FindVern(roster, m.ID)
+		if !ok {
+			v = council.Vern{ID: m.ID, Name: m.Name, Desc: m.Name}
+		}
+		if m.LLM != "" {
+			v.LLM = m.LLM
+		}
+		selected[i] = v
+	}
+	return manifest, selected, nil
+}
+
+// loadHistory reads every round's results. Debates that ended early have
+// fewer round dirs than planned, so the history may be shorter than rounds.
+func loadHistory(dir string, rounds int, selected []council.Vern) [][]VernHoleResult {
+	rounds = max(rounds, 1)
+	last := rounds
+	if last > 1 {
+		last = lastRound(dir, last)
+	}
+	history := make([][]VernHoleResult, last)
+	for round := 1; round <= last; round++ {
+		history[round-1] = loadRoundResults(roundDir(dir, round, rounds), selected)
+	}
+	return history
+}
+
+// scanManifest rebuilds a minimal manifest from the NN-id.md files of a run
+// that predates council.json.
+func scanManifest(dir, idea string) (*CouncilManifest, error) {
+	m := &CouncilManifest{Idea: idea, Rounds: 1}
+	scanDir := dir
+	if entries, _ := filepath.Glob(filepath.Join(dir, "round-[0-9][0-9]")); len(entries) > 0 {
+		m.Rounds = len(entries)
+		scanDir = filepath.Join(dir, RoundDirName(1))
+	}
+
+	entries, err := os.ReadDir(scanDir)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", scanDir, err)
+	}
+	for _, e := range entries {
+		match := vernFileRe.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+		idx, _ := strconv.Atoi(match[1])
+		m.Members = append(m.Members, ManifestMember{Index: idx, ID: match[2], Name: match[2]})
+	}
+	sort.Slice(m.Members, func(i, j int) bool { return m.Members[i].Index < m.Members[j].Index })
+	for i := range m.Members {
+		if m.Members[i].Index != i+1 {
+			return nil, fmt.Errorf("%s: Vern outputs are not numbered 01..%02d", scanDir, len(m.Members))
+		}
+	}
+	return m, nil
+}
+
+// roundDir is where a round's outputs live: the run dir itself unless the
+// run was a debate.
+func roundDir(dir string, round, rounds int) string {
+	if rounds <= 1 {
+		return dir
+	}
+	return filepath.Join(dir, RoundDirName(round))
+}
+
+// lastRound returns the highest debate round (up to rounds) with a directory.
+func lastRound(dir string, rounds int) int {
+	for round := rounds; round > 1; round-- {
+		if info, err := os.Stat(filepath.Join(dir, RoundDirName(round))); err == nil && info.IsDir() {
+			return round
+		}
+	}
+	return 1
+}
+
+// loadRoundResults reads each Vern's NN-id.md in dir. Missing, empty, and
+// failure-marked files count as failed.
+func loadRoundResults(dir string, selected []council.Vern) []VernHoleResult {
+	results := make([]VernHoleResult, len(selected))
+	for i, v := range selected {
+		path := filepath.Join(dir, fmt.Sprintf("%02d-%s.md", i+1, v.ID))
+		r := VernHoleResult{Index: i, Vern: v, OutputFile: path, ExitCode: 1}
+		if !IsFailedOutput(path) {
+			if data, err := os.ReadFile(path); err == nil {
+				r.Output, r.Succeeded, r.ExitCode = llm.StripSignOff(string(data)), true, 0
+			}
+		}
+		results[i] = r
+	}
+	return results
+}
+
+// latestPositions returns each Vern's most recent successful result.
+func latestPositions(history [][]VernHoleResult, n int) []VernHoleResult {
+	latest := make([]VernHoleResult, n)
+	for _, results := range history {
+		for i, r := range results {
+			if r.Succeeded {
+				latest[i] = r
+			} else if latest[i].Vern.ID == "" {
+				latest[i].Vern = r.Vern
+			}
+		}
+	}
+	return latest
+}
@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRoundResults(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "01-startup.md"), "Ship it.")
+	writeFile(t, filepath.Join(dir, "02-paranoid.md"), "# STEP FAILED\n\nVern paranoid (codex) failed with exit code 1.\n")
+	writeFile(t, filepath.Join(dir, "03-yolo.md"), "")
+	// 04-ux.md is missing
+
+	selected := []council.Vern{{ID: "startup"}, {ID: "paranoid"}, {ID: "yolo"}, {ID: "ux"}}
+	results := loadRoundResults(dir, selected)
+
+	want := []bool{true, false, false, false}
+	for i, r := range results {
+		if r.Succeeded != want[i] {
+			t.Errorf("%s: succeeded = %v, want %v", r.Vern.ID, r.Succeeded, want[i])
+		}
+		if r.Index != i || r.Vern.ID != selected[i].ID {
+			t.Errorf("result %d = %+v", i, r)
+		}
+	}
+	if results[0].Output != "Ship it." {
+		t.Errorf("output = %q", results[0].Output)
+	}
+}
+
+func TestScanManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "02-paranoid.md"), "x")
+	writeFile(t, filepath.Join(dir, "01-startup.md"), "x")
+	writeFile(t, filepath.Join(dir, "synthesis.md"), "x")
+
+	m, err := scanManifest(dir, "an idea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Idea != "an idea" || m.Rounds != 1 || len(m.Members) != 2 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	if m.Members[0].ID != "startup" || m.Members[1].ID != "paranoid" {
+		t.Errorf("members = %+v", m.Members)
+	}
+
+	writeFile(t, filepath.Join(dir, "04-yolo.md"), "x")
+	if _, err := scanManifest(dir, "an idea"); err == nil {
+		t.Error("expected error for a gap in Vern numbering")
+	}
+}
+
+func TestScanManifest_Debate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, RoundDirName(1), "01-startup.md"), "x")
+	writeFile(t, filepath.Join(dir, RoundDirName(2), "01-startup.md"), "x")
+
+	m, err := scanManifest(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Rounds != 2 || len(m.Members) != 1 {
+		t.Errorf("manifest = %+v", m)
+	}
+}
+
+func TestLastRound(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, RoundDirName(1), "01-startup.md"), "x")
+	writeFile(t, filepath.Join(dir, RoundDirName(2), "01-startup.md"), "x")
+
+	// Debate planned for 3 rounds but ended after round 2
+	if got := lastRound(dir, 3); got != 2 {
+		t.Errorf("lastRound = %d, want 2", got)
+	}
+	if got := roundDir(dir, 2, 3); got != filepath.Join(dir, "round-02") {
+		t.Errorf("roundDir = %s", got)
+	}
+	if got := roundDir(dir, 1, 1); got != dir {
+		t.Errorf("single-round roundDir = %s, want %s", got, dir)
+	}
+}
+
+func TestLatestPositions(t *testing.T) {
+	history := [][]VernHoleResult{
+		{debateResult(0, "startup", "open", true), debateResult(1, "paranoid", "open", true)},
+		{debateResult(0, "startup", "final", true), debateResult(1, "paranoid", "", false)},
+	}
+	latest := latestPositions(history, 2)
+	if latest[0].Output != "final" {
+		t.Errorf("startup = %q, want final position", latest[0].Output)
+	}
+	if !latest[1].Succeeded || latest[1].Output != "open" {
+		t.Errorf("paranoid should fall back to its opening position, got %+v", latest[1])
+	}
+}
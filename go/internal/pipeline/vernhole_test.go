@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func debateResult(idx int, id, output string, ok bool) VernHoleResult {
+	return VernHoleResult{
+		Index:     idx,
+		Vern:      council.Vern{ID: id, Name: strings.ToUpper(id[:1]) + id[1:] + " Vern", Desc: id + " desc"},
+		Output:    output,
+		Succeeded: ok,
+	}
+}
+
+func TestDebateDigest(t *testing.T) {
+	latest := []VernHoleResult{
+		debateResult(0, "startup", "Ship it this week.", true),
+		debateResult(1, "enterprise", "Needs a compliance review first.", true),
+		debateResult(2, "yolo", "", false),
+	}
+
+	digest := debateDigest(latest, 0)
+	if strings.Contains(digest, "Ship it this week") {
+		t.Error("digest should not include the Vern's own position")
+	}
+	if !strings.Contains(digest, "--- Enterprise Vern (enterprise) ---\nNeeds a compliance review first.") {
+		t.Errorf("digest missing enterprise position:\n%s", digest)
+	}
+	if strings.Contains(digest, "yolo") {
+		t.Error("digest should skip Verns without a position")
+	}
+}
+
+func TestDebateDigest_Truncates(t *testing.T) {
+	long := strings.Repeat("é", digestLimit+50)
+	latest := []VernHoleResult{
+		debateResult(0, "startup", "short", true),
+		debateResult(1, "academic", long, true),
+	}
+	digest := debateDigest(latest, 0)
+	if !strings.Contains(digest, "[... truncated]") {
+		t.Error("long positions should be truncated")
+	}
+	if strings.Count(digest, "é") != digestLimit {
+		t.Errorf("kept %d runes, want %d", strings.Count(digest, "é"), digestLimit)
+	}
+}
+
+func TestDebatePrompt(t *testing.T) {
+	mid := debatePrompt("BASE", 2, 3, "\n\n--- X ---\npos")
+	for _, want := range []string{"BASE", "DEBATE ROUND 2 OF 3", "rebut", "concede", "refine", "--- X ---"} {
+		if !strings.Contains(mid, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(mid, "final round") {
+		t.Error("middle round should not be marked final")
+	}
+	if !strings.Contains(debatePrompt("BASE", 3, 3, ""), "final round") {
+		t.Error("last round should ask for a settled position")
+	}
+}
+
+func TestDebateTranscript(t *testing.T) {
+	long := strings.Repeat("x", digestLimit+10)
+	history := [][]VernHoleResult{
+		{debateResult(0, "startup", "open-a", true), debateResult(1, "paranoid", "open-b", true)},
+		{debateResult(0, "startup", long, true), debateResult(1, "paranoid", "", false)},
+		{debateResult(0, "startup", "final-a", true), debateResult(1, "paranoid", "final-b", true)},
+	}
+	got := debateTranscript(history)
+	for _, want := range []string{
+		"##### ROUND 1: OPENING POSITIONS #####",
+		"##### ROUND 2: REBUTTALS #####",
+		"##### ROUND 3: FINAL POSITIONS #####",
+		"=== startup desc ===\nopen-a",
+		"=== paranoid desc ===\nfinal-b",
+		"[... truncated]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("transcript missing %q", want)
+		}
+	}
+	if strings.Count(got, "=== paranoid desc ===") != 2 {
+		t.Error("failed round entries should be skipped")
+	}
+}
+
+func TestRoundDirName(t *testing.T) {
+	if got := RoundDirName(3); got != "round-03" {
+		t.Errorf("RoundDirName(3) = %q", got)
+	}
+}
+
+func TestCouncilManifest(t *testing.T) {
+	opts := &VernHoleOptions{Idea: "idea", OverrideLLM: "gemini"}
+	selected := []council.Vern{
+		{ID: "startup", Name: "Startup Vern", LLM: "claude"},
+		{ID: "paranoid", Name: "Paranoid Vern", LLM: "codex"},
+	}
+	path := filepath.Join(t.TempDir(), ManifestFile)
+	if err := WriteManifest(path, newManifest(opts, "conflict", "Max Conflict", 99, 2, selected)); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ReadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Seed != 99 || m.Council != "conflict" || m.Rounds != 2 || m.OverrideLLM != "gemini" {
+		t.Errorf("manifest = %+v", m)
+	}
+	if len(m.Members) != 2 || m.Members[1] != (ManifestMember{Index: 2, ID: "paranoid", Name: "Paranoid Vern", LLM: "codex"}) {
+		t.Errorf("members = %+v", m.Members)
+	}
+}
@@ -0,0 +1,295 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VotesFile is the tally of Vern verdicts written next to synthesis.md.
+const VotesFile = "votes.json"
+
+const (
+	verdictStart = "=== VERDICT ==="
+	verdictEnd   = "=== END VERDICT ==="
+)
+
+// verdictInstructions is appended to every Vern prompt so each response ends
+// with a machine-readable verdict block.
+var verdictInstructions = `
+
+=== REQUIRED: VERDICT BLOCK ===
+End your response with this block, exactly as formatted, filled in from your persona's point of view:
+
+` + verdictStart + `
+Decision: go | no-go
+Confidence: 1-5
+Approach: <one sentence: the approach you would take>
+Risks:
+- <top risk>
+- <second risk>
+- <third risk>
+` + verdictEnd
+
+// Verdict is one Vern's structured vote.
+type Verdict struct {
+	Vern       string   `json:"vern"`
+	Name       string   `json:"name"`
+	Decision   string   `json:"decision"`   // "go" or "no-go"
+	Confidence int      `json:"confidence"` // 1-5, 0 if not given
+	Approach   string   `json:"approach,omitempty"`
+	Risks      []string `json:"risks,omitempty"`
+}
+
+// RiskCount is a risk raised by one or more Verns.
+type RiskCount struct {
+	Risk  string   `json:"risk"`
+	Count int      `json:"count"`
+	Verns []string `json:"verns"`
+}
+
+// Votes is the tally of a VernHole council's verdicts.
+type Votes struct {
+	Idea          string      `json:"idea"`
+	Verdicts      []Verdict   `json:"verdicts"`
+	Missing       []string    `json:"missing,omitempty"` // Verns with no parseable verdict
+	Go            int         `json:"go"`
+	NoGo          int         `json:"no_go"`
+	AvgConfidence float64     `json:"avg_confidence"`
+	Consensus     string      `json:"consensus"` // "go", "no-go", or "split"
+	Agreement     float64     `json:"agreement"` // share of verdicts matching the consensus
+	Dissent       []string    `json:"dissent,omitempty"`
+	Risks         []RiskCount `json:"risks,omitempty"`
+}
+
+var (
+	confidenceRe = regexp.MustCompile(`[1-5]`)
+	listItemRe   = regexp.MustCompile(`^\s*(?:[-*+•]|\d+[.)])\s+`)
+)
+
+// ParseVerdict extracts the last verdict block from a Vern's output.
+// It returns false when there is no block or no recognizable decision.
+func ParseVerdict(output string) (Verdict, bool) {
+	var v Verdict
+	start := strings.LastIndex(output, verdictStart)
+	if start < 0 {
+		return v, false
+	}
+	block := output[start+len(verdictStart):]
+	if end := strings.Index(block, verdictEnd); end >= 0 {
+		block = block[:end]
+	}
+
+	inRisks := false
+	for _, line := range strings.Split(block, "\n") {
+		clean := strings.TrimSpace(strings.NewReplacer("*", "", "_", "", "`", "").Replace(line))
+		if clean == "" {
+			continue
+		}
+		key, val, hasKey := strings.Cut(clean, ":")
+		if hasKey && !listItemRe.MatchString(line) {
+			val = strings.TrimSpace(val)
+			inRisks = false
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "decision", "verdict":
+				v.Decision = normalizeDecision(val)
+			case "confidence":
+				if m := confidenceRe.FindString(val); m != "" {
+					v.Confidence, _ = strconv.Atoi(m)
+				}
+			case "approach", "preferred approach":
+				v.Approach = val
+			case "risks", "top risks":
+				inRisks = true
+				if val != "" {
+					v.Risks = append(v.Risks, val)
+				}
+			}
+			continue
+		}
+		if inRisks && len(v.Risks) < 3 {
+			if risk := strings.TrimSpace(listItemRe.ReplaceAllString(line, "")); risk != "" {
+				v.Risks = append(v.Risks, strings.Trim(risk, "*_` "))
+			}
+		}
+	}
+	return v, v.Decision != ""
+}
+
+// normalizeDecision maps free-form decisions onto "go" / "no-go".
+func normalizeDecision(s string) string {
+	compact := strings.NewReplacer("-", "", " ", "", "_", "").Replace(strings.ToLower(s))
+	switch {
+	case strings.HasPrefix(compact, "no"):
+		return "no-go"
+	case strings.HasPrefix(compact, "go"), strings.HasPrefix(compact, "yes"):
+		return "go"
+	}
+	return ""
+}
+
+// TallyVotes parses every successful Vern's verdict and computes the
+// consensus, dissenters, and the risks raised most often.
+func TallyVotes(idea string, results []VernHoleResult) *Votes {
+	votes := &Votes{Idea: idea, Verdicts: []Verdict{}}
+	confSum, confN := 0, 0
+	riskIndex := map[string]int{}
+
+	for _, r := range results {
+		if !r.Succeeded {
+			continue
+		}
+		v, ok := ParseVerdict(r.Output)
+		if !ok {
+			votes.Missing = append(votes.Missing, r.Vern.ID)
+			continue
+		}
+		v.Vern, v.Name = r.Vern.ID, r.Vern.Name
+		votes.Verdicts = append(votes.Verdicts, v)
+
+		if v.Decision == "go" {
+			votes.Go++
+		} else {
+			votes.NoGo++
+		}
+		if v.Confidence > 0 {
+			confSum += v.Confidence
+			confN++
+		}
+		for _, risk := range v.Risks {
+			key := strings.ToLower(strings.TrimRight(risk, ". "))
+			i, seen := riskIndex[key]
+			if !seen {
+				i = len(votes.Risks)
+				riskIndex[key] = i
+				votes.Risks = append(votes.Risks, RiskCount{Risk: risk})
+			}
+			votes.Risks[i].Count++
+			votes.Risks[i].Verns = append(votes.Risks[i].Verns, v.Vern)
+		}
+	}
+
+	if confN > 0 {
+		votes.AvgConfidence = float64(int(float64(confSum)/float64(confN)*10+0.5)) / 10
+	}
+	sort.SliceStable(votes.Risks, func(i, j int) bool { return votes.Risks[i].Count > votes.Risks[j].Count })
+
+	total := votes.Go + votes.NoGo
+	switch {
+	case total == 0 || votes.Go == votes.NoGo:
+		votes.Consensus = "split"
+	case votes.Go > votes.NoGo:
+		votes.Consensus = "go"
+	default:
+		votes.Consensus = "no-go"
+	}
+	if total > 0 {
+		majority := max(votes.Go, votes.NoGo)
+		votes.Agreement = float64(int(float64(majority)/float64(total)*100+0.5)) / 100
+	}
+	if votes.Consensus != "split" {
+		for _, v := range votes.Verdicts {
+			if v.Decision != votes.Consensus {
+				votes.Dissent = append(votes.Dissent, v.Vern)
+			}
+		}
+	}
+	return votes
+}
+
+// Markdown renders the consensus/dissent table appended to synthesis.md.
+func (v *Votes) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## Council Vote\n\n")
+
+	total := v.Go + v.NoGo
+	if total == 0 {
+		b.WriteString("No Vern returned a parseable verdict.\n")
+		return b.String()
+	}
+
+	consensus := strings.ToUpper(v.Consensus)
+	strength := "split decision"
+	switch {
+	case v.Consensus == "split":
+	case v.Agreement == 1:
+		strength = "unanimous"
+	case v.Agreement >= 0.75:
+		strength = "strong consensus"
+	default:
+		strength = "narrow majority"
+	}
+	fmt.Fprintf(&b, "- **Consensus:** %s — %s (%d go / %d no-go)\n", consensus, strength, v.Go, v.NoGo)
+	fmt.Fprintf(&b, "- **Average confidence:** %s / 5\n", strconv.FormatFloat(v.AvgConfidence, 'f', -1, 64))
+	if len(v.Dissent) > 0 {
+		fmt.Fprintf(&b, "- **Dissent:** %s\n", strings.Join(v.Dissent, ", "))
+	}
+	if len(v.Missing) > 0 {
+		fmt.Fprintf(&b, "- **No verdict:** %s\n", strings.Join(v.Missing, ", "))
+	}
+
+	b.WriteString("\n| Vern | Verdict | Confidence | Preferred approach | Top risks |\n")
+	b.WriteString("|------|---------|------------|--------------------|-----------|\n")
+	for _, vd := range v.Verdicts {
+		decision := strings.ToUpper(vd.Decision)
+		if v.Consensus != "split" && vd.Decision != v.Consensus {
+			decision += " (dissent)"
+		}
+		conf := "–"
+		if vd.Confidence > 0 {
+			conf = strconv.Itoa(vd.Confidence)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			vd.Name, decision, conf, tableCell(vd.Approach), tableCell(strings.Join(vd.Risks, "; ")))
+	}
+
+	var shared []RiskCount
+	for _, r := range v.Risks {
+		if r.Count > 1 {
+			shared = append(shared, r)
+		}
+	}
+	if len(shared) > 0 {
+		b.WriteString("\n### Shared Risks\n\n")
+		for _, r := range shared {
+			fmt.Fprintf(&b, "- %s (%d Verns: %s)\n", r.Risk, r.Count, strings.Join(r.Verns, ", "))
+		}
+	}
+	return b.String()
+}
+
+// tableCell escapes a value for a single Markdown table cell.
+func tableCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "\n", " ")
+	if s == "" {
+		return "–"
+	}
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// WriteVotes writes the tally as indented JSON.
+func WriteVotes(path string, votes *Votes) error {
+	data, err := json.MarshalIndent(votes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal votes: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write votes: %w", err)
+	}
+	return nil
+}
+
+// appendVoteTable appends the council vote section to synthesis.md.
+func appendVoteTable(synthesisFile string, votes *Votes) error {
+	f, err := os.OpenFile(synthesisFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("open synthesis: %w", err)
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "\n\n%s", votes.Markdown())
+	return err
+}
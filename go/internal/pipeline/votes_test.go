@@ -0,0 +1,154 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		ok         bool
+		decision   string
+		confidence int
+		approach   string
+		risks      []string
+	}{
+		{
+			name:   "plain block",
+			output: "Long analysis...\n\n=== VERDICT ===\nDecision: go\nConfidence: 4\nApproach: Ship a thin slice behind a flag\nRisks:\n- Data loss\n- Vendor lock-in\n- Team burnout\n=== END VERDICT ===\n",
+			ok:     true, decision: "go", confidence: 4, approach: "Ship a thin slice behind a flag",
+			risks: []string{"Data loss", "Vendor lock-in", "Team burnout"},
+		},
+		{
+			name:   "markdown decorated, numbered risks",
+			output: "=== VERDICT ===\n**Decision:** NO-GO\n**Confidence:** 2/5\n**Preferred approach:** Buy, don't build\n**Top risks:**\n1. Compliance: SOC2 gaps\n2. Cost overrun\n3. Hiring\n4. Ignored fourth\n=== END VERDICT ===",
+			ok:     true, decision: "no-go", confidence: 2, approach: "Buy, don't build",
+			risks: []string{"Compliance: SOC2 gaps", "Cost overrun", "Hiring"},
+		},
+		{
+			name:   "last block wins",
+			output: "=== VERDICT ===\nDecision: go\n=== END VERDICT ===\nOn reflection...\n=== VERDICT ===\nDecision: no\nConfidence: 5\n=== END VERDICT ===",
+			ok:     true, decision: "no-go", confidence: 5,
+		},
+		{name: "no block", output: "Just vibes.", ok: false},
+		{name: "no decision", output: "=== VERDICT ===\nConfidence: 3\n=== END VERDICT ===", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := ParseVerdict(tt.output)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if v.Decision != tt.decision || v.Confidence != tt.confidence || v.Approach != tt.approach {
+				t.Errorf("verdict = %+v", v)
+			}
+			if strings.Join(v.Risks, "|") != strings.Join(tt.risks, "|") {
+				t.Errorf("risks = %q, want %q", v.Risks, tt.risks)
+			}
+		})
+	}
+}
+
+func verdictResult(id, decision string, confidence int, risks ...string) VernHoleResult {
+	out := "analysis\n=== VERDICT ===\nDecision: " + decision + "\nConfidence: " + string(rune('0'+confidence)) + "\nApproach: " + id + " way\nRisks:\n"
+	for _, r := range risks {
+		out += "- " + r + "\n"
+	}
+	out += "=== END VERDICT ==="
+	return VernHoleResult{Vern: council.Vern{ID: id, Name: id + " Vern"}, Output: out, Succeeded: true}
+}
+
+func TestTallyVotes(t *testing.T) {
+	results := []VernHoleResult{
+		verdictResult("startup", "go", 5, "Scope creep", "Burn rate"),
+		verdictResult("yolo", "go", 4, "scope creep."),
+		verdictResult("optimist", "go", 5),
+		verdictResult("paranoid", "no-go", 2, "Scope creep", "Breach"),
+		{Vern: council.Vern{ID: "academic"}, Output: "no verdict here", Succeeded: true},
+		{Vern: council.Vern{ID: "nyquil"}, Succeeded: false},
+	}
+
+	votes := TallyVotes("idea", results)
+	if votes.Go != 3 || votes.NoGo != 1 || votes.Consensus != "go" {
+		t.Errorf("tally = %d go / %d no-go, consensus %s", votes.Go, votes.NoGo, votes.Consensus)
+	}
+	if votes.AvgConfidence != 4 || votes.Agreement != 0.75 {
+		t.Errorf("avg confidence = %v, agreement = %v", votes.AvgConfidence, votes.Agreement)
+	}
+	if strings.Join(votes.Dissent, ",") != "paranoid" {
+		t.Errorf("dissent = %v", votes.Dissent)
+	}
+	if strings.Join(votes.Missing, ",") != "academic" {
+		t.Errorf("missing = %v", votes.Missing)
+	}
+	if len(votes.Risks) == 0 || votes.Risks[0].Count != 3 || votes.Risks[0].Risk != "Scope creep" {
+		t.Errorf("top risk = %+v", votes.Risks)
+	}
+
+	md := votes.Markdown()
+	for _, want := range []string{
+		"## Council Vote",
+		"- **Consensus:** GO — strong consensus (3 go / 1 no-go)",
+		"- **Average confidence:** 4 / 5",
+		"- **Dissent:** paranoid",
+		"| paranoid Vern | NO-GO (dissent) | 2 | paranoid way | Scope creep; Breach |",
+		"- Scope creep (3 Verns: startup, yolo, paranoid)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestTallyVotes_Split(t *testing.T) {
+	votes := TallyVotes("idea", []VernHoleResult{
+		verdictResult("startup", "go", 3),
+		verdictResult("enterprise", "no-go", 3),
+	})
+	if votes.Consensus != "split" || len(votes.Dissent) != 0 {
+		t.Errorf("consensus = %s, dissent = %v", votes.Consensus, votes.Dissent)
+	}
+	if !strings.Contains(votes.Markdown(), "SPLIT — split decision") {
+		t.Errorf("markdown:\n%s", votes.Markdown())
+	}
+}
+
+func TestWriteVotes(t *testing.T) {
+	dir := t.TempDir()
+	votes := TallyVotes("idea", []VernHoleResult{verdictResult("startup", "go", 4, "Risk")})
+	path := filepath.Join(dir, VotesFile)
+	if err := WriteVotes(path, votes); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Votes
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Consensus != "go" || len(got.Verdicts) != 1 || got.Verdicts[0].Risks[0] != "Risk" {
+		t.Errorf("round-trip = %+v", got)
+	}
+
+	synth := filepath.Join(dir, "synthesis.md")
+	os.WriteFile(synth, []byte("# Synthesis\n"), 0644)
+	if err := appendVoteTable(synth, votes); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(synth)
+	if !strings.HasPrefix(string(data), "# Synthesis\n") || !strings.Contains(string(data), "## Council Vote") {
+		t.Errorf("synthesis = %q", data)
+	}
+}
@@ -0,0 +1,343 @@
+// Package schedule turns a VTS task set into an effort estimate and a
+// phased, team-constrained schedule.
+package schedule
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jdonohoo/vern-bot/go/internal/tobeads"
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+// fallbackComplexity is used for tasks whose complexity has no effort range.
+const fallbackComplexity = "M"
+
+// Options configures schedule construction.
+type Options struct {
+	TeamSize int                   // developers working in parallel (min 1)
+	Effort   map[string][2]float64 // complexity code -> [min, max] days
+}
+
+// Entry is one scheduled task. All times are in days from project start.
+// EarliestStart/LatestStart/Slack come from the critical-path pass and
+// ignore team size; Start/Finish/Developer are the team-constrained plan.
+type Entry struct {
+	Task          vts.Task
+	Complexity    string // effort range key actually used
+	Wave          int    // 1-based: all deps are in earlier waves
+	EffortMin     float64
+	EffortMax     float64
+	Effort        float64 // midpoint of the range, used for scheduling
+	EarliestStart float64
+	LatestStart   float64
+	Slack         float64
+	Critical      bool
+	Developer     int // 1-based
+	Start         float64
+	Finish        float64
+}
+
+// Schedule is the full report for a VTS task set.
+type Schedule struct {
+	Entries      []Entry // ordered by wave, then ID
+	Waves        int
+	TeamSize     int
+	Effort       map[string][2]float64
+	TotalEffort  float64
+	CriticalPath []string // VTS IDs, first to last
+	CriticalDays float64  // critical path length at midpoint effort
+	CriticalMin  float64  // ... at minimum effort
+	CriticalMax  float64  // ... at maximum effort
+	Duration     float64  // team-constrained finish of the last task
+	Warnings     []string
+}
+
+// Build validates the task set (same preflight as tobeads) and schedules it.
+func Build(tasks []vts.Task, opts Options) (*Schedule, error) {
+	report := tobeads.Preflight(tasks)
+	if !report.OK() {
+		return nil, fmt.Errorf("preflight failed:\n%s", report.String())
+	}
+
+	teamSize := opts.TeamSize
+	if teamSize < 1 {
+		teamSize = 1
+	}
+	s := &Schedule{TeamSize: teamSize, Effort: opts.Effort}
+
+	byID := map[string]*Entry{}
+	dependents := map[string][]string{}
+	for _, t := range tasks {
+		e := &Entry{Task: t}
+		code := strings.ToUpper(strings.TrimSpace(t.Complexity))
+		r, ok := opts.Effort[code]
+		if !ok {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("%s: no effort range for complexity %q, using %s", t.ID, t.Complexity, fallbackComplexity))
+			code = fallbackComplexity
+			r = opts.Effort[code]
+		}
+		e.Complexity = code
+		e.EffortMin, e.EffortMax = r[0], r[1]
+		e.Effort = (r[0] + r[1]) / 2
+		s.TotalEffort += e.Effort
+		byID[t.ID] = e
+		for _, dep := range t.Dependencies {
+			dependents[dep] = append(dependents[dep], t.ID)
+		}
+	}
+
+	// Forward pass in topological order: waves, earliest start, and the
+	// critical-path length at min/mid/max effort.
+	finishMin, finishMax := map[string]float64{}, map[string]float64{}
+	for _, id := range report.Order {
+		e := byID[id]
+		e.Wave = 1
+		var startMin, startMax float64
+		for _, dep := range e.Task.Dependencies {
+			d := byID[dep]
+			e.Wave = max(e.Wave, d.Wave+1)
+			e.EarliestStart = math.Max(e.EarliestStart, d.EarliestStart+d.Effort)
+			startMin = math.Max(startMin, finishMin[dep])
+			startMax = math.Max(startMax, finishMax[dep])
+		}
+		finishMin[id] = startMin + e.EffortMin
+		finishMax[id] = startMax + e.EffortMax
+		s.Waves = max(s.Waves, e.Wave)
+		s.CriticalDays = math.Max(s.CriticalDays, e.EarliestStart+e.Effort)
+		s.CriticalMin = math.Max(s.CriticalMin, finishMin[id])
+		s.CriticalMax = math.Max(s.CriticalMax, finishMax[id])
+	}
+
+	// Backward pass: latest start without delaying the project
+	for i := len(report.Order) - 1; i >= 0; i-- {
+		e := byID[report.Order[i]]
+		latestFinish := s.CriticalDays
+		for _, dep := range dependents[e.Task.ID] {
+			latestFinish = math.Min(latestFinish, byID[dep].LatestStart)
+		}
+		e.LatestStart = latestFinish - e.Effort
+		e.Slack = roundDays(e.LatestStart - e.EarliestStart)
+		e.Critical = e.Slack == 0
+	}
+
+	s.CriticalPath = criticalPath(byID)
+	s.Duration = assignDevelopers(byID, teamSize)
+
+	for _, e := range byID {
+		s.Entries = append(s.Entries, *e)
+	}
+	sort.Slice(s.Entries, func(i, j int) bool {
+		if s.Entries[i].Wave != s.Entries[j].Wave {
+			return s.Entries[i].Wave < s.Entries[j].Wave
+		}
+		return s.Entries[i].Task.ID < s.Entries[j].Task.ID
+	})
+	return s, nil
+}
+
+// criticalPath walks back from the last-finishing critical task through
+// critical dependencies that finish exactly when it starts.
+func criticalPath(byID map[string]*Entry) []string {
+	var last *Entry
+	for _, e := range sortedEntries(byID) {
+		if e.Critical && (last == nil || e.EarliestStart+e.Effort > last.EarliestStart+last.Effort) {
+			last = e
+		}
+	}
+	var path []string
+	for cur := last; cur != nil; {
+		path = append([]string{cur.Task.ID}, path...)
+		var next *Entry
+		deps := append([]string{}, cur.Task.Dependencies...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			d := byID[dep]
+			if d.Critical && roundDays(d.EarliestStart+d.Effort) == roundDays(cur.EarliestStart) {
+				next = d
+				break
+			}
+		}
+		cur = next
+	}
+	return path
+}
+
+// assignDevelopers list-schedules tasks onto teamSize developers. Among tasks
+// whose deps are all scheduled, the one with the least latest start goes
+// next, onto whichever developer frees up first. Returns the finish time.
+func assignDevelopers(byID map[string]*Entry, teamSize int) float64 {
+	free := make([]float64, teamSize)
+	done := map[string]bool{}
+	var duration float64
+	entries := sortedEntries(byID)
+
+	for len(done) < len(entries) {
+		var next *Entry
+		for _, e := range entries {
+			if done[e.Task.ID] || !depsDone(e, done) {
+				continue
+			}
+			if next == nil || e.LatestStart < next.LatestStart {
+				next = e
+			}
+		}
+
+		dev := 0
+		for i := range free {
+			if free[i] < free[dev] {
+				dev = i
+			}
+		}
+		ready := 0.0
+		for _, dep := range next.Task.Dependencies {
+			ready = math.Max(ready, byID[dep].Finish)
+		}
+		next.Developer = dev + 1
+		next.Start = math.Max(free[dev], ready)
+		next.Finish = next.Start + next.Effort
+		free[dev] = next.Finish
+		done[next.Task.ID] = true
+		duration = math.Max(duration, next.Finish)
+	}
+	return duration
+}
+
+func depsDone(e *Entry, done map[string]bool) bool {
+	for _, dep := range e.Task.Dependencies {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedEntries(byID map[string]*Entry) []*Entry {
+	var entries []*Entry
+	for _, e := range byID {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Task.ID < entries[j].Task.ID })
+	return entries
+}
+
+// roundDays rounds to 1/100 day so float noise doesn't hide zero slack
+// (adding 0 turns -0 into 0).
+func roundDays(d float64) float64 {
+	return math.Round(d*100)/100 + 0
+}
+
+// days formats a day count with at most two decimals ("3", "0.75").
+func days(d float64) string {
+	return strconv.FormatFloat(roundDays(d), 'f', -1, 64)
+}
+
+// timelineWidth is the bar width of the Markdown timeline.
+const timelineWidth = 50
+
+// Markdown renders the schedule report.
+func (s *Schedule) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# VTS Schedule\n\n")
+	fmt.Fprintf(&b, "- **Tasks:** %d in %d waves (total effort %s days)\n", len(s.Entries), s.Waves, days(s.TotalEffort))
+	fmt.Fprintf(&b, "- **Team size:** %d\n", s.TeamSize)
+	fmt.Fprintf(&b, "- **Estimated duration:** %s days\n", days(s.Duration))
+	fmt.Fprintf(&b, "- **Critical path:** %s days (range %s–%s): %s\n",
+		days(s.CriticalDays), days(s.CriticalMin), days(s.CriticalMax), strings.Join(s.CriticalPath, " → "))
+	b.WriteString("\nEffort is the midpoint of each complexity range. Earliest/latest start and slack assume unlimited parallelism; Start/Finish/Dev are the plan for the given team size. `*` marks critical-path tasks.\n")
+
+	b.WriteString("\n## Effort Ranges (days)\n\n")
+	b.WriteString("| Complexity | Min | Max |\n|------------|-----|-----|\n")
+	var codes []string
+	for code := range s.Effort {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return s.Effort[codes[i]][1] < s.Effort[codes[j]][1] })
+	for _, code := range codes {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", code, days(s.Effort[code][0]), days(s.Effort[code][1]))
+	}
+
+	wave := 0
+	for _, e := range s.Entries {
+		if e.Wave != wave {
+			wave = e.Wave
+			fmt.Fprintf(&b, "\n## Wave %d\n\n", wave)
+			b.WriteString("| ID | Task | Size | Effort | Earliest | Latest | Slack | Dev | Start | Finish |\n")
+			b.WriteString("|----|------|------|--------|----------|--------|-------|-----|-------|--------|\n")
+		}
+		id := e.Task.ID
+		if e.Critical {
+			id += " *"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s (%s–%s) | %s | %s | %s | %d | %s | %s |\n",
+			id, e.Task.Title, e.Complexity, days(e.Effort), days(e.EffortMin), days(e.EffortMax),
+			days(e.EarliestStart), days(e.LatestStart), days(e.Slack), e.Developer, days(e.Start), days(e.Finish))
+	}
+
+	b.WriteString("\n## Timeline\n\n```text\n")
+	for _, e := range s.timelineOrder() {
+		fmt.Fprintf(&b, "%-8s dev %-2d |%s| %s–%s\n", e.Task.ID, e.Developer, s.bar(e), days(e.Start), days(e.Finish))
+	}
+	b.WriteString("```\n")
+
+	if len(s.Warnings) > 0 {
+		b.WriteString("\n## Warnings\n\n")
+		for _, w := range s.Warnings {
+			fmt.Fprintf(&b, "- %s\n", w)
+		}
+	}
+	return b.String()
+}
+
+func (s *Schedule) timelineOrder() []Entry {
+	entries := append([]Entry{}, s.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start < entries[j].Start })
+	return entries
+}
+
+func (s *Schedule) bar(e Entry) string {
+	if s.Duration == 0 {
+		return strings.Repeat(" ", timelineWidth)
+	}
+	from := int(math.Round(e.Start / s.Duration * timelineWidth))
+	to := int(math.Round(e.Finish / s.Duration * timelineWidth))
+	if to <= from {
+		to = from + 1
+	}
+	to = min(to, timelineWidth)
+	from = min(from, to-1)
+	return strings.Repeat(" ", from) + strings.Repeat("█", to-from) + strings.Repeat(" ", timelineWidth-to)
+}
+
+// WriteCSV writes one row per task in wave order.
+func (s *Schedule) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "title", "complexity", "wave", "effort_min", "effort_max", "effort",
+		"earliest_start", "latest_start", "slack", "critical", "developer", "start", "finish", "dependencies"})
+	for _, e := range s.Entries {
+		cw.Write([]string{
+			e.Task.ID,
+			e.Task.Title,
+			e.Complexity,
+			strconv.Itoa(e.Wave),
+			days(e.EffortMin),
+			days(e.EffortMax),
+			days(e.Effort),
+			days(e.EarliestStart),
+			days(e.LatestStart),
+			days(e.Slack),
+			strconv.FormatBool(e.Critical),
+			strconv.Itoa(e.Developer),
+			days(e.Start),
+			days(e.Finish),
+			strings.Join(e.Task.Dependencies, " "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,171 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+var testEffort = map[string][2]float64{
+	"XS": {0.5, 1},
+	"S":  {1, 2},
+	"M":  {2, 4},
+	"L":  {4, 8},
+	"XL": {8, 15},
+}
+
+// diamond: A → {B, C} → D, with B on the critical path.
+func diamond() []vts.Task {
+	return []vts.Task{
+		{ID: "VTS-001", Title: "A", Complexity: "S", Status: "pending"},
+		{ID: "VTS-002", Title: "B", Complexity: "M", Status: "pending", Dependencies: []string{"VTS-001"}},
+		{ID: "VTS-003", Title: "C", Complexity: "XS", Status: "pending", Dependencies: []string{"VTS-001"}},
+		{ID: "VTS-004", Title: "D", Complexity: "S", Status: "pending", Dependencies: []string{"VTS-002", "VTS-003"}},
+	}
+}
+
+func entryByID(s *Schedule, id string) Entry {
+	for _, e := range s.Entries {
+		if e.Task.ID == id {
+			return e
+		}
+	}
+	return Entry{}
+}
+
+func TestBuild_CriticalPath(t *testing.T) {
+	s, err := Build(diamond(), Options{TeamSize: 2, Effort: testEffort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Waves != 3 {
+		t.Errorf("waves = %d, want 3", s.Waves)
+	}
+	if s.CriticalDays != 6 || s.CriticalMin != 4 || s.CriticalMax != 8 {
+		t.Errorf("critical = %.2f (%.2f–%.2f), want 6 (4–8)", s.CriticalDays, s.CriticalMin, s.CriticalMax)
+	}
+	if got := strings.Join(s.CriticalPath, ","); got != "VTS-001,VTS-002,VTS-004" {
+		t.Errorf("critical path = %s", got)
+	}
+
+	tests := []struct {
+		id       string
+		wave     int
+		earliest float64
+		latest   float64
+		slack    float64
+		critical bool
+	}{
+		{"VTS-001", 1, 0, 0, 0, true},
+		{"VTS-002", 2, 1.5, 1.5, 0, true},
+		{"VTS-003", 2, 1.5, 3.75, 2.25, false},
+		{"VTS-004", 3, 4.5, 4.5, 0, true},
+	}
+	for _, tt := range tests {
+		e := entryByID(s, tt.id)
+		if e.Wave != tt.wave || e.EarliestStart != tt.earliest || e.LatestStart != tt.latest ||
+			e.Slack != tt.slack || e.Critical != tt.critical {
+			t.Errorf("%s = wave %d ES %.2f LS %.2f slack %.2f critical %v, want %d %.2f %.2f %.2f %v",
+				tt.id, e.Wave, e.EarliestStart, e.LatestStart, e.Slack, e.Critical,
+				tt.wave, tt.earliest, tt.latest, tt.slack, tt.critical)
+		}
+	}
+}
+
+func TestBuild_TeamSize(t *testing.T) {
+	tests := []struct {
+		team     int
+		duration float64
+	}{
+		{1, 6.75}, // serial: total effort
+		{2, 6},    // C runs alongside B; critical path bound
+		{5, 6},
+	}
+	for _, tt := range tests {
+		s, err := Build(diamond(), Options{TeamSize: tt.team, Effort: testEffort})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Duration != tt.duration {
+			t.Errorf("team %d: duration = %.2f, want %.2f", tt.team, s.Duration, tt.duration)
+		}
+		for _, e := range s.Entries {
+			if e.Developer < 1 || e.Developer > tt.team {
+				t.Errorf("team %d: %s assigned to dev %d", tt.team, e.Task.ID, e.Developer)
+			}
+		}
+	}
+
+	s, _ := Build(diamond(), Options{TeamSize: 2, Effort: testEffort})
+	b, c := entryByID(s, "VTS-002"), entryByID(s, "VTS-003")
+	if b.Developer == c.Developer {
+		t.Error("B and C should run in parallel on different developers")
+	}
+	if d := entryByID(s, "VTS-004"); d.Start != b.Finish {
+		t.Errorf("D start = %.2f, want B finish %.2f", d.Start, b.Finish)
+	}
+}
+
+func TestBuild_UnknownComplexity(t *testing.T) {
+	tasks := []vts.Task{{ID: "VTS-001", Title: "A", Complexity: "?", Status: "pending"}}
+	s, err := Build(tasks, Options{Effort: testEffort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Entries[0].Complexity != "M" || s.Entries[0].Effort != 3 {
+		t.Errorf("entry = %+v, want M fallback", s.Entries[0])
+	}
+	if len(s.Warnings) != 1 {
+		t.Errorf("warnings = %v, want 1", s.Warnings)
+	}
+}
+
+func TestBuild_Cycle(t *testing.T) {
+	tasks := []vts.Task{
+		{ID: "VTS-001", Title: "A", Status: "pending", Dependencies: []string{"VTS-002"}},
+		{ID: "VTS-002", Title: "B", Status: "pending", Dependencies: []string{"VTS-001"}},
+	}
+	if _, err := Build(tasks, Options{Effort: testEffort}); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestSchedule_Markdown(t *testing.T) {
+	s, _ := Build(diamond(), Options{TeamSize: 2, Effort: testEffort})
+	md := s.Markdown()
+	for _, want := range []string{
+		"# VTS Schedule",
+		"- **Team size:** 2",
+		"- **Estimated duration:** 6 days",
+		"VTS-001 → VTS-002 → VTS-004",
+		"## Wave 2",
+		"| VTS-002 * | B | M | 3 (2–4) |",
+		"## Timeline",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestSchedule_WriteCSV(t *testing.T) {
+	s, _ := Build(diamond(), Options{TeamSize: 1, Effort: testEffort})
+	var buf bytes.Buffer
+	if err := s.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("rows = %d, want header + 4", len(rows))
+	}
+	last := rows[4]
+	if last[0] != "VTS-004" || last[3] != "3" || last[13] != "6.75" || last[14] != "VTS-002 VTS-003" {
+		t.Errorf("last row = %v", last)
+	}
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table renders the report as plain-text tables, one per dimension.
+func (r *Report) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d runs", r.Runs)
+	if r.First != nil {
+		fmt.Fprintf(&b, " from %s to %s", r.First.Local().Format("2006-01-02 15:04"), r.Last.Local().Format("2006-01-02 15:04"))
+	}
+	if r.Skipped > 0 {
+		fmt.Fprintf(&b, " (%d unreadable log lines skipped)", r.Skipped)
+	}
+	b.WriteString("\n")
+
+	row := "%-24s %6s %7s %8s %9s %8s %8s %9s %9s\n"
+	for _, dim := range r.By {
+		fmt.Fprintf(&b, "\n"+row, strings.ToUpper(dim), "RUNS", "OK", "TIMEOUT", "FALLBACK", "P50", "P95", "OUT P50", "OUT P95")
+		for _, g := range r.Groups {
+			if g.Dimension != dim {
+				continue
+			}
+			fmt.Fprintf(&b, row, g.Key, strconv.Itoa(g.Runs),
+				percent(g.SuccessRate), percent(g.TimeoutRate), percent(g.FallbackRate),
+				duration(g.DurationP50Ms), duration(g.DurationP95Ms),
+				size(g.OutputP50Bytes), size(g.OutputP95Bytes))
+		}
+	}
+	return b.String()
+}
+
+// JSON renders the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteCSV writes one row per group, durations in milliseconds and sizes
+// in bytes.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"dimension", "key", "runs", "succeeded", "timed_out", "fell_back",
+		"success_rate", "timeout_rate", "fallback_rate",
+		"duration_p50_ms", "duration_p95_ms", "output_p50_bytes", "output_p95_bytes"})
+	for _, g := range r.Groups {
+		cw.Write([]string{
+			g.Dimension,
+			g.Key,
+			strconv.Itoa(g.Runs),
+			strconv.Itoa(g.Succeeded),
+			strconv.Itoa(g.TimedOut),
+			strconv.Itoa(g.FellBack),
+			strconv.FormatFloat(g.SuccessRate, 'f', -1, 64),
+			strconv.FormatFloat(g.TimeoutRate, 'f', -1, 64),
+			strconv.FormatFloat(g.FallbackRate, 'f', -1, 64),
+			strconv.FormatInt(g.DurationP50Ms, 10),
+			strconv.FormatInt(g.DurationP95Ms, 10),
+			strconv.Itoa(g.OutputP50Bytes),
+			strconv.Itoa(g.OutputP95Bytes),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// percent formats a rate as "87.5%".
+func percent(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 1, 64) + "%"
+}
+
+// duration formats milliseconds as "850ms", "42s", or "3m05s".
+func duration(ms int64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	d := (time.Duration(ms) * time.Millisecond).Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// size formats a byte count as "512B", "4.2KB", or "1.3MB".
+func size(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%dB", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1fKB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1024*1024))
+	}
+}
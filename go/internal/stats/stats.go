@@ -0,0 +1,299 @@
+// Package stats aggregates the run log (~/.config/vern/logs/vern.log) into
+// usage statistics by persona, LLM, and command.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/llm"
+)
+
+// Dimensions a report can group runs by.
+const (
+	ByPersona = "persona"
+	ByLLM     = "llm" // the LLM asked for, so fallbacks count against it
+	ByCommand = "command"
+	ByStep    = "step"
+)
+
+// Dimensions lists every dimension in report order.
+var Dimensions = []string{ByPersona, ByLLM, ByCommand, ByStep}
+
+// DefaultDimensions are the dimensions reported when none are given.
+var DefaultDimensions = []string{ByPersona, ByLLM, ByCommand}
+
+// none labels runs without a value for a dimension: runs with no persona,
+// and entries logged before persona, step, and command were recorded.
+const none = "(none)"
+
+// Entry is one run from the log.
+type Entry struct {
+	Time         time.Time `json:"time"`
+	Command      string    `json:"command"`
+	Step         string    `json:"step"`
+	Persona      string    `json:"persona"`
+	LLMRequested string    `json:"llm_requested"`
+	LLMUsed      string    `json:"llm_used"`
+	ExitCode     int       `json:"exit_code"`
+	TimedOut     bool      `json:"timed_out"`
+	DurationMs   int64     `json:"duration_ms"`
+	Error        string    `json:"error"`
+	OutputBytes  int       `json:"output_bytes"`
+}
+
+// Succeeded reports whether the run exited cleanly with usable output.
+func (e Entry) Succeeded() bool {
+	return e.ExitCode == 0 && !e.TimedOut && e.Error == ""
+}
+
+// FellBack reports whether the run used another LLM than the one asked for.
+func (e Entry) FellBack() bool {
+	return e.LLMUsed != "" && e.LLMRequested != "" && llm.CanonicalName(e.LLMUsed) != llm.CanonicalName(e.LLMRequested)
+}
+
+// key returns the entry's value for a dimension.
+func (e Entry) key(dim string) string {
+	var k string
+	switch dim {
+	case ByPersona:
+		k = e.Persona
+	case ByLLM:
+		k = llm.CanonicalName(e.LLMRequested)
+		if k == "" {
+			k = e.LLMUsed
+		}
+	case ByCommand:
+		k = e.Command
+	case ByStep:
+		k = e.Step
+	}
+	if k == "" {
+		return none
+	}
+	return k
+}
+
+// ReadLog reads a JSONL run log. Lines that aren't valid entries are
+// skipped and counted.
+func ReadLog(path string) ([]Entry, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	skipped := 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil || e.Time.IsZero() {
+			skipped++
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, 0, fmt.Errorf("read %s: %w", path, err)
+	}
+	return entries, skipped, nil
+}
+
+// Options selects and groups the runs in a report.
+type Options struct {
+	Since time.Time // optional: only runs at or after this time
+	Until time.Time // optional: only runs before this time
+	By    []string  // dimensions to group by (default: DefaultDimensions)
+}
+
+// Group is the statistics for the runs sharing one value of a dimension.
+type Group struct {
+	Dimension      string  `json:"dimension"`
+	Key            string  `json:"key"`
+	Runs           int     `json:"runs"`
+	Succeeded      int     `json:"succeeded"`
+	TimedOut       int     `json:"timed_out"`
+	FellBack       int     `json:"fell_back"`
+	SuccessRate    float64 `json:"success_rate"`
+	TimeoutRate    float64 `json:"timeout_rate"`
+	FallbackRate   float64 `json:"fallback_rate"`
+	DurationP50Ms  int64   `json:"duration_p50_ms"`
+	DurationP95Ms  int64   `json:"duration_p95_ms"`
+	OutputP50Bytes int     `json:"output_p50_bytes"`
+	OutputP95Bytes int     `json:"output_p95_bytes"`
+}
+
+// Report is the aggregated view of a run log.
+type Report struct {
+	Since   *time.Time `json:"since,omitempty"`
+	Until   *time.Time `json:"until,omitempty"`
+	First   *time.Time `json:"first,omitempty"` // earliest run in range
+	Last    *time.Time `json:"last,omitempty"`  // latest run in range
+	Runs    int        `json:"runs"`
+	Skipped int        `json:"skipped,omitempty"` // unreadable log lines
+	By      []string   `json:"by"`
+	Groups  []Group    `json:"groups"`
+}
+
+// Build aggregates the entries in the options' range. Within a dimension,
+// groups are ordered by run count, most first.
+func Build(entries []Entry, opts Options) (*Report, error) {
+	by := opts.By
+	if len(by) == 0 {
+		by = DefaultDimensions
+	}
+	for _, dim := range by {
+		if !validDimension(dim) {
+			return nil, fmt.Errorf("unknown dimension %q (valid: %s)", dim, strings.Join(Dimensions, ", "))
+		}
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Until.After(opts.Since) {
+		return nil, fmt.Errorf("empty date range: %s to %s", opts.Since.Format(time.RFC3339), opts.Until.Format(time.RFC3339))
+	}
+
+	r := &Report{By: by, Groups: []Group{}}
+	if !opts.Since.IsZero() {
+		r.Since = &opts.Since
+	}
+	if !opts.Until.IsZero() {
+		r.Until = &opts.Until
+	}
+
+	var in []Entry
+	for _, e := range entries {
+		if !opts.Since.IsZero() && e.Time.Before(opts.Since) {
+			continue
+		}
+		if !opts.Until.IsZero() && !e.Time.Before(opts.Until) {
+			continue
+		}
+		in = append(in, e)
+		if r.First == nil || e.Time.Before(*r.First) {
+			t := e.Time
+			r.First = &t
+		}
+		if r.Last == nil || e.Time.After(*r.Last) {
+			t := e.Time
+			r.Last = &t
+		}
+	}
+	r.Runs = len(in)
+
+	for _, dim := range by {
+		buckets := map[string][]Entry{}
+		for _, e := range in {
+			k := e.key(dim)
+			buckets[k] = append(buckets[k], e)
+		}
+		groups := make([]Group, 0, len(buckets))
+		for k, es := range buckets {
+			groups = append(groups, aggregate(dim, k, es))
+		}
+		sort.Slice(groups, func(i, j int) bool {
+			if groups[i].Runs != groups[j].Runs {
+				return groups[i].Runs > groups[j].Runs
+			}
+			return groups[i].Key < groups[j].Key
+		})
+		r.Groups = append(r.Groups, groups...)
+	}
+	return r, nil
+}
+
+func validDimension(dim string) bool {
+	for _, d := range Dimensions {
+		if d == dim {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregate computes one group's statistics.
+func aggregate(dim, key string, entries []Entry) Group {
+	g := Group{Dimension: dim, Key: key, Runs: len(entries)}
+	durations := make([]int64, 0, len(entries))
+	sizes := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		if e.Succeeded() {
+			g.Succeeded++
+		}
+		if e.TimedOut {
+			g.TimedOut++
+		}
+		if e.FellBack() {
+			g.FellBack++
+		}
+		durations = append(durations, e.DurationMs)
+		sizes = append(sizes, int64(e.OutputBytes))
+	}
+	n := float64(g.Runs)
+	g.SuccessRate = rate(g.Succeeded, n)
+	g.TimeoutRate = rate(g.TimedOut, n)
+	g.FallbackRate = rate(g.FellBack, n)
+	g.DurationP50Ms = percentile(durations, 50)
+	g.DurationP95Ms = percentile(durations, 95)
+	g.OutputP50Bytes = int(percentile(sizes, 50))
+	g.OutputP95Bytes = int(percentile(sizes, 95))
+	return g
+}
+
+// rate returns count/n rounded to three decimals.
+func rate(count int, n float64) float64 {
+	if n == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/n*1000) / 1000
+}
+
+// percentile returns the nearest-rank p-th percentile of values.
+func percentile(values []int64, p int) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// ParseTime reads a --since/--until value relative to now: a date
+// (2006-01-02, local time), an RFC 3339 timestamp, or an age such as "7d"
+// or "12h". A date given as the end of a range covers that whole day.
+func ParseTime(value string, now time.Time, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (want YYYY-MM-DD, RFC 3339, or an age like 7d or 12h)", value)
+}
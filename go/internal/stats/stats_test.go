@@ -0,0 +1,207 @@
+package stats
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func entryAt(day int, persona, llmName, used string, exit int, timedOut bool, ms int64, size int) Entry {
+	return Entry{
+		Time:         time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC),
+		Command:      "hole",
+		Step:         "vernhole",
+		Persona:      persona,
+		LLMRequested: llmName,
+		LLMUsed:      used,
+		ExitCode:     exit,
+		TimedOut:     timedOut,
+		DurationMs:   ms,
+		OutputBytes:  size,
+	}
+}
+
+func findGroup(r *Report, dim, key string) *Group {
+	for i, g := range r.Groups {
+		if g.Dimension == dim && g.Key == key {
+			return &r.Groups[i]
+		}
+	}
+	return nil
+}
+
+func TestBuild(t *testing.T) {
+	entries := []Entry{
+		entryAt(1, "mighty", "claude", "claude", 0, false, 1000, 100),
+		entryAt(2, "mighty", "c", "claude", 0, false, 3000, 300),
+		entryAt(3, "mighty", "codex", "codex", 124, true, 9000, 0),
+		entryAt(4, "yolo", "gemini", "claude", 0, false, 2000, 200),
+		entryAt(20, "", "claude", "claude", 0, false, 500, 50),
+	}
+
+	r, err := Build(entries, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Runs != 5 {
+		t.Errorf("Runs = %d, want 5", r.Runs)
+	}
+
+	mighty := findGroup(r, ByPersona, "mighty")
+	if mighty == nil {
+		t.Fatal("no mighty group")
+	}
+	if mighty.Runs != 3 || mighty.Succeeded != 2 || mighty.TimedOut != 1 {
+		t.Errorf("mighty = %+v", mighty)
+	}
+	if mighty.SuccessRate != 0.667 || mighty.TimeoutRate != 0.333 {
+		t.Errorf("mighty rates = %v/%v, want 0.667/0.333", mighty.SuccessRate, mighty.TimeoutRate)
+	}
+	if mighty.DurationP50Ms != 3000 || mighty.DurationP95Ms != 9000 {
+		t.Errorf("mighty durations = %d/%d, want 3000/9000", mighty.DurationP50Ms, mighty.DurationP95Ms)
+	}
+	if mighty.OutputP50Bytes != 100 || mighty.OutputP95Bytes != 300 {
+		t.Errorf("mighty sizes = %d/%d, want 100/300", mighty.OutputP50Bytes, mighty.OutputP95Bytes)
+	}
+	if r.Groups[0] != *mighty {
+		t.Errorf("first group = %s, want the busiest persona", r.Groups[0].Key)
+	}
+	if findGroup(r, ByPersona, none) == nil {
+		t.Error("run without a persona should be grouped under (none)")
+	}
+
+	claude := findGroup(r, ByLLM, "claude")
+	if claude == nil || claude.Runs != 3 || claude.FellBack != 0 {
+		t.Errorf("claude = %+v, want 3 runs (shorthand merged), no fallbacks", claude)
+	}
+	gemini := findGroup(r, ByLLM, "gemini")
+	if gemini == nil || gemini.FellBack != 1 || gemini.FallbackRate != 1 {
+		t.Errorf("gemini = %+v, want its fallback to claude counted", gemini)
+	}
+	if hole := findGroup(r, ByCommand, "hole"); hole == nil || hole.Runs != 5 {
+		t.Errorf("hole = %+v, want 5 runs", hole)
+	}
+	if findGroup(r, ByStep, "vernhole") != nil {
+		t.Error("step is not a default dimension")
+	}
+}
+
+func TestBuildRange(t *testing.T) {
+	entries := []Entry{
+		entryAt(1, "mighty", "claude", "claude", 0, false, 1000, 100),
+		entryAt(5, "mighty", "claude", "claude", 0, false, 1000, 100),
+		entryAt(9, "mighty", "claude", "claude", 0, false, 1000, 100),
+	}
+	since := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC)
+
+	r, err := Build(entries, Options{Since: since, Until: until, By: []string{ByStep}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Runs != 1 || !r.First.Equal(entries[1].Time) {
+		t.Errorf("Runs = %d, First = %v; want only the Oct 5 run (until is exclusive)", r.Runs, r.First)
+	}
+	if len(r.Groups) != 1 || r.Groups[0].Dimension != ByStep {
+		t.Errorf("groups = %+v, want one step group", r.Groups)
+	}
+
+	if _, err := Build(entries, Options{By: []string{"model"}}); err == nil {
+		t.Error("unknown dimension should be an error")
+	}
+	if _, err := Build(entries, Options{Since: until, Until: since}); err == nil {
+		t.Error("inverted range should be an error")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values []int64
+		p      int
+		want   int64
+	}{
+		{nil, 50, 0},
+		{[]int64{7}, 95, 7},
+		{[]int64{4, 1, 3, 2}, 50, 2},
+		{[]int64{4, 1, 3, 2}, 95, 4},
+		{[]int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 50, 50},
+		{[]int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 95, 100},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %d) = %d, want %d", tt.values, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		end     bool
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-10-01", false, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2026-10-01", true, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2026-10-01T08:00:00Z", true, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), false},
+		{"7d", false, time.Date(2026, 10, 12, 15, 30, 0, 0, time.UTC), false},
+		{"12h", false, time.Date(2026, 10, 19, 3, 30, 0, 0, time.UTC), false},
+		{"last week", false, time.Time{}, true},
+		{"-3d", false, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.value, now, tt.end)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q, end=%v) = %v, want %v", tt.value, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestReadLogAndReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vern.log")
+	log := `{"time":"2026-10-01T10:00:00Z","command":"run","step":"run","persona":"mighty","llm_requested":"claude","llm_used":"claude","exit_code":0,"timed_out":false,"duration_ms":1500,"output_bytes":2048,"prompt_preview":"hi"}
+not json
+
+{"time":"2026-10-02T10:00:00Z","llm_requested":"gemini","llm_used":"gemini","exit_code":1,"timed_out":false,"duration_ms":90000,"error":"exit status 1","output_bytes":0}
+`
+	os.WriteFile(path, []byte(log), 0644)
+
+	entries, skipped, err := ReadLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || skipped != 1 {
+		t.Fatalf("got %d entries, %d skipped; want 2, 1", len(entries), skipped)
+	}
+	if entries[0].Persona != "mighty" || entries[1].Succeeded() {
+		t.Errorf("entries = %+v", entries)
+	}
+
+	r, err := Build(entries, Options{By: []string{ByCommand}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := r.Table()
+	for _, want := range []string{"2 runs", "COMMAND", "run ", "(none)", "100.0%", "1m30s", "2.0KB"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table missing %q:\n%s", want, table)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "command,") {
+		t.Errorf("csv = %q, want a header and two command rows", buf.String())
+	}
+}
@@ -3,6 +3,7 @@ package tobeads
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -13,6 +14,25 @@ type BrRunner interface {
 	Create(spec BeadSpec) (beadID string, alreadyExisted bool, err error)
 	DepAdd(fromID, toID string) error
 	Sync() error
+	Show(beadID string) (*BeadState, error)
+	Update(beadID string, spec BeadSpec) error // pushes title + description
+	DepRemove(fromID, toID string) error
+	Close(beadID, reason string) error
+}
+
+// ErrBeadNotFound is returned (wrapped) by BrRunner.Show when br has no
+// bead with the given ID. Other Show errors mean br couldn't answer.
+var ErrBeadNotFound = errors.New("bead not found")
+
+// BeadState is the current state of a bead as reported by br.
+type BeadState struct {
+	ID           string
+	ExternalRef  string
+	Title        string
+	Description  string
+	Status       string
+	Assignee     string
+	Dependencies []string // Beads IDs this bead depends on
 }
 
 // RealBrRunner shells out to the br CLI binary.
@@ -79,6 +99,48 @@ func (r *RealBrRunner) Sync() error {
 	return nil
 }
 
+func (r *RealBrRunner) Show(beadID string) (*BeadState, error) {
+	stdout, stderr, err := r.runBr("show", beadID, "--json")
+	if err != nil {
+		if strings.Contains(strings.ToLower(stderr), "not found") {
+			return nil, fmt.Errorf("br show %s: %w", beadID, ErrBeadNotFound)
+		}
+		return nil, fmt.Errorf("br show %s: %s (stderr: %s)", beadID, err, stderr)
+	}
+	state, parseErr := parseShowJSON(stdout)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse br show output: %w", parseErr)
+	}
+	return state, nil
+}
+
+func (r *RealBrRunner) Update(beadID string, spec BeadSpec) error {
+	_, stderr, err := r.runBr("update", beadID,
+		"--title", spec.Title,
+		"--description", spec.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("br update %s: %s (stderr: %s)", beadID, err, stderr)
+	}
+	return nil
+}
+
+func (r *RealBrRunner) DepRemove(fromID, toID string) error {
+	_, stderr, err := r.runBr("dep", "remove", fromID, toID)
+	if err != nil {
+		return fmt.Errorf("br dep remove %s %s: %s (stderr: %s)", fromID, toID, err, stderr)
+	}
+	return nil
+}
+
+func (r *RealBrRunner) Close(beadID, reason string) error {
+	_, stderr, err := r.runBr("close", beadID, "--reason", reason)
+	if err != nil {
+		return fmt.Errorf("br close %s: %s (stderr: %s)", beadID, err, stderr)
+	}
+	return nil
+}
+
 func (r *RealBrRunner) lookupByExternalRef(extRef string) (string, error) {
 	stdout, _, err := r.runBr("list", "--json")
 	if err != nil {
@@ -136,15 +198,73 @@ func parseCreateJSON(output string) (string, error) {
 	return "", fmt.Errorf("no id field found in JSON output: %s", output)
 }
 
+// parseShowJSON extracts bead state from br show --json output.
+// Accepts a single object or a one-element array; dependencies may be
+// plain IDs or objects carrying depends_on_id.
+func parseShowJSON(output string) (*BeadState, error) {
+	var item map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &item); err != nil {
+		var items []map[string]interface{}
+		if arrErr := json.Unmarshal([]byte(output), &items); arrErr != nil || len(items) == 0 {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		item = items[0]
+	}
+
+	str := func(key string) string {
+		switch v := item[key].(type) {
+		case string:
+			return v
+		case float64:
+			return fmt.Sprintf("%d", int(v))
+		}
+		return ""
+	}
+
+	state := &BeadState{
+		ID:          str("id"),
+		ExternalRef: str("external_ref"),
+		Title:       str("title"),
+		Description: str("description"),
+		Status:      str("status"),
+		Assignee:    str("assignee"),
+	}
+	if state.ID == "" {
+		return nil, fmt.Errorf("no id field found in JSON output: %s", output)
+	}
+
+	deps, _ := item["dependencies"].([]interface{})
+	for _, d := range deps {
+		switch dep := d.(type) {
+		case string:
+			state.Dependencies = append(state.Dependencies, dep)
+		case map[string]interface{}:
+			for _, key := range []string{"depends_on_id", "id"} {
+				if id, ok := dep[key].(string); ok && id != "" {
+					state.Dependencies = append(state.Dependencies, id)
+					break
+				}
+			}
+		}
+	}
+
+	return state, nil
+}
+
 // MockBrRunner is a test double for BrRunner.
 type MockBrRunner struct {
-	Created    []BeadSpec
-	Deps       [][2]string
-	Synced     bool
-	NextID     int
+	Created      []BeadSpec
+	Deps         [][2]string
+	Synced       bool
+	NextID       int
 	ExistingRefs map[string]string // external_ref -> bead ID (simulates already-created)
-	FailCreate map[string]error   // external_ref -> error
-	FailDep    error
+	FailCreate   map[string]error  // external_ref -> error
+	FailDep      error
+	Beads        map[string]*BeadState // bead ID -> current state
+	Updated      []string              // bead IDs passed to Update
+	RemovedDeps  [][2]string
+	Closed       []string // bead IDs passed to Close
+	FailShow     error    // returned by Show for every bead, e.g. a locked database
 }
 
 func NewMockBrRunner() *MockBrRunner {
@@ -152,6 +272,7 @@ func NewMockBrRunner() *MockBrRunner {
 		NextID:       1,
 		ExistingRefs: map[string]string{},
 		FailCreate:   map[string]error{},
+		Beads:        map[string]*BeadState{},
 	}
 }
 
@@ -166,6 +287,14 @@ func (m *MockBrRunner) Create(spec BeadSpec) (string, bool, error) {
 	m.NextID++
 	m.Created = append(m.Created, spec)
 	m.ExistingRefs[spec.ExternalRef] = id
+	m.Beads[id] = &BeadState{
+		ID:          id,
+		ExternalRef: spec.ExternalRef,
+		Title:       spec.Title,
+		Description: spec.Description,
+		Status:      spec.Status,
+		Assignee:    spec.Assignee,
+	}
 	return id, false, nil
 }
 
@@ -174,6 +303,60 @@ func (m *MockBrRunner) DepAdd(fromID, toID string) error {
 		return m.FailDep
 	}
 	m.Deps = append(m.Deps, [2]string{fromID, toID})
+	if b, ok := m.Beads[fromID]; ok {
+		b.Dependencies = append(b.Dependencies, toID)
+	}
+	return nil
+}
+
+func (m *MockBrRunner) Show(beadID string) (*BeadState, error) {
+	if m.FailShow != nil {
+		return nil, m.FailShow
+	}
+	b, ok := m.Beads[beadID]
+	if !ok {
+		return nil, fmt.Errorf("show %s: %w", beadID, ErrBeadNotFound)
+	}
+	cp := *b
+	cp.Dependencies = append([]string{}, b.Dependencies...)
+	return &cp, nil
+}
+
+func (m *MockBrRunner) Update(beadID string, spec BeadSpec) error {
+	b, ok := m.Beads[beadID]
+	if !ok {
+		return fmt.Errorf("bead %s not found", beadID)
+	}
+	b.Title = spec.Title
+	b.Description = spec.Description
+	m.Updated = append(m.Updated, beadID)
+	return nil
+}
+
+func (m *MockBrRunner) DepRemove(fromID, toID string) error {
+	if m.FailDep != nil {
+		return m.FailDep
+	}
+	m.RemovedDeps = append(m.RemovedDeps, [2]string{fromID, toID})
+	if b, ok := m.Beads[fromID]; ok {
+		var kept []string
+		for _, d := range b.Dependencies {
+			if d != toID {
+				kept = append(kept, d)
+			}
+		}
+		b.Dependencies = kept
+	}
+	return nil
+}
+
+func (m *MockBrRunner) Close(beadID, reason string) error {
+	b, ok := m.Beads[beadID]
+	if !ok {
+		return fmt.Errorf("bead %s not found", beadID)
+	}
+	b.Status = "closed"
+	m.Closed = append(m.Closed, beadID)
 	return nil
 }
 
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/jdonohoo/vern-bot/go/internal/vts"
 )
@@ -16,19 +15,26 @@ type ImportOptions struct {
 	BeadsDir string // optional: target Beads repo
 	Apply    bool   // false = dry-run (default)
 	Sync     bool   // trigger br sync after apply
+	Force    bool   // re-import: overwrite beads edited in Beads since the last sync
 }
 
 // ImportResult holds the outcome of an import run.
 type ImportResult struct {
-	Created  int
-	Skipped  int // already existed
-	Failed   int
-	DepsOK   int
-	DepsFail int
-	Errors   []string
+	Created     int
+	Skipped     int // already existed and unchanged
+	Updated     int
+	Closed      int
+	Failed      int
+	DepsOK      int
+	DepsFail    int
+	DepsRemoved int
+	Conflicts   []string // beads left alone because they were edited in Beads
+	Errors      []string
 }
 
-// Run executes the full import pipeline: parse → normalize → preflight → execute.
+// Run executes the full import pipeline: parse → normalize → preflight →
+// reconcile → execute. Re-running after the VTS set changes updates, rewires,
+// and closes the beads recorded in vts-br-map.json instead of only creating.
 func Run(opts ImportOptions, runner BrRunner) (*ImportResult, error) {
 	result := &ImportResult{}
 
@@ -57,34 +63,35 @@ func Run(opts ImportOptions, runner BrRunner) (*ImportResult, error) {
 		return nil, fmt.Errorf("no valid tasks after normalization")
 	}
 
-	// 4. Dry-run: print action plan
+	// 4. Reconcile against beads from a previous import (none on first run)
+	idMap, err := loadIDMapIfExists(opts.VTSDir)
+	if err != nil {
+		return nil, err
+	}
+	state, err := LoadSyncState(opts.VTSDir)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := BuildPlan(specs, idMap, state.Tasks, opts.Force, runner)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: %w", err)
+	}
+
+	// 5. Dry-run: print reconciliation plan
 	if !opts.Apply {
-		printDryRun(specs)
+		printPlan(plan)
 		return result, nil
 	}
 
-	// 5. Pass 1: Create issues
-	idMap := map[string]string{} // VTS ID -> Beads ID
-	for _, spec := range specs {
-		beadID, existed, err := runner.Create(spec)
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("create %s: %v", spec.ExternalRef, err))
-			fmt.Printf("  FAIL: %s — %v\n", spec.ExternalRef, err)
-			continue
-		}
-		idMap[spec.ExternalRef] = beadID
-		if existed {
-			result.Skipped++
-			fmt.Printf("  SKIP: %s → %s (already exists)\n", spec.ExternalRef, beadID)
-		} else {
-			result.Created++
-			fmt.Printf("  OK:   %s → %s\n", spec.ExternalRef, beadID)
-		}
+	// 6. Execute plan
+	result.Skipped = len(plan.Unchanged)
+	for _, c := range plan.Conflicts {
+		result.Conflicts = append(result.Conflicts, fmt.Sprintf("%s ↔ %s: %s", c.VTSID, c.BeadID, c.Detail))
 	}
+	failed := executePlan(plan, specs, idMap, runner, result)
 
 	// Write ID map
-	mapPath := filepath.Join(opts.VTSDir, "vts-br-map.json")
+	mapPath := filepath.Join(opts.VTSDir, mapFileName)
 	mapData, _ := json.MarshalIndent(idMap, "", "  ")
 	if err := os.WriteFile(mapPath, mapData, 0644); err != nil {
 		result.Errors = append(result.Errors, fmt.Sprintf("write map: %v", err))
@@ -92,29 +99,30 @@ func Run(opts ImportOptions, runner BrRunner) (*ImportResult, error) {
 		fmt.Printf("Wrote %s\n", mapPath)
 	}
 
-	// 6. Pass 2: Wire dependencies
-	fmt.Println("Wiring dependencies...")
+	// Refresh sync baselines: beads that now match their VTS task are
+	// re-baselined on the push side; tasks with failed steps or conflicts
+	// keep their old entry, so sync-back still sees the Beads edits.
+	for vtsID := range state.Tasks {
+		if _, ok := idMap[vtsID]; !ok {
+			delete(state.Tasks, vtsID)
+		}
+	}
 	for _, spec := range specs {
-		fromBR, ok := idMap[spec.ExternalRef]
-		if !ok {
-			continue // failed create, skip
+		beadID, ok := idMap[spec.ExternalRef]
+		if !ok || failed[spec.ExternalRef] || plan.conflicted(spec.ExternalRef) {
+			continue
 		}
-		for _, depVTS := range spec.Dependencies {
-			toBR, ok := idMap[depVTS]
-			if !ok {
-				result.DepsFail++
-				result.Errors = append(result.Errors, fmt.Sprintf("dep %s→%s: target not in map", spec.ExternalRef, depVTS))
-				continue
-			}
-			if err := runner.DepAdd(fromBR, toBR); err != nil {
-				result.DepsFail++
-				result.Errors = append(result.Errors, fmt.Sprintf("dep %s→%s: %v", spec.ExternalRef, depVTS, err))
-				fmt.Printf("  FAIL: %s → %s: %v\n", fromBR, toBR, err)
-			} else {
-				result.DepsOK++
-				fmt.Printf("  DEP:  %s → %s\n", fromBR, toBR)
-			}
+		entry, exists := state.Tasks[spec.ExternalRef]
+		if !exists {
+			state.Tasks[spec.ExternalRef] = baselineEntry(beadID, spec, spec.Dependencies)
+			continue
 		}
+		entry.BeadID = beadID
+		entry.PushHash = pushHash(spec.Title, spec.Description, spec.Dependencies)
+		state.Tasks[spec.ExternalRef] = entry
+	}
+	if err := state.Save(opts.VTSDir); err != nil {
+		result.Errors = append(result.Errors, err.Error())
 	}
 
 	// 7. Optional sync
@@ -134,35 +142,116 @@ func Run(opts ImportOptions, runner BrRunner) (*ImportResult, error) {
 	return result, nil
 }
 
-func printDryRun(specs []BeadSpec) {
-	fmt.Println("\n=== DRY RUN ===")
-	fmt.Printf("Would create %d issues:\n\n", len(specs))
+// executePlan applies plan steps in order, updating idMap as beads are
+// created or closed. Returns the VTS IDs that had at least one failed step.
+func executePlan(plan *Plan, specs []BeadSpec, idMap map[string]string, runner BrRunner, result *ImportResult) map[string]bool {
+	failed := map[string]bool{}
+	byRef := map[string]BeadSpec{}
+	for _, spec := range specs {
+		byRef[spec.ExternalRef] = spec
+	}
+	fail := func(vtsID, msg string) {
+		failed[vtsID] = true
+		result.Errors = append(result.Errors, msg)
+	}
+
+	wiring := false
+	for _, step := range plan.Steps {
+		spec := byRef[step.VTSID]
+		switch step.Action {
+		case ActionCreate:
+			beadID, existed, err := runner.Create(spec)
+			if err != nil {
+				result.Failed++
+				fail(step.VTSID, fmt.Sprintf("create %s: %v", step.VTSID, err))
+				fmt.Printf("  FAIL: %s — %v\n", step.VTSID, err)
+				continue
+			}
+			idMap[step.VTSID] = beadID
+			if existed {
+				result.Skipped++
+				fmt.Printf("  SKIP: %s → %s (already exists)\n", step.VTSID, beadID)
+			} else {
+				result.Created++
+				fmt.Printf("  OK:   %s → %s\n", step.VTSID, beadID)
+			}
 
-	depCount := 0
-	for _, s := range specs {
-		labels := ""
-		if len(s.Labels) > 0 {
-			labels = " [" + strings.Join(s.Labels, ", ") + "]"
-		}
-		deps := ""
-		if len(s.Dependencies) > 0 {
-			deps = " (deps: " + strings.Join(s.Dependencies, ", ") + ")"
-			depCount += len(s.Dependencies)
+		case ActionUpdate:
+			if err := runner.Update(step.BeadID, spec); err != nil {
+				result.Failed++
+				fail(step.VTSID, fmt.Sprintf("update %s: %v", step.VTSID, err))
+				fmt.Printf("  FAIL: %s — %v\n", step.VTSID, err)
+				continue
+			}
+			result.Updated++
+			fmt.Printf("  UPD:  %s → %s (%s)\n", step.VTSID, step.BeadID, step.Detail)
+
+		case ActionDepAdd, ActionDepRemove:
+			if !wiring {
+				fmt.Println("Wiring dependencies...")
+				wiring = true
+			}
+			fromBR, ok := idMap[step.VTSID]
+			if !ok {
+				continue // failed create, skip
+			}
+			toBR, ok := idMap[step.DepVTS]
+			if !ok {
+				result.DepsFail++
+				fail(step.VTSID, fmt.Sprintf("dep %s→%s: target not in map", step.VTSID, step.DepVTS))
+				continue
+			}
+			if step.Action == ActionDepRemove {
+				if err := runner.DepRemove(fromBR, toBR); err != nil {
+					result.DepsFail++
+					fail(step.VTSID, fmt.Sprintf("dep remove %s→%s: %v", step.VTSID, step.DepVTS, err))
+					fmt.Printf("  FAIL: %s -/→ %s: %v\n", fromBR, toBR, err)
+					continue
+				}
+				result.DepsRemoved++
+				fmt.Printf("  UNDEP: %s -/→ %s\n", fromBR, toBR)
+				continue
+			}
+			if err := runner.DepAdd(fromBR, toBR); err != nil {
+				result.DepsFail++
+				fail(step.VTSID, fmt.Sprintf("dep %s→%s: %v", step.VTSID, step.DepVTS, err))
+				fmt.Printf("  FAIL: %s → %s: %v\n", fromBR, toBR, err)
+				continue
+			}
+			result.DepsOK++
+			fmt.Printf("  DEP:  %s → %s\n", fromBR, toBR)
+
+		case ActionClose:
+			if err := runner.Close(step.BeadID, closeReason); err != nil {
+				result.Failed++
+				fail(step.VTSID, fmt.Sprintf("close %s: %v", step.VTSID, err))
+				fmt.Printf("  FAIL: %s — %v\n", step.VTSID, err)
+				continue
+			}
+			delete(idMap, step.VTSID)
+			result.Closed++
+			fmt.Printf("  CLOSE: %s → %s (%s)\n", step.VTSID, step.BeadID, step.Detail)
 		}
-		fmt.Printf("  %s: %s (%s)%s%s\n", s.ExternalRef, s.Title, s.Status, labels, deps)
 	}
-
-	fmt.Printf("\nWould wire %d dependency edges.\n", depCount)
-	fmt.Println("Run with --apply to execute.")
+	return failed
 }
 
 func printSummary(r *ImportResult) {
 	fmt.Println("\n=== SUMMARY ===")
 	fmt.Printf("Created: %d\n", r.Created)
-	fmt.Printf("Skipped: %d (already existed)\n", r.Skipped)
+	fmt.Printf("Updated: %d\n", r.Updated)
+	fmt.Printf("Closed:  %d\n", r.Closed)
+	fmt.Printf("Skipped: %d (already existed, unchanged)\n", r.Skipped)
 	fmt.Printf("Failed:  %d\n", r.Failed)
 	fmt.Printf("Deps OK: %d\n", r.DepsOK)
+	fmt.Printf("Deps Removed: %d\n", r.DepsRemoved)
 	fmt.Printf("Deps Failed: %d\n", r.DepsFail)
+	if len(r.Conflicts) > 0 {
+		fmt.Printf("Conflicts: %d (not updated; use --force to overwrite the Beads edits)\n", len(r.Conflicts))
+		for _, c := range r.Conflicts {
+			fmt.Printf("  - %s\n", c)
+		}
+	}
 	if len(r.Errors) > 0 {
 		fmt.Println("Errors:")
 		for _, e := range r.Errors {
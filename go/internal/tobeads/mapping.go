@@ -11,6 +11,15 @@ var StatusMap = map[string]string{
 	"deferred": "deferred",
 }
 
+// ReverseStatusMap maps Beads statuses back to VTS statuses for sync-back.
+var ReverseStatusMap = map[string]string{
+	"open":        "pending",
+	"in_progress": "active",
+	"blocked":     "blocked",
+	"closed":      "done",
+	"deferred":    "deferred",
+}
+
 // Valid complexity codes. Mapped to labels like "complexity:XS".
 var ValidComplexity = map[string]bool{
 	"XS": true,
@@ -27,6 +36,7 @@ type BeadSpec struct {
 	Description  string   // Body + files + source_ref metadata + acceptance criteria
 	Status       string   // Beads status (mapped)
 	Labels       []string // ["complexity:M", "source:oracle"]
+	Complexity   string   // Normalized complexity code (e.g. "M"), empty if unset
 	Assignee     string   // From owner, empty = unset
 	Dependencies []string // VTS IDs (resolved to Beads IDs in executor)
 }
@@ -44,6 +44,7 @@ func normalizeTask(t vts.Task) (BeadSpec, error) {
 	cx := strings.ToUpper(strings.TrimSpace(t.Complexity))
 	if ValidComplexity[cx] {
 		labels = append(labels, "complexity:"+cx)
+		spec.Complexity = cx
 	} else if cx != "" && cx != "?" {
 		return spec, fmt.Errorf("unknown complexity %q", t.Complexity)
 	}
@@ -45,6 +45,9 @@ func TestNormalize_ValidTask(t *testing.T) {
 	if s.Labels[1] != "source:oracle" {
 		t.Errorf("Labels[1] = %q", s.Labels[1])
 	}
+	if s.Complexity != "M" {
+		t.Errorf("Complexity = %q, want M", s.Complexity)
+	}
 	if !strings.Contains(s.Description, "## Files") {
 		t.Error("description missing files section")
 	}
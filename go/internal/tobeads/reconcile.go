@@ -0,0 +1,258 @@
+package tobeads
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// PlanAction is one kind of reconciliation step.
+type PlanAction string
+
+const (
+	ActionCreate    PlanAction = "create"
+	ActionUpdate    PlanAction = "update"
+	ActionClose     PlanAction = "close"
+	ActionDepAdd    PlanAction = "dep-add"
+	ActionDepRemove PlanAction = "dep-remove"
+)
+
+// closeReason is recorded on beads whose VTS task was removed from the plan.
+const closeReason = "Removed from VTS plan"
+
+// PlanStep is a single change needed to bring Beads in line with the VTS set.
+type PlanStep struct {
+	Action PlanAction
+	VTSID  string
+	BeadID string // empty for creates (assigned on apply)
+	DepVTS string // dep-add / dep-remove: VTS ID of the dependency target
+	Detail string // human-readable summary for the dry-run listing
+}
+
+// Plan is the ordered list of steps for a (re-)import. Steps are grouped in
+// execution order: creates first (so new beads have IDs), then updates,
+// dependency removals, dependency additions, and finally closes.
+type Plan struct {
+	Steps     []PlanStep
+	Unchanged []string   // VTS IDs whose beads already match
+	Conflicts []PlanStep // updates skipped because the bead was edited in Beads since the last sync
+}
+
+// conflicted reports whether vtsID's update was skipped as a conflict.
+func (p *Plan) conflicted(vtsID string) bool {
+	for _, c := range p.Conflicts {
+		if c.VTSID == vtsID {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of steps with the given action.
+func (p *Plan) Count(action PlanAction) int {
+	n := 0
+	for _, s := range p.Steps {
+		if s.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// BuildPlan diffs normalized specs against the beads recorded in idMap
+// (VTS ID -> Beads ID, from vts-br-map.json). Tasks without a mapped bead
+// are created; mapped beads get title/description updates and dependency
+// edits; mapped beads whose task no longer exists are closed. Status and
+// assignee are left to sync-back, where Beads is authoritative.
+// Only runner.Show is called, so building a plan is safe in dry-run.
+// A mapped bead that br reports as not found is recreated; any other Show
+// error aborts the plan rather than guessing, since recreating a bead br
+// merely couldn't read would duplicate it.
+//
+// A bead whose title, description, or dependencies were edited in Beads
+// since the baseline in vts-br-sync.json is a conflict: it gets no update
+// and is listed in Plan.Conflicts, unless force is set.
+func BuildPlan(specs []BeadSpec, idMap map[string]string, baselines map[string]SyncEntry, force bool, runner BrRunner) (*Plan, error) {
+	plan := &Plan{}
+	var creates, updates, depRemoves, depAdds, closes []PlanStep
+
+	beadToVTS := map[string]string{}
+	for vtsID, beadID := range idMap {
+		beadToVTS[beadID] = vtsID
+	}
+
+	inSpecs := map[string]bool{}
+	for _, spec := range specs {
+		inSpecs[spec.ExternalRef] = true
+
+		beadID, mapped := idMap[spec.ExternalRef]
+		var bead *BeadState
+		if mapped {
+			var err error
+			bead, err = runner.Show(beadID)
+			switch {
+			case errors.Is(err, ErrBeadNotFound):
+				// Bead was deleted in Beads: recreate it
+				mapped = false
+				beadID = ""
+			case err != nil:
+				return nil, fmt.Errorf("show %s (%s): %w", beadID, spec.ExternalRef, err)
+			}
+		}
+
+		if !mapped {
+			creates = append(creates, PlanStep{
+				Action: ActionCreate,
+				VTSID:  spec.ExternalRef,
+				Detail: describeCreate(spec),
+			})
+			for _, dep := range spec.Dependencies {
+				depAdds = append(depAdds, PlanStep{Action: ActionDepAdd, VTSID: spec.ExternalRef, DepVTS: dep})
+			}
+			continue
+		}
+
+		// Bead deps in VTS terms; edges to beads outside this VTS set are left alone
+		var beadDeps []string
+		for _, d := range bead.Dependencies {
+			if v, ok := beadToVTS[d]; ok {
+				beadDeps = append(beadDeps, v)
+			}
+		}
+
+		beadPush := pushHash(bead.Title, bead.Description, beadDeps)
+		if entry, ok := baselines[spec.ExternalRef]; ok && !force &&
+			beadPush != entry.PushHash && beadPush != pushHash(spec.Title, spec.Description, spec.Dependencies) {
+			plan.Conflicts = append(plan.Conflicts, PlanStep{
+				Action: ActionUpdate,
+				VTSID:  spec.ExternalRef,
+				BeadID: beadID,
+				Detail: "title/description/dependencies edited in Beads since the last sync",
+			})
+			continue
+		}
+
+		changed := false
+		if fields := changedFields(spec, bead); len(fields) > 0 {
+			updates = append(updates, PlanStep{
+				Action: ActionUpdate,
+				VTSID:  spec.ExternalRef,
+				BeadID: beadID,
+				Detail: strings.Join(fields, ", "),
+			})
+			changed = true
+		}
+		added, removed := diffDeps(spec.Dependencies, beadDeps)
+		for _, dep := range removed {
+			depRemoves = append(depRemoves, PlanStep{Action: ActionDepRemove, VTSID: spec.ExternalRef, BeadID: beadID, DepVTS: dep})
+			changed = true
+		}
+		for _, dep := range added {
+			depAdds = append(depAdds, PlanStep{Action: ActionDepAdd, VTSID: spec.ExternalRef, BeadID: beadID, DepVTS: dep})
+			changed = true
+		}
+		if !changed {
+			plan.Unchanged = append(plan.Unchanged, spec.ExternalRef)
+		}
+	}
+
+	// Mapped beads whose task was removed from the VTS set
+	var removedIDs []string
+	for vtsID := range idMap {
+		if !inSpecs[vtsID] {
+			removedIDs = append(removedIDs, vtsID)
+		}
+	}
+	sort.Strings(removedIDs)
+	for _, vtsID := range removedIDs {
+		beadID := idMap[vtsID]
+		bead, err := runner.Show(beadID)
+		if errors.Is(err, ErrBeadNotFound) {
+			continue // already gone
+		}
+		if err != nil {
+			return nil, fmt.Errorf("show %s (%s): %w", beadID, vtsID, err)
+		}
+		if strings.EqualFold(bead.Status, "closed") {
+			continue
+		}
+		closes = append(closes, PlanStep{
+			Action: ActionClose,
+			VTSID:  vtsID,
+			BeadID: beadID,
+			Detail: "task removed from VTS",
+		})
+	}
+
+	for _, group := range [][]PlanStep{creates, updates, depRemoves, depAdds, closes} {
+		plan.Steps = append(plan.Steps, group...)
+	}
+	return plan, nil
+}
+
+// changedFields lists the pushed fields that differ between spec and bead.
+func changedFields(spec BeadSpec, bead *BeadState) []string {
+	var fields []string
+	if strings.TrimSpace(spec.Title) != strings.TrimSpace(bead.Title) {
+		fields = append(fields, "title")
+	}
+	if strings.TrimSpace(spec.Description) != strings.TrimSpace(bead.Description) {
+		fields = append(fields, "description")
+	}
+	return fields
+}
+
+func describeCreate(s BeadSpec) string {
+	labels := ""
+	if len(s.Labels) > 0 {
+		labels = " [" + strings.Join(s.Labels, ", ") + "]"
+	}
+	return fmt.Sprintf("%s (%s)%s", s.Title, s.Status, labels)
+}
+
+// loadIDMapIfExists returns the recorded ID map, or an empty map on first import.
+func loadIDMapIfExists(vtsDir string) (map[string]string, error) {
+	idMap, err := LoadIDMap(vtsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return idMap, nil
+}
+
+func printPlan(plan *Plan) {
+	fmt.Println("\n=== DRY RUN ===")
+	fmt.Printf("Reconciliation plan: %d steps, %d unchanged\n\n", len(plan.Steps), len(plan.Unchanged))
+
+	for _, s := range plan.Steps {
+		switch s.Action {
+		case ActionCreate:
+			fmt.Printf("  %-10s  %s: %s\n", s.Action, s.VTSID, s.Detail)
+		case ActionDepAdd, ActionDepRemove:
+			fmt.Printf("  %-10s  %s → %s\n", s.Action, s.VTSID, s.DepVTS)
+		default:
+			fmt.Printf("  %-10s  %s → %s (%s)\n", s.Action, s.VTSID, s.BeadID, s.Detail)
+		}
+	}
+
+	fmt.Printf("\nWould create %d, update %d, close %d issues; add %d, remove %d dependency edges.\n",
+		plan.Count(ActionCreate), plan.Count(ActionUpdate), plan.Count(ActionClose),
+		plan.Count(ActionDepAdd), plan.Count(ActionDepRemove))
+	printConflicts(plan.Conflicts)
+	fmt.Println("Run with --apply to execute.")
+}
+
+// printConflicts lists updates skipped because Beads was edited too.
+func printConflicts(conflicts []PlanStep) {
+	if len(conflicts) == 0 {
+		return
+	}
+	fmt.Printf("Conflicts: %d (not updated; review with --sync-back, or use --force to overwrite the Beads edits)\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Printf("  - %s ↔ %s: %s\n", c.VTSID, c.BeadID, c.Detail)
+	}
+}
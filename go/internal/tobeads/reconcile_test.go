@@ -0,0 +1,237 @@
+package tobeads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+func TestBuildPlan_FirstImport(t *testing.T) {
+	specs := []BeadSpec{
+		{ExternalRef: "VTS-001", Title: "A", Status: "open"},
+		{ExternalRef: "VTS-002", Title: "B", Status: "open", Dependencies: []string{"VTS-001"}},
+	}
+	plan, err := BuildPlan(specs, map[string]string{}, nil, false, NewMockBrRunner())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Count(ActionCreate) != 2 || plan.Count(ActionDepAdd) != 1 {
+		t.Errorf("plan = %+v, want 2 creates + 1 dep-add", plan.Steps)
+	}
+	if plan.Steps[0].Action != ActionCreate || plan.Steps[len(plan.Steps)-1].Action != ActionDepAdd {
+		t.Error("creates should come before dep-adds")
+	}
+}
+
+func TestRun_ReimportUnchanged(t *testing.T) {
+	dir, mock := importFixture(t)
+	result, err := Run(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 3 || result.Created != 0 || result.Updated != 0 || result.DepsOK != 0 {
+		t.Errorf("result = %+v, want 3 skipped and nothing else", result)
+	}
+}
+
+// evolveFixture changes the imported plan: retitles VTS-002 and drops its
+// dependency, removes VTS-003, and adds VTS-004 depending on VTS-002.
+func evolveFixture(t *testing.T, dir string) {
+	t.Helper()
+	writeTask(t, dir, "VTS-002", "Parser v2", "pending")
+	if err := os.Remove(filepath.Join(dir, "vts-003.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeTask(t, dir, "VTS-004", "Docs", "pending", "VTS-002")
+}
+
+func TestBuildPlan_Reconcile(t *testing.T) {
+	dir, mock := importFixture(t)
+	evolveFixture(t, dir)
+
+	result, err := Run(ImportOptions{VTSDir: dir}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 0 || len(mock.Updated) != 0 || len(mock.Closed) != 0 {
+		t.Fatal("dry-run should not change beads")
+	}
+
+	specs := mustSpecs(t, dir)
+	idMap, err := LoadIDMap(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := BuildPlan(specs, idMap, nil, false, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []PlanStep{
+		{Action: ActionCreate, VTSID: "VTS-004"},
+		{Action: ActionUpdate, VTSID: "VTS-002", BeadID: "BR-002"},
+		{Action: ActionDepRemove, VTSID: "VTS-002", BeadID: "BR-002", DepVTS: "VTS-001"},
+		{Action: ActionDepAdd, VTSID: "VTS-004", DepVTS: "VTS-002"},
+		{Action: ActionClose, VTSID: "VTS-003", BeadID: "BR-003"},
+	}
+	if len(plan.Steps) != len(want) {
+		t.Fatalf("steps = %+v, want %d", plan.Steps, len(want))
+	}
+	for i, w := range want {
+		got := plan.Steps[i]
+		if got.Action != w.Action || got.VTSID != w.VTSID || got.BeadID != w.BeadID || got.DepVTS != w.DepVTS {
+			t.Errorf("step %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if len(plan.Unchanged) != 1 || plan.Unchanged[0] != "VTS-001" {
+		t.Errorf("unchanged = %v, want [VTS-001]", plan.Unchanged)
+	}
+}
+
+func TestRun_ReimportApply(t *testing.T) {
+	dir, mock := importFixture(t)
+	evolveFixture(t, dir)
+
+	result, err := Run(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Closed != 1 ||
+		result.DepsOK != 1 || result.DepsRemoved != 1 || result.Skipped != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	if mock.Beads["BR-002"].Title != "Parser v2" || len(mock.Beads["BR-002"].Dependencies) != 0 {
+		t.Errorf("BR-002 = %+v", mock.Beads["BR-002"])
+	}
+	if mock.Beads["BR-003"].Status != "closed" {
+		t.Errorf("BR-003 status = %q, want closed", mock.Beads["BR-003"].Status)
+	}
+	if deps := mock.Beads["BR-004"].Dependencies; len(deps) != 1 || deps[0] != "BR-002" {
+		t.Errorf("BR-004 deps = %v, want [BR-002]", deps)
+	}
+
+	idMap, _ := LoadIDMap(dir)
+	if _, ok := idMap["VTS-003"]; ok {
+		t.Error("closed task should be dropped from the map")
+	}
+	if idMap["VTS-004"] != "BR-004" {
+		t.Errorf("map VTS-004 = %q, want BR-004", idMap["VTS-004"])
+	}
+
+	// Applied plan leaves nothing to do, for re-import and sync-back alike
+	plan, err := BuildPlan(mustSpecs(t, dir), idMap, nil, false, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != 0 {
+		t.Errorf("second plan = %+v, want empty", plan.Steps)
+	}
+	sync, err := SyncBack(ImportOptions{VTSDir: dir}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sync.InSync != 3 || len(sync.Conflicts) != 0 {
+		t.Errorf("sync-back = %+v, want 3 in sync", sync)
+	}
+}
+
+func TestBuildPlan_SkipsAlreadyClosed(t *testing.T) {
+	mock := NewMockBrRunner()
+	mock.Beads["BR-009"] = &BeadState{ID: "BR-009", Status: "closed"}
+	plan, err := BuildPlan(nil, map[string]string{"VTS-009": "BR-009"}, nil, false, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != 0 {
+		t.Errorf("steps = %+v, want none for closed bead", plan.Steps)
+	}
+}
+
+func TestBuildPlan_ShowErrors(t *testing.T) {
+	dir, mock := importFixture(t)
+	idMap, err := LoadIDMap(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A bead deleted in Beads is recreated, with its dependencies
+	delete(mock.Beads, "BR-002")
+	plan, err := BuildPlan(mustSpecs(t, dir), idMap, nil, false, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Count(ActionCreate) != 1 || plan.Steps[0].VTSID != "VTS-002" {
+		t.Errorf("steps = %+v, want VTS-002 recreated", plan.Steps)
+	}
+
+	// br failing for any other reason aborts instead of planning duplicates
+	mock.FailShow = fmt.Errorf("database is locked")
+	if _, err := BuildPlan(mustSpecs(t, dir), idMap, nil, false, mock); err == nil || !strings.Contains(err.Error(), "database is locked") {
+		t.Errorf("err = %v, want the show error", err)
+	}
+	if _, err := BuildPlan(nil, idMap, nil, false, mock); err == nil {
+		t.Error("show error on a bead to close should be reported")
+	}
+	if _, err := Run(ImportOptions{VTSDir: dir, Apply: true}, mock); err == nil {
+		t.Error("Run should fail when the plan can't be built")
+	}
+	if len(mock.Created) != 3 {
+		t.Errorf("created %d beads, want only the 3 from the first import", len(mock.Created))
+	}
+}
+
+func mustSpecs(t *testing.T, dir string) []BeadSpec {
+	t.Helper()
+	tasks, err := vts.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, _ := Normalize(tasks)
+	return specs
+}
+
+func TestRun_ReimportConflict(t *testing.T) {
+	dir, mock := importFixture(t)
+	before, err := LoadSyncState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Edited on both sides: VTS retitles VTS-002, Beads rewrites its description
+	writeTask(t, dir, "VTS-002", "Parser v2", "pending", "VTS-001")
+	mock.Beads["BR-002"].Description = "Edited in Beads"
+
+	result, err := Run(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || result.Updated != 0 || len(mock.Updated) != 0 {
+		t.Errorf("result = %+v, want VTS-002 reported as a conflict and not updated", result)
+	}
+	if mock.Beads["BR-002"].Description != "Edited in Beads" {
+		t.Error("Beads edit should survive re-import")
+	}
+	after, _ := LoadSyncState(dir)
+	if after.Tasks["VTS-002"].PushHash != before.Tasks["VTS-002"].PushHash {
+		t.Error("conflicted task should keep its old baseline")
+	}
+	sync, err := SyncBack(ImportOptions{VTSDir: dir}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sync.Conflicts) != 1 {
+		t.Errorf("sync-back conflicts = %v, want the VTS-002 conflict still visible", sync.Conflicts)
+	}
+
+	result, err = Run(ImportOptions{VTSDir: dir, Apply: true, Force: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 0 || result.Updated != 1 || mock.Beads["BR-002"].Title != "Parser v2" {
+		t.Errorf("forced result = %+v, want VTS-002 overwritten", result)
+	}
+}
@@ -0,0 +1,378 @@
+package tobeads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+const (
+	mapFileName  = "vts-br-map.json"
+	syncFileName = "vts-br-sync.json"
+)
+
+// SyncEntry records the last-synced state of one VTS task ↔ bead pair.
+// Hashes cover the two field groups that flow in opposite directions:
+// push fields (title, description, deps) go VTS → Beads, pull fields
+// (status, assignee) go Beads → VTS.
+type SyncEntry struct {
+	BeadID   string `json:"bead_id"`
+	PushHash string `json:"push_hash"`
+	PullHash string `json:"pull_hash"`
+	SyncedAt string `json:"synced_at"`
+}
+
+// SyncState is persisted as vts-br-sync.json next to vts-br-map.json.
+type SyncState struct {
+	Tasks map[string]SyncEntry `json:"tasks"` // VTS ID -> entry
+}
+
+// SyncResult holds the outcome of a sync-back run.
+type SyncResult struct {
+	Pushed    int
+	Pulled    int
+	InSync    int
+	Conflicts []string
+	Notes     []string
+	Errors    []string
+}
+
+// LoadIDMap reads vts-br-map.json (VTS ID -> Beads ID) from a VTS directory.
+func LoadIDMap(vtsDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(vtsDir, mapFileName))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", mapFileName, err)
+	}
+	idMap := map[string]string{}
+	if err := json.Unmarshal(data, &idMap); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", mapFileName, err)
+	}
+	return idMap, nil
+}
+
+// LoadSyncState reads vts-br-sync.json. A missing file yields an empty state.
+func LoadSyncState(vtsDir string) (*SyncState, error) {
+	state := &SyncState{Tasks: map[string]SyncEntry{}}
+	data, err := os.ReadFile(filepath.Join(vtsDir, syncFileName))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", syncFileName, err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", syncFileName, err)
+	}
+	if state.Tasks == nil {
+		state.Tasks = map[string]SyncEntry{}
+	}
+	return state, nil
+}
+
+// Save writes the sync state to vts-br-sync.json.
+func (s *SyncState) Save(vtsDir string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal sync state: %w", err)
+	}
+	path := filepath.Join(vtsDir, syncFileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", syncFileName, err)
+	}
+	return nil
+}
+
+// baselineEntry builds a sync entry treating the spec as the agreed state.
+func baselineEntry(beadID string, spec BeadSpec, deps []string) SyncEntry {
+	return SyncEntry{
+		BeadID:   beadID,
+		PushHash: pushHash(spec.Title, spec.Description, deps),
+		PullHash: pullHash(spec.Status, spec.Assignee),
+		SyncedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// pushHash fingerprints the fields that flow VTS → Beads.
+func pushHash(title, description string, deps []string) string {
+	sorted := append([]string{}, deps...)
+	sort.Strings(sorted)
+	return fieldHash(strings.TrimSpace(title), strings.TrimSpace(description), strings.Join(sorted, ","))
+}
+
+// pullHash fingerprints the fields that flow Beads → VTS (status in Beads vocabulary).
+func pullHash(status, assignee string) string {
+	return fieldHash(strings.ToLower(strings.TrimSpace(status)), strings.TrimSpace(assignee))
+}
+
+func fieldHash(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SyncBack reconciles VTS files with their existing beads:
+// Beads status/assignee are pulled into VTS status/owner, and changed VTS
+// titles, descriptions, and dependencies are pushed to the beads. A field
+// group changed on both sides since the last sync is reported as a conflict
+// and left untouched. Dry-run unless opts.Apply is set.
+func SyncBack(opts ImportOptions, runner BrRunner) (*SyncResult, error) {
+	result := &SyncResult{}
+
+	tasks, err := vts.ReadDir(opts.VTSDir)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	fmt.Printf("Parsed %d VTS tasks from %s\n", len(tasks), opts.VTSDir)
+
+	report := Preflight(tasks)
+	if !report.OK() {
+		fmt.Print(report.String())
+		return nil, fmt.Errorf("preflight failed with %d errors", len(report.Errors))
+	}
+
+	specs, normErrs := Normalize(tasks)
+	for _, e := range normErrs {
+		fmt.Printf("  WARN: %s\n", e)
+	}
+
+	idMap, err := LoadIDMap(opts.VTSDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w (run tobeads --apply first)", err)
+	}
+	state, err := LoadSyncState(opts.VTSDir)
+	if err != nil {
+		return nil, err
+	}
+
+	beadToVTS := map[string]string{}
+	for vtsID, beadID := range idMap {
+		beadToVTS[beadID] = vtsID
+	}
+	paths := map[string]string{}
+	for _, t := range tasks {
+		paths[t.ID] = t.Path
+	}
+
+	if !opts.Apply {
+		fmt.Println("\n=== SYNC-BACK DRY RUN ===")
+	} else {
+		fmt.Println("\n=== SYNC-BACK ===")
+	}
+
+	for _, spec := range specs {
+		beadID, ok := idMap[spec.ExternalRef]
+		if !ok {
+			result.Notes = append(result.Notes, fmt.Sprintf("%s: not in %s (run tobeads --apply to create)", spec.ExternalRef, mapFileName))
+			continue
+		}
+
+		bead, err := runner.Show(beadID)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("show %s: %v", beadID, err))
+			fmt.Printf("  FAIL: %s → %s — %v\n", spec.ExternalRef, beadID, err)
+			continue
+		}
+
+		// Bead deps in VTS terms; edges to beads outside this VTS set are left alone
+		var beadDeps []string
+		for _, d := range bead.Dependencies {
+			if v, ok := beadToVTS[d]; ok {
+				beadDeps = append(beadDeps, v)
+			}
+		}
+
+		// VTS deps without a bead yet can't be pushed; leave them until they're imported
+		var vtsDeps []string
+		for _, d := range spec.Dependencies {
+			if _, ok := idMap[d]; !ok {
+				result.Notes = append(result.Notes, fmt.Sprintf("%s: dependency %s not pushed, target not in map (import it first)", spec.ExternalRef, d))
+				continue
+			}
+			vtsDeps = append(vtsDeps, d)
+		}
+
+		entry, hasEntry := state.Tasks[spec.ExternalRef]
+		if !hasEntry {
+			// No recorded baseline (imported before sync-back existed): treat the VTS file as last-synced
+			entry = baselineEntry(beadID, spec, vtsDeps)
+		}
+		entry.BeadID = beadID
+
+		vtsPush := pushHash(spec.Title, spec.Description, vtsDeps)
+		beadPush := pushHash(bead.Title, bead.Description, beadDeps)
+		vtsPull := pullHash(spec.Status, spec.Assignee)
+		beadPull := pullHash(bead.Status, bead.Assignee)
+		if vtsPush == beadPush && vtsPull == beadPull {
+			result.InSync++
+		}
+
+		// Push: title, description, dependencies (VTS → Beads)
+		switch {
+		case vtsPush == beadPush:
+			entry.PushHash = vtsPush
+		case vtsPush != entry.PushHash && beadPush != entry.PushHash:
+			result.Conflicts = append(result.Conflicts, fmt.Sprintf("%s ↔ %s: title/description/dependencies changed in both VTS and Beads", spec.ExternalRef, beadID))
+		case vtsPush != entry.PushHash:
+			added, removed := diffDeps(vtsDeps, beadDeps)
+			fmt.Printf("  PUSH: %s → %s (%s)\n", spec.ExternalRef, beadID, describePush(spec, bead, added, removed))
+			if opts.Apply {
+				if err := pushChanges(runner, beadID, spec, bead, added, removed, idMap); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("push %s: %v", spec.ExternalRef, err))
+					fmt.Printf("  FAIL: %s — %v\n", spec.ExternalRef, err)
+					break
+				}
+				entry.PushHash = vtsPush
+			}
+			result.Pushed++
+		default:
+			result.Notes = append(result.Notes, fmt.Sprintf("%s: title/description/dependencies edited in Beads (%s); VTS unchanged, not pulled", spec.ExternalRef, beadID))
+		}
+
+		// Pull: status, assignee (Beads → VTS)
+		switch {
+		case vtsPull == beadPull:
+			entry.PullHash = beadPull
+		case vtsPull != entry.PullHash && beadPull != entry.PullHash:
+			result.Conflicts = append(result.Conflicts, fmt.Sprintf("%s ↔ %s: status/owner changed in both VTS and Beads", spec.ExternalRef, beadID))
+		case beadPull != entry.PullHash:
+			vtsStatus, ok := ReverseStatusMap[strings.ToLower(bead.Status)]
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("pull %s: unknown Beads status %q", spec.ExternalRef, bead.Status))
+				break
+			}
+			fmt.Printf("  PULL: %s ← %s (status %s, owner %q)\n", spec.ExternalRef, beadID, vtsStatus, bead.Assignee)
+			if opts.Apply {
+				err := vts.UpdateFrontmatter(paths[spec.ExternalRef], map[string]string{
+					"status": vtsStatus,
+					"owner":  bead.Assignee,
+				})
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("pull %s: %v", spec.ExternalRef, err))
+					fmt.Printf("  FAIL: %s — %v\n", spec.ExternalRef, err)
+					break
+				}
+				entry.PullHash = beadPull
+			}
+			result.Pulled++
+		default:
+			result.Notes = append(result.Notes, fmt.Sprintf("%s: status/owner edited in VTS; Beads (%s) is authoritative, not pushed", spec.ExternalRef, beadID))
+		}
+
+		if opts.Apply {
+			entry.SyncedAt = time.Now().UTC().Format(time.RFC3339)
+			state.Tasks[spec.ExternalRef] = entry
+		}
+	}
+
+	if opts.Apply {
+		if err := state.Save(opts.VTSDir); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+		if opts.Sync {
+			fmt.Println("Running br sync --flush-only...")
+			if err := runner.Sync(); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("sync: %v", err))
+			}
+		}
+	}
+
+	printSyncSummary(result, opts.Apply)
+	return result, nil
+}
+
+// pushChanges sends title/description and dependency edits for one bead.
+func pushChanges(runner BrRunner, beadID string, spec BeadSpec, bead *BeadState, added, removed []string, idMap map[string]string) error {
+	if strings.TrimSpace(spec.Title) != strings.TrimSpace(bead.Title) ||
+		strings.TrimSpace(spec.Description) != strings.TrimSpace(bead.Description) {
+		if err := runner.Update(beadID, spec); err != nil {
+			return err
+		}
+	}
+	for _, dep := range added {
+		if err := runner.DepAdd(beadID, idMap[dep]); err != nil {
+			return err
+		}
+	}
+	for _, dep := range removed {
+		if err := runner.DepRemove(beadID, idMap[dep]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffDeps returns VTS IDs present in want but not have, and in have but not want.
+func diffDeps(want, have []string) (added, removed []string) {
+	haveSet := map[string]bool{}
+	for _, d := range have {
+		haveSet[d] = true
+	}
+	wantSet := map[string]bool{}
+	for _, d := range want {
+		wantSet[d] = true
+		if !haveSet[d] {
+			added = append(added, d)
+		}
+	}
+	for _, d := range have {
+		if !wantSet[d] {
+			removed = append(removed, d)
+		}
+	}
+	return added, removed
+}
+
+func describePush(spec BeadSpec, bead *BeadState, added, removed []string) string {
+	var parts []string
+	if strings.TrimSpace(spec.Title) != strings.TrimSpace(bead.Title) {
+		parts = append(parts, "title")
+	}
+	if strings.TrimSpace(spec.Description) != strings.TrimSpace(bead.Description) {
+		parts = append(parts, "description")
+	}
+	for _, d := range added {
+		parts = append(parts, "+dep "+d)
+	}
+	for _, d := range removed {
+		parts = append(parts, "-dep "+d)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printSyncSummary(r *SyncResult, applied bool) {
+	fmt.Println("\n=== SYNC-BACK SUMMARY ===")
+	verb := "Would push"
+	pullVerb := "Would pull"
+	if applied {
+		verb, pullVerb = "Pushed", "Pulled"
+	}
+	fmt.Printf("%s: %d\n", verb, r.Pushed)
+	fmt.Printf("%s: %d\n", pullVerb, r.Pulled)
+	fmt.Printf("In sync: %d\n", r.InSync)
+	fmt.Printf("Conflicts: %d\n", len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		fmt.Printf("  - %s\n", c)
+	}
+	if len(r.Notes) > 0 {
+		fmt.Println("Notes:")
+		for _, n := range r.Notes {
+			fmt.Printf("  - %s\n", n)
+		}
+	}
+	if len(r.Errors) > 0 {
+		fmt.Println("Errors:")
+		for _, e := range r.Errors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+	if !applied {
+		fmt.Println("Run with --apply to execute.")
+	}
+}
@@ -0,0 +1,223 @@
+package tobeads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdonohoo/vern-bot/go/internal/vts"
+)
+
+func writeTask(t *testing.T, dir, id, title, status string, deps ...string) {
+	t.Helper()
+	depBlock := "dependencies: []"
+	if len(deps) > 0 {
+		depBlock = "dependencies:\n  - " + strings.Join(deps, "\n  - ")
+	}
+	content := fmt.Sprintf(`---
+id: %s
+title: %q
+complexity: S
+status: %s
+owner: ""
+source: discovery
+source_ref: "architect-breakdown.md"
+%s
+files: []
+---
+
+# %s
+
+Body for %s.
+`, id, title, status, depBlock, title, id)
+	path := filepath.Join(dir, strings.ToLower(id)+".md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// importFixture writes three tasks and imports them into a fresh mock.
+func importFixture(t *testing.T) (string, *MockBrRunner) {
+	t.Helper()
+	dir := t.TempDir()
+	writeTask(t, dir, "VTS-001", "Setup", "pending")
+	writeTask(t, dir, "VTS-002", "Parser", "pending", "VTS-001")
+	writeTask(t, dir, "VTS-003", "Executor", "pending", "VTS-002")
+
+	mock := NewMockBrRunner()
+	if _, err := Run(ImportOptions{VTSDir: dir, Apply: true}, mock); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	return dir, mock
+}
+
+func TestRun_WritesSyncBaseline(t *testing.T) {
+	dir, _ := importFixture(t)
+	state, err := LoadSyncState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Tasks) != 3 {
+		t.Fatalf("baseline entries = %d, want 3", len(state.Tasks))
+	}
+	if state.Tasks["VTS-002"].BeadID != "BR-002" {
+		t.Errorf("VTS-002 bead = %q, want BR-002", state.Tasks["VTS-002"].BeadID)
+	}
+}
+
+func TestSyncBack_NoChanges(t *testing.T) {
+	dir, mock := importFixture(t)
+	result, err := SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.InSync != 3 || result.Pushed != 0 || result.Pulled != 0 {
+		t.Errorf("result = %+v, want 3 in sync", result)
+	}
+}
+
+func TestSyncBack_PullStatusAndOwner(t *testing.T) {
+	dir, mock := importFixture(t)
+	mock.Beads["BR-001"].Status = "closed"
+	mock.Beads["BR-001"].Assignee = "alice"
+
+	result, err := SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pulled != 1 {
+		t.Fatalf("pulled = %d, want 1", result.Pulled)
+	}
+	task, err := vts.ReadFile(filepath.Join(dir, "vts-001.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != "done" || task.Owner != "alice" {
+		t.Errorf("task status/owner = %q/%q, want done/alice", task.Status, task.Owner)
+	}
+
+	// Second run is a no-op
+	result, _ = SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if result.Pulled != 0 || result.InSync != 3 {
+		t.Errorf("second run = %+v, want all in sync", result)
+	}
+}
+
+func TestSyncBack_PushTitleAndDeps(t *testing.T) {
+	dir, mock := importFixture(t)
+	// Retitle VTS-003 and rewire it to depend on VTS-001 instead of VTS-002
+	writeTask(t, dir, "VTS-003", "Executor v2", "pending", "VTS-001")
+
+	result, err := SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("pushed = %d, want 1", result.Pushed)
+	}
+	if mock.Beads["BR-003"].Title != "Executor v2" {
+		t.Errorf("bead title = %q", mock.Beads["BR-003"].Title)
+	}
+	deps := mock.Beads["BR-003"].Dependencies
+	if len(deps) != 1 || deps[0] != "BR-001" {
+		t.Errorf("bead deps = %v, want [BR-001]", deps)
+	}
+	if len(mock.RemovedDeps) != 1 || mock.RemovedDeps[0] != [2]string{"BR-003", "BR-002"} {
+		t.Errorf("removed deps = %v", mock.RemovedDeps)
+	}
+}
+
+func TestSyncBack_UnmappedDependency(t *testing.T) {
+	dir, mock := importFixture(t)
+	// VTS-004 is new since the import, so it has no bead yet
+	writeTask(t, dir, "VTS-004", "Reporter", "pending")
+	writeTask(t, dir, "VTS-003", "Executor", "pending", "VTS-002", "VTS-004")
+	deps := len(mock.Deps)
+
+	for run := 1; run <= 2; run++ {
+		result, err := SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Pushed != 0 || len(result.Errors) != 0 || result.InSync != 3 {
+			t.Errorf("run %d: result = %+v, want 3 in sync and nothing pushed", run, result)
+		}
+		if !strings.Contains(strings.Join(result.Notes, "\n"), "VTS-003: dependency VTS-004 not pushed") {
+			t.Errorf("run %d: notes = %v, want the unmapped dependency noted", run, result.Notes)
+		}
+	}
+	if len(mock.Deps) != deps {
+		t.Errorf("dep adds = %v, want none for an unmapped target", mock.Deps[deps:])
+	}
+}
+
+func TestSyncBack_Conflict(t *testing.T) {
+	dir, mock := importFixture(t)
+	writeTask(t, dir, "VTS-002", "Parser (VTS edit)", "pending", "VTS-001")
+	mock.Beads["BR-002"].Title = "Parser (Beads edit)"
+
+	result, err := SyncBack(ImportOptions{VTSDir: dir, Apply: true}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || !strings.Contains(result.Conflicts[0], "VTS-002") {
+		t.Fatalf("conflicts = %v, want one for VTS-002", result.Conflicts)
+	}
+	if len(mock.Updated) != 0 {
+		t.Errorf("conflicting bead should not be updated, got %v", mock.Updated)
+	}
+}
+
+func TestSyncBack_DryRun(t *testing.T) {
+	dir, mock := importFixture(t)
+	mock.Beads["BR-001"].Status = "in_progress"
+	writeTask(t, dir, "VTS-002", "Parser renamed", "pending", "VTS-001")
+
+	result, err := SyncBack(ImportOptions{VTSDir: dir}, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pushed != 1 || result.Pulled != 1 {
+		t.Errorf("plan = %+v, want 1 push + 1 pull", result)
+	}
+	if len(mock.Updated) != 0 {
+		t.Error("dry-run should not update beads")
+	}
+	task, _ := vts.ReadFile(filepath.Join(dir, "vts-001.md"))
+	if task.Status != "pending" {
+		t.Errorf("dry-run should not touch VTS, status = %q", task.Status)
+	}
+}
+
+func TestSyncBack_MissingMap(t *testing.T) {
+	dir := t.TempDir()
+	writeTask(t, dir, "VTS-001", "Setup", "pending")
+	_, err := SyncBack(ImportOptions{VTSDir: dir}, NewMockBrRunner())
+	if err == nil || !strings.Contains(err.Error(), "tobeads --apply") {
+		t.Fatalf("expected missing map error, got %v", err)
+	}
+}
+
+func TestParseShowJSON(t *testing.T) {
+	out := `{"id": "bd-7", "title": "T", "status": "closed", "assignee": "bob",
+		"dependencies": [{"issue_id": "bd-7", "depends_on_id": "bd-3", "type": "blocks"}, "bd-4"]}`
+	state, err := parseShowJSON(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ID != "bd-7" || state.Assignee != "bob" {
+		t.Errorf("state = %+v", state)
+	}
+	if len(state.Dependencies) != 2 || state.Dependencies[0] != "bd-3" || state.Dependencies[1] != "bd-4" {
+		t.Errorf("deps = %v", state.Dependencies)
+	}
+
+	if _, err := parseShowJSON(`[{"id": "bd-1"}]`); err != nil {
+		t.Errorf("array form: %v", err)
+	}
+	if _, err := parseShowJSON(`{"title": "no id"}`); err == nil {
+		t.Error("expected error for missing id")
+	}
+}
@@ -170,7 +170,10 @@ func (a App) activeKeyMap() help.KeyMap {
 		case holeStateRunning:
 			return runningKeys
 		case holeStateDone:
-			return runDoneKeys
+			keys := holeDoneKeys
+			keys.Ask.SetEnabled(a.hole.canAsk())
+			keys.Retry.SetEnabled(a.hole.vernsFailed > 0)
+			return keys
 		default:
 			return formKeys
 		}
@@ -199,6 +202,8 @@ func (a App) activeKeyMap() help.KeyMap {
 		switch a.generate.state {
 		case genStateRunning:
 			return runningKeys
+		case genStatePreview:
+			return genPreviewKeys
 		case genStateDone:
 			if a.generate.err != nil {
 				return runRetryKeys
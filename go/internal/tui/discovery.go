@@ -22,7 +22,7 @@ import (
 // vernStatus tracks an individual Vern's async state in the VernHole phase.
 type vernStatus struct {
 	num    int    // 1-based index
-	desc   string // display name from log line (e.g. "Ketamine Vern")
+	desc   string // display name (e.g. "Ketamine Vern")
 	llm    string // e.g. "claude"
 	status string // "summoned", "ok", "failed"
 }
@@ -55,7 +55,7 @@ type discoveryVals struct {
 	oracleApply  string
 	confirm      bool
 	cancel       context.CancelFunc
-	logCh        chan string
+	events       chan pipeline.Event
 }
 
 // projectInfo describes an existing discovery project for the rerun selector.
@@ -88,7 +88,7 @@ type DiscoveryModel struct {
 	// Execution state
 	running               bool
 	stepLog               []string
-	pipelineSteps         []string // step definitions captured from "[step] N. Name → llm"
+	pipelineSteps         []string // step definitions from the pipeline banner: "N. Name → llm"
 	stepsCompleted        int
 	totalSteps            int
 	historianPhase        string // "pending", "running", "done", "failed", "skipped"
@@ -385,6 +385,7 @@ func (m *DiscoveryModel) buildSetupForm() *huh.Form {
 func (m *DiscoveryModel) buildConfigForm() *huh.Form {
 	w := contentWidth(m.width)
 	v := m.vals
+	councilOpts := withCustomCouncils(VernHoleOptions, m.projectRoot)
 	return huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
@@ -418,8 +419,8 @@ func (m *DiscoveryModel) buildConfigForm() *huh.Form {
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Run VernHole council after pipeline?").
-				Options(VernHoleOptions...).
-				Height(len(VernHoleOptions)+1).
+				Options(councilOpts...).
+				Height(len(councilOpts)+1).
 				Value(&v.vernhole),
 		),
 		huh.NewGroup(
@@ -487,95 +488,9 @@ func (m DiscoveryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.initDoneViewport()
 		return m, tea.Batch(tea.DisableMouse, celebCmd)
 
-	case pipelineLogMsg:
-		line := msg.line
-
-		// Capture step list lines for the outline (don't show in activity log)
-		if strings.HasPrefix(line, "[step] ") {
-			m.pipelineSteps = append(m.pipelineSteps, strings.TrimPrefix(line, "[step] "))
-			return m, m.waitForLog()
-		}
-
-		upper := strings.ToUpper(line)
-
-		// Detect phase transitions (before filtering, so state updates even for filtered lines)
-		if strings.Contains(upper, "ENTERING THE VERNHOLE") {
-			m.runningPhase = "vernhole"
-			m.phaseLogStart = len(m.stepLog)
-		} else if strings.Contains(upper, "CONSULTING THE ORACLE") {
-			// VernHole just finished — fire ephemeral celebration
-			m.celebration.StartEphemeral("vernhole", m.width)
-			m.runningPhase = "oracle"
-			m.phaseLogStart = len(m.stepLog)
-			m.oracleStep = "consult"
-		} else if strings.Contains(upper, "ORACLE APPLYING VISION") {
-			m.oracleStep = "apply"
-		}
-
-		// Filter banner/noise lines (after phase detection)
-		if isFilteredLogLine(line) {
-			return m, m.waitForLog()
-		}
-
-		// Pipeline phase: track historian and step progress
-		if m.runningPhase == "pipeline" {
-			if strings.Contains(upper, "INVOKING THE ANCIENT SECRETS OF THE HISTORIAN") {
-				m.historianPhase = "running"
-			} else if strings.Contains(upper, "HISTORIAN COMPLETE") || strings.Contains(upper, "HISTORIAN INDEX ALREADY EXISTS") {
-				m.historianPhase = "done"
-				m.celebration.StartEphemeral("historian", m.width)
-			} else if strings.Contains(upper, "HISTORIAN: PROMPT ONLY") || strings.Contains(upper, "NOTHING TO INDEX") {
-				m.historianPhase = "done"
-			} else if strings.Contains(upper, "HISTORIAN FAILED") {
-				m.historianPhase = "failed"
-			}
-
-			if strings.HasPrefix(line, ">>> Pass ") {
-				rest := strings.TrimPrefix(line, ">>> Pass ")
-				if idx := strings.Index(rest, "/"); idx > 0 {
-					var n int
-					fmt.Sscanf(rest[:idx], "%d", &n)
-					if n > 0 {
-						m.currentStep = n
-					}
-				}
-			}
-		}
-
-		// VernHole phase: track Vern personas (async — all run in parallel)
-		if m.runningPhase == "vernhole" {
-			if strings.HasPrefix(line, ">>> Vern ") {
-				// Parse ">>> Vern N/Total: Description (llm)"
-				rest := strings.TrimPrefix(line, ">>> Vern ")
-				if num, total, desc, llmName, ok := parseVernLine(rest); ok {
-					if m.vernRoster == nil {
-						m.vernRoster = make(map[int]*vernStatus)
-					}
-					m.totalVerns = total
-					m.vernRoster[num] = &vernStatus{
-						num:    num,
-						desc:   desc,
-						llm:    llmName,
-						status: "summoned",
-					}
-				}
-			} else if strings.Contains(upper, "VERN ") && (strings.Contains(upper, "OK (") || strings.Contains(upper, "FAILED (")) {
-				// Parse "    OK (llm, NB, Vern N/Total)" or "    FAILED (id, exit N, Vern N/Total)"
-				if num := parseVernResultLine(line); num > 0 {
-					if vs, ok := m.vernRoster[num]; ok {
-						if strings.Contains(upper, "OK (") {
-							vs.status = "ok"
-						} else {
-							vs.status = "failed"
-						}
-					}
-				}
-			}
-		}
-
-		m.stepLog = append(m.stepLog, line)
-		m.updateProgress(line)
-		return m, m.waitForLog()
+	case pipelineEventMsg:
+		m.handleEvent(msg.ev)
+		return m, m.waitForEvent()
 
 	case discStatusClearMsg:
 		m.statusMsg = ""
@@ -712,8 +627,8 @@ func (m DiscoveryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historianPhase = "skipped"
 			}
 			m.runningPhase = "pipeline"
-			m.vals.logCh = make(chan string, 100)
-			return m, tea.Batch(m.spinner.Tick, m.startPipeline(), m.waitForLog())
+			m.vals.events = make(chan pipeline.Event, 100)
+			return m, tea.Batch(m.spinner.Tick, m.startPipeline(), m.waitForEvent())
 		}
 		if m.configForm.State == huh.StateAborted {
 			return m, backToMenu
@@ -754,12 +669,60 @@ func (m DiscoveryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *DiscoveryModel) updateProgress(line string) {
-	upper := strings.ToUpper(line)
+// handleEvent updates the phase panels and progress from a pipeline event
+// and appends its text to the activity log.
+func (m *DiscoveryModel) handleEvent(ev pipeline.Event) {
+	switch d := ev.Data.(type) {
+	case pipeline.BannerData:
+		// The header already shows folder/mode/LLM; keep only the step outline
+		m.pipelineSteps = nil
+		for _, s := range d.Steps {
+			m.pipelineSteps = append(m.pipelineSteps, fmt.Sprintf("%d. %s → %s", s.Step, s.Name, s.LLM))
+		}
+		return
+
+	case pipeline.PhaseData:
+		if d.Phase == pipeline.PhaseHistorian {
+			m.stepsCompleted++
+			switch d.Status {
+			case "ok":
+				m.historianPhase = "done"
+				m.celebration.StartEphemeral("historian", m.width)
+			case "skipped":
+				m.historianPhase = "done"
+			default:
+				m.historianPhase = "failed"
+			}
+		}
 
-	switch m.runningPhase {
-	case "vernhole":
-		// Count completed Verns from roster (handles async completion)
+	case pipeline.StepStartData:
+		m.currentStep = d.StepNum
+
+	case pipeline.StepResult:
+		if d.Status == "ok" || d.Status == "skipped" {
+			m.stepsCompleted++
+			m.completedPipelineStep = d.StepNum
+		}
+
+	case pipeline.VernStartData:
+		if m.vernRoster == nil {
+			m.vernRoster = make(map[int]*vernStatus)
+		}
+		m.totalVerns = d.Total
+		m.vernRoster[d.Index+1] = &vernStatus{
+			num:    d.Index + 1,
+			desc:   d.Vern.Name,
+			llm:    d.LLM,
+			status: "summoned",
+		}
+
+	case pipeline.VernCompleteData:
+		if vs, ok := m.vernRoster[d.Result.Index+1]; ok {
+			vs.status = "failed"
+			if d.Result.Succeeded {
+				vs.status = "ok"
+			}
+		}
 		completed := 0
 		for _, vs := range m.vernRoster {
 			if vs.status == "ok" || vs.status == "failed" {
@@ -767,18 +730,29 @@ func (m *DiscoveryModel) updateProgress(line string) {
 			}
 		}
 		m.vernsCompleted = completed
-	case "oracle":
-		// Oracle has no granular progress
-	default: // pipeline
-		if strings.Contains(upper, "OK (") || strings.Contains(upper, "FALLBACK SUCCEEDED") {
-			m.stepsCompleted++
-			m.completedPipelineStep = m.currentStep
-		} else if strings.Contains(upper, "HISTORIAN COMPLETE") ||
-			strings.Contains(upper, "HISTORIAN INDEX ALREADY EXISTS") ||
-			strings.Contains(upper, "HISTORIAN: PROMPT ONLY") ||
-			strings.Contains(upper, "NOTHING TO INDEX") ||
-			strings.Contains(upper, "HISTORIAN FAILED") {
-			m.stepsCompleted++
+	}
+
+	if ev.Type == pipeline.EventPhaseStart {
+		switch ev.Data.(string) {
+		case pipeline.PhaseHistorian:
+			m.historianPhase = "running"
+		case pipeline.PhaseVernHole:
+			m.runningPhase = "vernhole"
+			m.phaseLogStart = len(m.stepLog)
+		case pipeline.PhaseOracle:
+			// VernHole just finished — fire ephemeral celebration
+			m.celebration.StartEphemeral("vernhole", m.width)
+			m.runningPhase = "oracle"
+			m.phaseLogStart = len(m.stepLog)
+			m.oracleStep = "consult"
+		case pipeline.PhaseOracleApply:
+			m.oracleStep = "apply"
+		}
+	}
+
+	for _, line := range eventLogLines(ev) {
+		if !isFilteredLogLine(line) {
+			m.stepLog = append(m.stepLog, line)
 		}
 	}
 }
@@ -934,8 +908,8 @@ type pipelineDoneMsg struct {
 	err     error
 }
 
-type pipelineLogMsg struct {
-	line string
+type pipelineEventMsg struct {
+	ev pipeline.Event
 }
 
 type discStatusClearMsg struct{}
@@ -943,7 +917,7 @@ type discStatusClearMsg struct{}
 func (m DiscoveryModel) startPipeline() tea.Cmd {
 	return func() tea.Msg {
 		v := m.vals
-		defer close(v.logCh)
+		defer close(v.events)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		v.cancel = cancel
@@ -968,12 +942,7 @@ func (m DiscoveryModel) startPipeline() tea.Cmd {
 			ProjectRoot:   m.projectRoot,
 			LLMMode:       v.llmMode,
 			SingleLLM:     singleLLM,
-			OnLog: func(line string) {
-				select {
-				case v.logCh <- line:
-				default:
-				}
-			},
+			Events:        pipeline.NewChannelHandler(v.events, ctx.Done()),
 		}
 
 		if v.vernhole != "" {
@@ -989,17 +958,10 @@ func (m DiscoveryModel) startPipeline() tea.Cmd {
 	}
 }
 
-func (m DiscoveryModel) waitForLog() tea.Cmd {
-	return func() tea.Msg {
-		if m.vals.logCh == nil {
-			return nil
-		}
-		line, ok := <-m.vals.logCh
-		if !ok {
-			return nil
-		}
-		return pipelineLogMsg{line: line}
-	}
+func (m DiscoveryModel) waitForEvent() tea.Cmd {
+	return waitForEvent(m.vals.events, func(ev pipeline.Event) tea.Msg {
+		return pipelineEventMsg{ev: ev}
+	})
 }
 
 func (m *DiscoveryModel) readStatus() {
@@ -1109,53 +1071,6 @@ func (m DiscoveryModel) renderOracleOutlinePanel(width, height int) string {
 	)
 }
 
-// parseVernLine parses "N/Total: Description (llm)" from a ">>> Vern " line.
-func parseVernLine(rest string) (num, total int, desc, llmName string, ok bool) {
-	// Format: "3/15: Needs 6 meetings and a committee first (claude)"
-	slashIdx := strings.Index(rest, "/")
-	if slashIdx < 1 {
-		return
-	}
-	colonIdx := strings.Index(rest, ":")
-	if colonIdx < slashIdx {
-		return
-	}
-	fmt.Sscanf(rest[:slashIdx], "%d", &num)
-	fmt.Sscanf(rest[slashIdx+1:colonIdx], "%d", &total)
-	if num == 0 || total == 0 {
-		return
-	}
-
-	afterColon := strings.TrimSpace(rest[colonIdx+1:])
-	// Extract LLM from trailing "(llm)"
-	if parenIdx := strings.LastIndex(afterColon, "("); parenIdx > 0 {
-		desc = strings.TrimSpace(afterColon[:parenIdx])
-		llmName = strings.TrimSuffix(strings.TrimSpace(afterColon[parenIdx+1:]), ")")
-	} else {
-		desc = afterColon
-	}
-	ok = true
-	return
-}
-
-// parseVernResultLine extracts the Vern number from an OK or FAILED line.
-// Format: "    OK (llm, NB, Vern N/Total)" or "    FAILED (id, exit N, Vern N/Total)"
-func parseVernResultLine(line string) int {
-	// Look for "Vern N/" pattern
-	idx := strings.Index(line, "Vern ")
-	if idx < 0 {
-		return 0
-	}
-	rest := line[idx+5:] // after "Vern "
-	slashIdx := strings.Index(rest, "/")
-	if slashIdx < 1 {
-		return 0
-	}
-	var num int
-	fmt.Sscanf(rest[:slashIdx], "%d", &num)
-	return num
-}
-
 // Cancel aborts any running pipeline goroutine.
 func (m DiscoveryModel) Cancel() {
 	if m.vals != nil && m.vals.cancel != nil {
@@ -1,45 +1,49 @@
 package tui
 
-import "testing"
+import (
+	"testing"
 
-func TestDiscoveryUpdateProgress(t *testing.T) {
+	"github.com/jdonohoo/vern-bot/go/internal/pipeline"
+)
+
+func TestDiscoveryHandleEvent(t *testing.T) {
 	m := NewDiscoveryModel("/tmp", "/tmp/agents")
 	m.totalSteps = 5
 
+	h := pipeline.FuncHandler(m.handleEvent)
 	tests := []struct {
-		line            string
+		name            string
+		emit            func()
 		wantCompleted   int
 		wantProgressPct float64
 	}{
-		{">>> Running step 1: Architect", 0, 0.0},
-		{"    OK (2.3s)", 1, 0.2},
-		{">>> Running step 2: Engineer", 1, 0.2},
-		{"    FAILED: timeout", 1, 0.2},
-		{"    RETRY 1/3...", 1, 0.2},
-		{"    FALLBACK SUCCEEDED (3.1s)", 2, 0.4},
-		{">>> Running step 3: Validator", 2, 0.4},
-		{"    OK (1.8s)", 3, 0.6},
-		{">>> Running step 4: Consolidator", 3, 0.6},
-		{"    OK (2.1s)", 4, 0.8},
-		{">>> Running step 5: Reviewer", 4, 0.8},
-		{"    OK (1.5s)", 5, 1.0},
-		{">>> VernHole starting", 5, 1.0},
-		{"    OK (5.0s)", 6, 1.0}, // overflow capped at 1.0
+		{"step 1 start", func() { h.OnStepStart(pipeline.StepStartData{StepNum: 1, Total: 5, Name: "Architect"}) }, 0, 0.0},
+		{"step 1 ok", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 1, Status: "ok"}) }, 1, 0.2},
+		{"step 2 start", func() { h.OnStepStart(pipeline.StepStartData{StepNum: 2, Total: 5, Name: "Engineer"}) }, 1, 0.2},
+		{"step 2 retry", func() { h.OnStepRetry(pipeline.StepRetryData{StepNum: 2, Attempt: 1, MaxRetries: 3}) }, 1, 0.2},
+		{"step 2 fallback", func() { h.OnStepFallback(pipeline.StepFallbackData{StepNum: 2, From: "codex", To: "claude"}) }, 1, 0.2},
+		{"step 2 ok after fallback", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 2, Status: "ok", FellBack: true}) }, 2, 0.4},
+		{"step 3 skipped", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 3, Status: "skipped"}) }, 3, 0.6},
+		{"step 4 failed", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 4, Status: "failed"}) }, 3, 0.6},
+		{"step 5 ok", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 5, Status: "ok"}) }, 4, 0.8},
+		{"log line", func() { h.OnLog("    OK (claude, 12 bytes, 1s)") }, 4, 0.8},
+		{"historian done", func() { h.OnPhaseComplete(pipeline.PhaseHistorian, "ok") }, 5, 1.0},
+		{"extra step", func() { h.OnStepComplete(pipeline.StepResult{StepNum: 6, Status: "ok"}) }, 6, 1.0}, // overflow capped at 1.0
 	}
 
 	for _, tt := range tests {
-		m.updateProgress(tt.line)
+		tt.emit()
 		if m.stepsCompleted != tt.wantCompleted {
-			t.Errorf("after line %q: stepsCompleted = %d, want %d",
-				tt.line, m.stepsCompleted, tt.wantCompleted)
+			t.Errorf("after %s: stepsCompleted = %d, want %d",
+				tt.name, m.stepsCompleted, tt.wantCompleted)
 		}
 		pct := float64(m.stepsCompleted) / float64(m.totalSteps)
 		if pct > 1 {
 			pct = 1
 		}
 		if pct != tt.wantProgressPct {
-			t.Errorf("after line %q: progress percent = %.1f, want %.1f",
-				tt.line, pct, tt.wantProgressPct)
+			t.Errorf("after %s: progress percent = %.1f, want %.1f",
+				tt.name, pct, tt.wantProgressPct)
 		}
 	}
 }
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jdonohoo/vern-bot/go/internal/pipeline"
+)
+
+// eventLogLines returns the activity-log lines for a pipeline event: the text
+// the CLI would print, trimmed, without blank lines.
+func eventLogLines(ev pipeline.Event) []string {
+	var lines []string
+	for _, line := range pipeline.EventLines(ev) {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			lines = append(lines, trimmed)
+		}
+	}
+	return lines
+}
+
+// waitForEvent reads the next event from ch and wraps it in the screen's
+// message type. It returns nil once ch is closed.
+func waitForEvent(ch chan pipeline.Event, wrap func(pipeline.Event) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		if ch == nil {
+			return nil
+		}
+		ev, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return wrap(ev)
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -10,6 +11,7 @@ import (
 	"github.com/charmbracelet/huh"
 
 	"github.com/jdonohoo/vern-bot/go/internal/generate"
+	"github.com/jdonohoo/vern-bot/go/internal/persona"
 )
 
 type genState int
@@ -17,6 +19,8 @@ type genState int
 const (
 	genStateForm genState = iota
 	genStateRunning
+	genStatePreview  // reviewing a draft before anything is written
+	genStateFeedback // feedback form for the next revision
 	genStateDone
 )
 
@@ -27,6 +31,7 @@ type genVals struct {
 	description string
 	model       string
 	color       string
+	feedback    string
 	logCh       chan string
 }
 
@@ -39,6 +44,7 @@ type GenerateModel struct {
 	width       int
 	height      int
 	projectRoot string
+	repoRoot    string // projectRoot if it's a vern-bot checkout; empty writes to the user persona dir
 	agentsDir   string
 	vals        *genVals
 
@@ -46,6 +52,14 @@ type GenerateModel struct {
 	stepLog []string
 	err     error
 	summary string
+
+	// Refine loop: drafts are previewed and revised until accepted
+	draft        *generate.GeneratedPersona
+	draftErr     error // a failed revision; the previous draft stays up
+	revision     int
+	revising     bool // the running draft revises the previous one
+	writing      bool
+	feedbackForm *huh.Form
 }
 
 // ModelOptions are the model options for persona generation (determines which LLM runs the persona).
@@ -89,6 +103,9 @@ func NewGenerateModel(projectRoot, agentsDir string) GenerateModel {
 		agentsDir:   agentsDir,
 		vals:        vals,
 	}
+	if generate.IsRepoRoot(projectRoot) {
+		m.repoRoot = projectRoot
+	}
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -125,7 +142,7 @@ func (m *GenerateModel) buildForm() *huh.Form {
 				Placeholder("nihilist").
 				Value(&v.name).
 				Validate(func(s string) error {
-					return generate.ValidateName(s, m.projectRoot)
+					return generate.ValidateName(s, m.repoRoot)
 				}),
 		),
 		huh.NewGroup(
@@ -168,6 +185,11 @@ type genDoneMsg struct {
 	err     error
 }
 
+type genDraftMsg struct {
+	gen *generate.GeneratedPersona
+	err error
+}
+
 type genLogMsg struct {
 	line string
 }
@@ -176,12 +198,34 @@ func (m GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "esc" && !m.running {
-			if m.state == genStateDone {
-				return m, backToMenu
+			if m.state == genStateFeedback {
+				m.state = genStatePreview
+				return m, tea.DisableMouse
 			}
 			return m, backToMenu
 		}
 
+	case genDraftMsg:
+		m.running = false
+		if msg.err != nil && m.draft != nil {
+			m.state = genStatePreview
+			m.draftErr = msg.err
+			m.initPreviewViewport()
+			return m, tea.DisableMouse
+		}
+		if msg.err != nil {
+			m.state = genStateDone
+			m.err = msg.err
+			m.initDoneViewport()
+			return m, tea.DisableMouse
+		}
+		m.state = genStatePreview
+		m.draft = msg.gen
+		m.draftErr = nil
+		m.revision++
+		m.initPreviewViewport()
+		return m, tea.DisableMouse
+
 	case genDoneMsg:
 		m.state = genStateDone
 		m.running = false
@@ -202,11 +246,7 @@ func (m GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.form = f
 		}
 		if m.form.State == huh.StateCompleted {
-			m.state = genStateRunning
-			m.running = true
-			m.stepLog = nil
-			m.vals.logCh = make(chan string, 50)
-			return m, tea.Batch(m.spinner.Tick, m.startGenerate(), m.waitForLog())
+			return m, m.startDraft(nil, "")
 		}
 		if m.form.State == huh.StateAborted {
 			return m, backToMenu
@@ -218,6 +258,40 @@ func (m GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case genStatePreview:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "q":
+				return m, backToMenu
+			case "enter", "a":
+				return m, m.startWrite()
+			case "f":
+				m.state = genStateFeedback
+				m.vals.feedback = ""
+				m.feedbackForm = m.buildFeedbackForm()
+				return m, tea.Batch(m.feedbackForm.Init(), tea.EnableMouseCellMotion)
+			case "r":
+				return m, m.startDraft(nil, "")
+			}
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+
+	case genStateFeedback:
+		form, cmd := m.feedbackForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.feedbackForm = f
+		}
+		switch m.feedbackForm.State {
+		case huh.StateCompleted:
+			return m, m.startDraft(m.draft, m.vals.feedback)
+		case huh.StateAborted:
+			m.state = genStatePreview
+			return m, tea.DisableMouse
+		}
+		return m, cmd
+
 	case genStateDone:
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			switch keyMsg.String() {
@@ -229,6 +303,7 @@ func (m GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.err = nil
 					m.summary = ""
 					m.stepLog = nil
+					m.draft, m.draftErr, m.revision = nil, nil, 0
 					m.form = m.buildForm()
 					return m, tea.Batch(m.form.Init(), tea.EnableMouseCellMotion)
 				}
@@ -275,49 +350,145 @@ func (m *GenerateModel) initDoneViewport() {
 	m.viewport.SetContent(content.String())
 }
 
-func (m GenerateModel) startGenerate() tea.Cmd {
-	return func() tea.Msg {
-		v := m.vals
-		defer close(v.logCh)
+// buildFeedbackForm asks what to change in the current draft.
+func (m *GenerateModel) buildFeedbackForm() *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("What should change?").
+				Placeholder("Make the catchphrases darker, and use opus...").
+				Lines(textareaLines(m.height)).
+				CharLimit(2000).
+				Value(&m.vals.feedback).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("feedback is required")
+					}
+					return nil
+				}),
+		),
+	).WithTheme(VernTheme()).WithWidth(contentWidth(m.width)).WithHeight(formHeight(m.height))
+}
 
-		repoRoot := m.projectRoot
+func (m *GenerateModel) initPreviewViewport() {
+	cw := contentWidth(m.width)
+	vpHeight := m.height - 6
+	if vpHeight < 5 {
+		vpHeight = 5
+	}
+	m.viewport = viewport.New(cw, vpHeight)
+	m.viewport.MouseWheelEnabled = true
 
-		var summaryLines []string
+	var content strings.Builder
+	if m.draftErr != nil {
+		content.WriteString(stepFailStyle.Render("Revision failed: " + m.draftErr.Error()))
+		content.WriteString("\n")
+		content.WriteString(logDimStyle.Render("The previous draft is still below."))
+		content.WriteString("\n\n")
+	}
+	content.WriteString(logHeaderStyle.Render(fmt.Sprintf("Draft %d", m.revision)))
+	content.WriteString(" ")
+	content.WriteString(logDimStyle.Render("— nothing is written until you accept"))
+	content.WriteString("\n\n")
+
+	files := []struct{ label, body string }{
+		{m.agentLabel(), m.draft.Agent},
+		{"commands/" + m.vals.name + ".md", m.draft.Command},
+		{"skills/" + m.vals.name + "/SKILL.md", m.draft.Skill},
+	}
+	if m.repoRoot == "" {
+		files = files[:1]
+	}
+	for _, f := range files {
+		content.WriteString(logHeaderStyle.Render("=== " + f.label + " ==="))
+		content.WriteString("\n")
+		content.WriteString(strings.TrimSpace(f.body))
+		content.WriteString("\n\n")
+	}
+
+	m.viewport.SetContent(content.String())
+}
 
-		logFn := func(msg string) {
+// agentLabel names where the agent file will be written.
+func (m GenerateModel) agentLabel() string {
+	if m.repoRoot == "" {
+		return filepath.Join(persona.UserDir(), m.vals.name+".md")
+	}
+	return "agents/" + m.vals.name + ".md"
+}
+
+func (m GenerateModel) options() generate.Options {
+	v := m.vals
+	return generate.Options{
+		Name:        v.name,
+		Description: v.description,
+		Model:       v.model,
+		Color:       v.color,
+		LLM:         "claude",
+		RepoRoot:    m.repoRoot,
+		LogFunc: func(msg string) {
 			select {
 			case v.logCh <- msg:
 			default:
 			}
-		}
+		},
+	}
+}
+
+// startRunning switches to the spinner with a fresh log channel.
+func (m *GenerateModel) startRunning(writing bool) {
+	m.state = genStateRunning
+	m.running = true
+	m.writing = writing
+	m.stepLog = nil
+	m.vals.logCh = make(chan string, 50)
+}
 
-		err := generate.Run(generate.Options{
-			Name:        v.name,
-			Description: v.description,
-			Model:       v.model,
-			Color:       v.color,
-			LLM:         "claude",
-			RepoRoot:    repoRoot,
-			LogFunc:     logFn,
-		})
-
-		if err != nil {
+// startDraft asks for a new draft, or a revision of prev given feedback.
+func (m *GenerateModel) startDraft(prev *generate.GeneratedPersona, feedback string) tea.Cmd {
+	m.startRunning(false)
+	m.revising = prev != nil && strings.TrimSpace(feedback) != ""
+	opts := m.options()
+	logCh := m.vals.logCh
+	draft := func() tea.Msg {
+		defer close(logCh)
+		gen, err := generate.Draft(opts, prev, feedback)
+		return genDraftMsg{gen: gen, err: err}
+	}
+	return tea.Batch(m.spinner.Tick, draft, m.waitForLog())
+}
+
+// startWrite writes the accepted draft.
+func (m *GenerateModel) startWrite() tea.Cmd {
+	m.startRunning(true)
+	opts := m.options()
+	logCh := m.vals.logCh
+	gen := m.draft
+	name := m.vals.name
+	agentLabel := m.agentLabel()
+	write := func() tea.Msg {
+		defer close(logCh)
+		if err := generate.Write(opts, gen); err != nil {
 			return genDoneMsg{err: err}
 		}
-
-		summaryLines = append(summaryLines,
-			fmt.Sprintf("Created agents/%s.md", v.name),
-			fmt.Sprintf("Created commands/%s.md", v.name),
-			fmt.Sprintf("Created skills/%s/SKILL.md", v.name),
+		if opts.RepoRoot == "" {
+			return genDoneMsg{summary: strings.Join([]string{
+				"Created " + agentLabel,
+				"Skipped plugin command and skill files (no vern-bot checkout)",
+			}, "\n")}
+		}
+		return genDoneMsg{summary: strings.Join([]string{
+			fmt.Sprintf("Created agents/%s.md", name),
+			fmt.Sprintf("Created commands/%s.md", name),
+			fmt.Sprintf("Created skills/%s/SKILL.md", name),
 			"Updated commands/v.md",
 			"Updated commands/help.md",
 			"Updated go/internal/embedded/embedded_test.go",
 			"Updated go/internal/council/selection.go",
 			"Regenerated embedded assets",
-		)
-
-		return genDoneMsg{summary: strings.Join(summaryLines, "\n")}
+		}, "\n")}
 	}
+	return tea.Batch(m.spinner.Tick, write, m.waitForLog())
 }
 
 func (m GenerateModel) waitForLog() tea.Cmd {
@@ -352,7 +523,14 @@ func (m GenerateModel) View() string {
 		b.WriteString(m.form.View())
 
 	case genStateRunning:
-		b.WriteString(fmt.Sprintf("%s %s %s...\n", m.spinner.View(), logHeaderStyle.Render("Generating"), llmStyle.Render(m.vals.name)))
+		verb := "Generating"
+		switch {
+		case m.writing:
+			verb = "Writing"
+		case m.revising:
+			verb = "Revising"
+		}
+		b.WriteString(fmt.Sprintf("%s %s %s...\n", m.spinner.View(), logHeaderStyle.Render(verb), llmStyle.Render(m.vals.name)))
 		b.WriteString(fmt.Sprintf("  Description: %s\n", logDimStyle.Render(m.vals.description)))
 		b.WriteString("\n")
 
@@ -370,8 +548,11 @@ func (m GenerateModel) View() string {
 			}
 		}
 
-	case genStateDone:
+	case genStatePreview, genStateDone:
 		b.WriteString(m.viewport.View())
+
+	case genStateFeedback:
+		b.WriteString(m.feedbackForm.View())
 	}
 
 	return b.String()
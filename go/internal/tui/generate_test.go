@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jdonohoo/vern-bot/go/internal/generate"
+)
+
+func TestGenerateRefineLoop(t *testing.T) {
+	m := NewGenerateModel("/tmp", "/tmp/agents")
+	m.SetSize(120, 40)
+	m.vals.name = "nihilist"
+	if m.repoRoot != "" {
+		t.Fatalf("repoRoot = %q, want empty outside a checkout", m.repoRoot)
+	}
+
+	update := func(msg tea.Msg) {
+		t.Helper()
+		next, _ := m.Update(msg)
+		m = next.(GenerateModel)
+	}
+	draft := &generate.GeneratedPersona{Name: "nihilist", Agent: "---\nname: nihilist\n---\nNothing matters.", Command: "CMD", Skill: "SKILL"}
+
+	update(genDraftMsg{gen: draft})
+	if m.state != genStatePreview || m.revision != 1 {
+		t.Fatalf("after a draft: state = %v, revision = %d", m.state, m.revision)
+	}
+	view := m.viewport.View()
+	if !strings.Contains(view, "Draft 1") || !strings.Contains(view, "Nothing matters.") {
+		t.Errorf("preview missing the draft:\n%s", view)
+	}
+	if strings.Contains(view, "CMD") {
+		t.Error("without a checkout only the agent file is previewed")
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m.state != genStateFeedback {
+		t.Fatalf("f: state = %v, want feedback", m.state)
+	}
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != genStatePreview {
+		t.Fatalf("esc from feedback: state = %v, want preview", m.state)
+	}
+
+	// A failed revision keeps the previous draft up
+	update(genDraftMsg{err: errors.New("LLM call failed")})
+	if m.state != genStatePreview || m.draft != draft || m.draftErr == nil {
+		t.Errorf("failed revision: state = %v, draft kept = %v, draftErr = %v", m.state, m.draft == draft, m.draftErr)
+	}
+	if !strings.Contains(m.viewport.View(), "Revision failed") {
+		t.Error("preview should show the failed revision")
+	}
+
+	// Without a draft, a failure ends on the retry screen
+	fresh := NewGenerateModel("/tmp", "/tmp/agents")
+	next, _ := fresh.Update(genDraftMsg{err: errors.New("boom")})
+	if g := next.(GenerateModel); g.state != genStateDone || g.err == nil {
+		t.Errorf("first draft failure: state = %v, err = %v", g.state, g.err)
+	}
+}
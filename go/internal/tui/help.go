@@ -153,6 +153,61 @@ var runRetryKeys = runRetryKeyMap{
 	Down:  key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("", "")),
 }
 
+// holeDoneKeyMap is the VernHole done screen. Ask and Retry are disabled
+// (and hidden) when they don't apply.
+type holeDoneKeyMap struct {
+	Copy  key.Binding
+	Ask   key.Binding
+	Retry key.Binding
+	Back  key.Binding
+	Up    key.Binding
+	Down  key.Binding
+}
+
+func (k holeDoneKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Copy, k.Ask, k.Retry, k.Back}
+}
+
+func (k holeDoneKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Copy, k.Ask, k.Retry, k.Back}}
+}
+
+var holeDoneKeys = holeDoneKeyMap{
+	Copy:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Ask:   key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "ask")),
+	Retry: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry")),
+	Back:  key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q/esc", "menu")),
+	Up:    key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("j/k", "scroll")),
+	Down:  key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("", "")),
+}
+
+// genPreviewKeyMap is the Generate Persona draft preview.
+type genPreviewKeyMap struct {
+	Accept   key.Binding
+	Feedback key.Binding
+	Restart  key.Binding
+	Back     key.Binding
+	Up       key.Binding
+	Down     key.Binding
+}
+
+func (k genPreviewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Accept, k.Feedback, k.Restart, k.Back}
+}
+
+func (k genPreviewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Accept, k.Feedback, k.Restart, k.Back}}
+}
+
+var genPreviewKeys = genPreviewKeyMap{
+	Accept:   key.NewBinding(key.WithKeys("enter", "a"), key.WithHelp("enter", "accept & write")),
+	Feedback: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "feedback")),
+	Restart:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "start over")),
+	Back:     key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q/esc", "discard")),
+	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("j/k", "scroll")),
+	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("", "")),
+}
+
 type oracleDoneKeyMap struct {
 	Copy key.Binding
 	Back key.Binding
@@ -28,7 +28,7 @@ const (
 // bubbletea's value-copy semantics.
 type histVals struct {
 	directory string
-	logCh     chan string
+	events    chan pipeline.Event
 	cancel    context.CancelFunc
 }
 
@@ -123,8 +123,8 @@ type histDoneMsg struct {
 	err     error
 }
 
-type histLogMsg struct {
-	line string
+type histEventMsg struct {
+	ev pipeline.Event
 }
 
 func (m HistorianModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -153,9 +153,9 @@ func (m HistorianModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.initDoneViewport()
 		return m, tea.Batch(tea.DisableMouse, celebCmd)
 
-	case histLogMsg:
-		m.stepLog = append(m.stepLog, msg.line)
-		return m, m.waitForLog()
+	case histEventMsg:
+		m.stepLog = append(m.stepLog, eventLogLines(msg.ev)...)
+		return m, m.waitForEvent()
 	}
 
 	switch m.state {
@@ -168,8 +168,8 @@ func (m HistorianModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state = histStateRunning
 			m.running = true
 			m.stepLog = nil
-			m.vals.logCh = make(chan string, 50)
-			return m, tea.Batch(m.spinner.Tick, m.startHistorian(), m.waitForLog())
+			m.vals.events = make(chan pipeline.Event, 50)
+			return m, tea.Batch(m.spinner.Tick, m.startHistorian(), m.waitForEvent())
 		}
 		if m.form.State == huh.StateAborted {
 			return m, backToMenu
@@ -242,18 +242,11 @@ func (m *HistorianModel) initDoneViewport() {
 func (m HistorianModel) startHistorian() tea.Cmd {
 	return func() tea.Msg {
 		v := m.vals
-		defer close(v.logCh)
+		defer close(v.events)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		v.cancel = cancel
 
-		logFn := func(msg string) {
-			select {
-			case v.logCh <- msg:
-			default:
-			}
-		}
-
 		cfg := config.Load(m.projectRoot)
 
 		result, err := pipeline.RunHistorian(pipeline.HistorianOptions{
@@ -261,7 +254,7 @@ func (m HistorianModel) startHistorian() tea.Cmd {
 			TargetDir: expandHome(v.directory),
 			AgentsDir: m.agentsDir,
 			Timeout:   cfg.GetHistorianTimeout(),
-			OnLog:     logFn,
+			Events:    pipeline.NewChannelHandler(v.events, ctx.Done()),
 		})
 
 		if err != nil {
@@ -288,17 +281,10 @@ func (m HistorianModel) startHistorian() tea.Cmd {
 	}
 }
 
-func (m HistorianModel) waitForLog() tea.Cmd {
-	return func() tea.Msg {
-		if m.vals.logCh == nil {
-			return nil
-		}
-		line, ok := <-m.vals.logCh
-		if !ok {
-			return nil
-		}
-		return histLogMsg{line: line}
-	}
+func (m HistorianModel) waitForEvent() tea.Cmd {
+	return waitForEvent(m.vals.events, func(ev pipeline.Event) tea.Msg {
+		return histEventMsg{ev: ev}
+	})
 }
 
 // Cancel aborts any running historian operation.
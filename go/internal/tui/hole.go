@@ -25,6 +25,7 @@ const (
 	holeStateForm holeState = iota
 	holeStateRunning
 	holeStateDone
+	holeStateAsk // follow-up question form over a finished run
 )
 
 // holeVals holds form-bound values on the heap so pointers survive
@@ -37,14 +38,17 @@ type holeVals struct {
 	customPath string
 	idea       string
 	confirm    bool
+	askVern    string // follow-up target: a Vern ID, or "" for the whole council
+	question   string
 	cancel     context.CancelFunc
-	logCh      chan string
+	events     chan pipeline.Event
 }
 
 // HoleModel handles the VernHole wizard.
 type HoleModel struct {
 	state       holeState
 	form        *huh.Form
+	askForm     *huh.Form
 	spinner     spinner.Model
 	progress    progress.Model
 	viewport    viewport.Model
@@ -59,7 +63,11 @@ type HoleModel struct {
 	running        bool
 	stepLog        []string
 	vernsCompleted int
+	vernsFailed    int
 	totalVerns     int
+	retrying       bool   // re-running failed Verns; totalVerns counts only those
+	asking         bool   // asking a follow-up; totalVerns counts only the Verns asked
+	askErr         error  // last follow-up failure, shown on the done screen
 	statusMsg      string // transient feedback (e.g. "Copied to clipboard!")
 	err            error
 }
@@ -126,12 +134,13 @@ func (m *HoleModel) buildForm() *huh.Form {
 	lines := textareaLines(m.height)
 	w := contentWidth(m.width)
 	v := m.vals
+	councilOpts := withCustomCouncils(CouncilOptions, m.projectRoot)
 	return huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Which council do you want to summon?").
-				Options(CouncilOptions...).
-				Height(len(CouncilOptions)+1).
+				Options(councilOpts...).
+				Height(len(councilOpts)+1).
 				Value(&v.council),
 		),
 		huh.NewGroup(
@@ -198,8 +207,9 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "esc" && !m.running {
-			if m.state == holeStateDone {
-				return m, backToMenu
+			if m.state == holeStateAsk {
+				m.state = holeStateDone
+				return m, nil
 			}
 			return m, backToMenu
 		}
@@ -211,6 +221,13 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case holeDoneMsg:
 		m.state = holeStateDone
 		m.running = false
+		if m.asking {
+			m.asking = false
+			m.askErr = msg.err
+			m.initDoneViewport()
+			m.viewport.GotoBottom()
+			return m, tea.DisableMouse
+		}
 		m.err = msg.err
 		var celebCmd tea.Cmd
 		if msg.err == nil {
@@ -219,10 +236,9 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.initDoneViewport()
 		return m, tea.Batch(tea.DisableMouse, celebCmd)
 
-	case holeLogMsg:
-		m.stepLog = append(m.stepLog, msg.line)
-		m.updateProgress(msg.line)
-		return m, m.waitForLog()
+	case holeEventMsg:
+		m.handleEvent(msg.ev)
+		return m, m.waitForEvent()
 
 	case holeStatusClearMsg:
 		m.statusMsg = ""
@@ -246,14 +262,34 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.state = holeStateRunning
 			m.running = true
-			m.vals.logCh = make(chan string, 100)
-			return m, tea.Batch(m.spinner.Tick, m.startHole(), m.waitForLog())
+			m.vals.events = make(chan pipeline.Event, 100)
+			return m, tea.Batch(m.spinner.Tick, m.startHole(), m.waitForEvent())
 		}
 		if m.form.State == huh.StateAborted {
 			return m, backToMenu
 		}
 		return m, cmd
 
+	case holeStateAsk:
+		form, cmd := m.askForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.askForm = f
+		}
+		switch m.askForm.State {
+		case huh.StateCompleted:
+			m.state = holeStateRunning
+			m.running = true
+			m.asking = true
+			m.askErr = nil
+			m.vernsCompleted, m.totalVerns = 0, 0
+			m.vals.events = make(chan pipeline.Event, 100)
+			return m, tea.Batch(m.spinner.Tick, m.startAsk(), m.waitForEvent())
+		case huh.StateAborted:
+			m.state = holeStateDone
+			return m, nil
+		}
+		return m, cmd
+
 	case holeStateRunning:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -264,6 +300,24 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch keyMsg.String() {
 			case "q":
 				return m, backToMenu
+			case "a":
+				if m.canAsk() {
+					m.state = holeStateAsk
+					m.vals.question = ""
+					m.askForm = m.buildAskForm()
+					return m, m.askForm.Init()
+				}
+			case "r":
+				if m.vernsFailed > 0 {
+					m.state = holeStateRunning
+					m.running = true
+					m.retrying = true
+					m.err = nil
+					m.stepLog = nil
+					m.vernsCompleted, m.vernsFailed, m.totalVerns = 0, 0, 0
+					m.vals.events = make(chan pipeline.Event, 100)
+					return m, tea.Batch(m.spinner.Tick, m.startRetry(), m.waitForEvent())
+				}
 			case "c":
 				text := m.resultContent()
 				if text != "" {
@@ -286,19 +340,25 @@ func (m HoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *HoleModel) updateProgress(line string) {
-	upper := strings.ToUpper(line)
-	// Parse total from ">>> Vern N/Total:" lines
-	if strings.HasPrefix(line, ">>> Vern ") {
-		rest := strings.TrimPrefix(line, ">>> Vern ")
-		if _, total, _, _, ok := parseVernLine(rest); ok {
-			m.totalVerns = total
+// handleEvent tracks Vern progress from a VernHole event and appends its
+// text to the activity log.
+func (m *HoleModel) handleEvent(ev pipeline.Event) {
+	switch d := ev.Data.(type) {
+	case pipeline.VernStartData:
+		// Retries and follow-ups only run some Verns, so count them instead
+		if m.retrying || m.asking {
+			m.totalVerns++
+		} else {
+			m.totalVerns = d.Total
 		}
-	}
-	// Count completions from OK/FAILED lines with "Vern N/" pattern
-	if strings.Contains(upper, "VERN ") && (strings.Contains(upper, "OK (") || strings.Contains(upper, "FAILED (")) {
+	case pipeline.VernCompleteData:
 		m.vernsCompleted++
+		// A follow-up answer failing doesn't make its Vern's run output retryable
+		if !d.Result.Succeeded && !m.asking {
+			m.vernsFailed++
+		}
 	}
+	m.stepLog = append(m.stepLog, eventLogLines(ev)...)
 }
 
 func (m *HoleModel) initDoneViewport() {
@@ -321,6 +381,18 @@ func (m *HoleModel) initDoneViewport() {
 		content.WriteString(fmt.Sprintf("Files created in: %s\n", m.outputDir()))
 		content.WriteString("\n")
 	}
+	if m.vernsFailed > 0 {
+		content.WriteString(logDimStyle.Render(fmt.Sprintf("%d Vern(s) failed — press r to retry them and re-synthesize", m.vernsFailed)))
+		content.WriteString("\n\n")
+	}
+	if m.askErr != nil {
+		content.WriteString(stepFailStyle.Render("Follow-up failed: " + m.askErr.Error()))
+		content.WriteString("\n\n")
+	}
+	if m.canAsk() {
+		content.WriteString(logDimStyle.Render("Press a to ask the council a follow-up question"))
+		content.WriteString("\n\n")
+	}
 
 	// Try to read synthesis
 	synthPath := filepath.Join(m.outputDir(), "synthesis.md")
@@ -331,6 +403,13 @@ func (m *HoleModel) initDoneViewport() {
 		content.WriteString("\n")
 	}
 
+	if data, err := os.ReadFile(m.transcriptPath()); err == nil {
+		content.WriteString(logHeaderStyle.Render("Follow-ups"))
+		content.WriteString("\n")
+		content.WriteString(string(data))
+		content.WriteString("\n")
+	}
+
 	// Show full log
 	if len(m.stepLog) > 0 {
 		content.WriteString(logHeaderStyle.Render("Activity Log"))
@@ -351,6 +430,58 @@ func (m HoleModel) outputDir() string {
 	return "./vernhole/"
 }
 
+// canAsk reports whether the run finished with a synthesis to ask about.
+func (m HoleModel) canAsk() bool {
+	_, err := os.Stat(filepath.Join(m.outputDir(), "synthesis.md"))
+	return err == nil
+}
+
+func (m HoleModel) transcriptPath() string {
+	return filepath.Join(m.outputDir(), pipeline.FollowUpDir, pipeline.FollowUpTranscript)
+}
+
+// buildAskForm asks who to put a follow-up to (the council or one member
+// from council.json) and the question.
+func (m *HoleModel) buildAskForm() *huh.Form {
+	v := m.vals
+	targets := []huh.Option[string]{huh.NewOption("The whole council", "")}
+	if manifest, err := pipeline.ReadManifest(filepath.Join(m.outputDir(), pipeline.ManifestFile)); err == nil {
+		for _, member := range manifest.Members {
+			targets = append(targets, huh.NewOption(member.Name, member.ID))
+		}
+	}
+	known := false
+	for _, t := range targets {
+		known = known || t.Value == v.askVern
+	}
+	if !known {
+		v.askVern = ""
+	}
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Who should answer?").
+				Options(targets...).
+				Height(min(len(targets)+1, 12)).
+				Value(&v.askVern),
+		),
+		huh.NewGroup(
+			huh.NewText().
+				Title("Follow-up question").
+				Placeholder("What would change your verdict?").
+				Lines(textareaLines(m.height)).
+				CharLimit(2000).
+				Value(&v.question).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("question is required")
+					}
+					return nil
+				}),
+		),
+	).WithTheme(VernTheme()).WithWidth(contentWidth(m.width)).WithHeight(formHeight(m.height))
+}
+
 func (m HoleModel) llmModeLabel() string {
 	if m.vals.llmMode == "single_llm" {
 		return "single_llm (" + m.vals.singleLLM + ")"
@@ -387,8 +518,8 @@ type holeDoneMsg struct {
 	err error
 }
 
-type holeLogMsg struct {
-	line string
+type holeEventMsg struct {
+	ev pipeline.Event
 }
 
 type holeStatusClearMsg struct{}
@@ -396,59 +527,108 @@ type holeStatusClearMsg struct{}
 func (m HoleModel) startHole() tea.Cmd {
 	return func() tea.Msg {
 		v := m.vals
-		defer close(v.logCh)
+		defer close(v.events)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		v.cancel = cancel
 		defer cancel()
 
 		cfg := config.Load(m.projectRoot)
-
-		synthesisLLM := cfg.GetSynthesisLLM()
-		overrideLLM := cfg.GetOverrideLLM()
-
-		if v.llmMode == "single_llm" && v.singleLLM != "" {
-			overrideLLM = v.singleLLM
-			synthesisLLM = v.singleLLM
-		} else if v.llmMode != "" {
-			cfg.LLMMode = v.llmMode
-			synthesisLLM = cfg.GetSynthesisLLM()
-			overrideLLM = cfg.GetOverrideLLM()
-		}
+		synthesisLLM, overrideLLM := m.resolveLLMs(cfg)
 
 		err := pipeline.RunVernHole(pipeline.VernHoleOptions{
 			Ctx:          ctx,
 			Idea:         v.idea,
 			OutputDir:    m.outputDir(),
 			Council:      v.council,
+			Councils:     cfg.VernHole.Councils,
 			AgentsDir:    m.agentsDir,
 			Timeout:      cfg.GetPipelineStepTimeout(),
 			SynthesisLLM: synthesisLLM,
 			OverrideLLM:  overrideLLM,
-			OnLog: func(line string) {
-				select {
-				case v.logCh <- line:
-				default:
-				}
-			},
+			Events:       pipeline.NewChannelHandler(v.events, ctx.Done()),
 		})
 		return holeDoneMsg{err: err}
 	}
 }
 
-func (m HoleModel) waitForLog() tea.Cmd {
+// startRetry re-runs the failed Verns in the output dir and re-synthesizes.
+func (m HoleModel) startRetry() tea.Cmd {
 	return func() tea.Msg {
-		if m.vals.logCh == nil {
-			return nil
-		}
-		line, ok := <-m.vals.logCh
-		if !ok {
-			return nil
-		}
-		return holeLogMsg{line: line}
+		v := m.vals
+		defer close(v.events)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		v.cancel = cancel
+		defer cancel()
+
+		cfg := config.Load(m.projectRoot)
+		synthesisLLM, overrideLLM := m.resolveLLMs(cfg)
+
+		err := pipeline.RetryVernHole(pipeline.VernHoleRetryOptions{
+			Ctx:          ctx,
+			Dir:          m.outputDir(),
+			AgentsDir:    m.agentsDir,
+			Timeout:      cfg.GetPipelineStepTimeout(),
+			SynthesisLLM: synthesisLLM,
+			OverrideLLM:  overrideLLM,
+			Events:       pipeline.NewChannelHandler(v.events, ctx.Done()),
+		})
+		return holeDoneMsg{err: err}
+	}
+}
+
+// startAsk puts the follow-up question to the council or one Vern.
+func (m HoleModel) startAsk() tea.Cmd {
+	return func() tea.Msg {
+		v := m.vals
+		defer close(v.events)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		v.cancel = cancel
+		defer cancel()
+
+		cfg := config.Load(m.projectRoot)
+		_, overrideLLM := m.resolveLLMs(cfg)
+
+		_, _, err := pipeline.AskVernHole(pipeline.FollowUpOptions{
+			Ctx:         ctx,
+			Dir:         m.outputDir(),
+			Vern:        v.askVern,
+			Question:    v.question,
+			Idea:        v.idea,
+			AgentsDir:   m.agentsDir,
+			Timeout:     cfg.GetPipelineStepTimeout(),
+			OverrideLLM: overrideLLM,
+			Events:      pipeline.NewChannelHandler(v.events, ctx.Done()),
+		})
+		return holeDoneMsg{err: err}
 	}
 }
 
+// resolveLLMs applies the form's LLM mode to the config defaults.
+func (m HoleModel) resolveLLMs(cfg *config.Config) (synthesisLLM, overrideLLM string) {
+	v := m.vals
+	synthesisLLM = cfg.GetSynthesisLLM()
+	overrideLLM = cfg.GetOverrideLLM()
+
+	if v.llmMode == "single_llm" && v.singleLLM != "" {
+		overrideLLM = v.singleLLM
+		synthesisLLM = v.singleLLM
+	} else if v.llmMode != "" {
+		cfg.LLMMode = v.llmMode
+		synthesisLLM = cfg.GetSynthesisLLM()
+		overrideLLM = cfg.GetOverrideLLM()
+	}
+	return synthesisLLM, overrideLLM
+}
+
+func (m HoleModel) waitForEvent() tea.Cmd {
+	return waitForEvent(m.vals.events, func(ev pipeline.Event) tea.Msg {
+		return holeEventMsg{ev: ev}
+	})
+}
+
 // resultContent builds the full VernHole output as plain text for clipboard copy.
 func (m HoleModel) resultContent() string {
 	var b strings.Builder
@@ -493,7 +673,13 @@ func (m HoleModel) View() string {
 		b.WriteString(m.form.View())
 
 	case holeStateRunning:
-		b.WriteString(fmt.Sprintf("%s %s\n", m.spinner.View(), logHeaderStyle.Render("Summoning the VernHole council...")))
+		title := "Summoning the VernHole council..."
+		if m.asking {
+			title = "Asking the council..."
+		} else if m.retrying {
+			title = "Retrying failed Verns..."
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", m.spinner.View(), logHeaderStyle.Render(title)))
 		b.WriteString(fmt.Sprintf("  Output: %s\n\n", logDimStyle.Render(m.outputDir())))
 
 		// Progress bar
@@ -532,6 +718,9 @@ func (m HoleModel) View() string {
 			}
 		}
 
+	case holeStateAsk:
+		b.WriteString(m.askForm.View())
+
 	case holeStateDone:
 		if cv := m.celebration.View(); cv != "" {
 			b.WriteString(cv)
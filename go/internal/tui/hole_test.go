@@ -1,38 +1,81 @@
 package tui
 
-import "testing"
+import (
+	"testing"
 
-func TestHoleUpdateProgress(t *testing.T) {
+	"github.com/jdonohoo/vern-bot/go/internal/council"
+	"github.com/jdonohoo/vern-bot/go/internal/pipeline"
+)
+
+func TestHoleHandleEvent(t *testing.T) {
 	m := NewHoleModel("/tmp", "/tmp/agents")
+	h := pipeline.FuncHandler(m.handleEvent)
+
+	start := func(i int, name string) func() {
+		return func() {
+			h.OnVernStart(pipeline.VernStartData{Index: i, Total: 4, Vern: council.Vern{Name: name}})
+		}
+	}
+	done := func(i int, ok bool) func() {
+		return func() {
+			h.OnVernComplete(pipeline.VernCompleteData{Total: 4, Result: pipeline.VernHoleResult{Index: i, Succeeded: ok}})
+		}
+	}
 
 	tests := []struct {
-		line          string
+		name          string
+		emit          func()
 		wantTotal     int
 		wantCompleted int
+		wantFailed    int
 	}{
 		// Verns are summoned in parallel — all appear quickly
-		{">>> Vern 1/4: Architect (claude)", 4, 0},
-		{">>> Vern 2/4: Engineer (codex)", 4, 0},
-		{">>> Vern 3/4: Validator (gemini)", 4, 0},
-		{">>> Vern 4/4: Oracle (claude)", 4, 0},
+		{"start 1", start(0, "Architect"), 4, 0, 0},
+		{"start 2", start(1, "Engineer"), 4, 0, 0},
+		{"start 3", start(2, "Validator"), 4, 0, 0},
+		{"start 4", start(3, "Oracle"), 4, 0, 0},
 		// Completions come back in any order
-		{"    OK (claude, 4800B, Vern 1/4)", 4, 1},
-		{"    OK (codex, 3200B, Vern 2/4)", 4, 2},
-		{"    FAILED (validator, exit 1, Vern 3/4) — excluding from synthesis", 4, 3},
-		{"    OK (claude, 5100B, Vern 4/4)", 4, 4},
-		// Synthesis lines don't change count
-		{">>> Synthesizing the chaos (3/4 Verns succeeded)...", 4, 4},
+		{"done 1", done(0, true), 4, 1, 0},
+		{"done 2", done(1, true), 4, 2, 0},
+		{"fail 3", done(2, false), 4, 3, 1},
+		{"done 4", done(3, true), 4, 4, 1},
+		// Synthesis doesn't change counts
+		{"synthesis", func() { h.OnSynthesisStart("claude") }, 4, 4, 1},
 	}
 
 	for _, tt := range tests {
-		m.updateProgress(tt.line)
+		tt.emit()
 		if m.totalVerns != tt.wantTotal {
-			t.Errorf("after line %q: totalVerns = %d, want %d",
-				tt.line, m.totalVerns, tt.wantTotal)
+			t.Errorf("after %s: totalVerns = %d, want %d", tt.name, m.totalVerns, tt.wantTotal)
 		}
 		if m.vernsCompleted != tt.wantCompleted {
-			t.Errorf("after line %q: vernsCompleted = %d, want %d",
-				tt.line, m.vernsCompleted, tt.wantCompleted)
+			t.Errorf("after %s: vernsCompleted = %d, want %d", tt.name, m.vernsCompleted, tt.wantCompleted)
 		}
+		if m.vernsFailed != tt.wantFailed {
+			t.Errorf("after %s: vernsFailed = %d, want %d", tt.name, m.vernsFailed, tt.wantFailed)
+		}
+	}
+	if len(m.stepLog) != 8 {
+		t.Errorf("stepLog has %d lines, want 8 (one per Vern start/complete)", len(m.stepLog))
+	}
+}
+
+func TestHoleHandleEvent_Asking(t *testing.T) {
+	m := NewHoleModel("/tmp", "/tmp/agents")
+	m.vernsFailed = 1 // left over from the run itself
+	m.asking = true
+	h := pipeline.FuncHandler(m.handleEvent)
+
+	// Asking one Vern of a council of four
+	h.OnVernStart(pipeline.VernStartData{Index: 2, Total: 4, Vern: council.Vern{Name: "Validator"}})
+	if m.totalVerns != 1 {
+		t.Errorf("totalVerns = %d, want 1 (only the Verns asked)", m.totalVerns)
+	}
+	h.OnVernComplete(pipeline.VernCompleteData{Total: 4, Result: pipeline.VernHoleResult{Index: 2}})
+	if m.vernsCompleted != 1 {
+		t.Errorf("vernsCompleted = %d, want 1", m.vernsCompleted)
+	}
+	if m.vernsFailed != 1 {
+		t.Errorf("vernsFailed = %d, want 1 (a failed answer isn't a retryable Vern)", m.vernsFailed)
 	}
 }
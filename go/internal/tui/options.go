@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/huh"
+
+	"github.com/jdonohoo/vern-bot/go/internal/config"
+	"github.com/jdonohoo/vern-bot/go/internal/council"
 )
 
 // expandHome replaces a leading ~/ with the user's home directory.
@@ -40,6 +43,7 @@ var SingleLLMOptions = []huh.Option[string]{
 // CouncilOptions are the VernHole council tier options.
 var CouncilOptions = []huh.Option[string]{
 	huh.NewOption("The Full Vern Experience (15) (Recommended)", "full"),
+	huh.NewOption("The Smart Council (4-6, picked for your idea)", "smart"),
 	huh.NewOption("Fate's Hand (random count, random selection)", "random"),
 	huh.NewOption("The War Room (10-13)", "war"),
 	huh.NewOption("The Round Table (6-9)", "round"),
@@ -51,6 +55,7 @@ var CouncilOptions = []huh.Option[string]{
 // VernHoleOptions are council options plus a "No VernHole" option for discovery.
 var VernHoleOptions = []huh.Option[string]{
 	huh.NewOption("The Full Vern Experience (15) (Recommended)", "full"),
+	huh.NewOption("The Smart Council (4-6, picked for your idea)", "smart"),
 	huh.NewOption("Fate's Hand (random count, random selection)", "random"),
 	huh.NewOption("The War Room (10-13)", "war"),
 	huh.NewOption("The Round Table (6-9)", "round"),
@@ -60,6 +65,41 @@ var VernHoleOptions = []huh.Option[string]{
 	huh.NewOption("No VernHole, just the pipeline", ""),
 }
 
+// withCustomCouncils appends councils declared in config or .vern/councils
+// to a council option list, ahead of any "No VernHole" entry. Invalid
+// definitions are left out here; the VernHole run reports them.
+func withCustomCouncils(base []huh.Option[string], projectRoot string) []huh.Option[string] {
+	cfg := config.Load(projectRoot)
+	tiers, err := council.LoadTiers(cfg.VernHole.Councils, council.UserCouncilDir(), council.ProjectCouncilDir)
+	if err != nil {
+		return base
+	}
+
+	known := map[string]bool{}
+	var none []huh.Option[string]
+	opts := make([]huh.Option[string], 0, len(base))
+	for _, opt := range base {
+		known[opt.Value] = true
+		if opt.Value == "" {
+			none = append(none, opt)
+			continue
+		}
+		opts = append(opts, opt)
+	}
+	for _, name := range council.TierNames(tiers) {
+		if known[name] {
+			continue
+		}
+		t := tiers[name]
+		size := fmt.Sprintf("%d", len(t.Core))
+		if !t.Fixed {
+			size = fmt.Sprintf("%d-%d", t.MinFill, t.MaxFill)
+		}
+		opts = append(opts, huh.NewOption(fmt.Sprintf("%s (%s) (custom)", t.Display, size), name))
+	}
+	return append(opts, none...)
+}
+
 // PipelineOptions are the discovery pipeline mode options.
 var PipelineOptions = []huh.Option[string]{
 	huh.NewOption("Default (5-step) (Recommended)", "default"),
@@ -21,14 +21,14 @@ func TestSingleLLMOptionsCount(t *testing.T) {
 }
 
 func TestCouncilOptionsCount(t *testing.T) {
-	if len(CouncilOptions) != 7 {
-		t.Errorf("expected 7 council options, got %d", len(CouncilOptions))
+	if len(CouncilOptions) != 8 {
+		t.Errorf("expected 8 council options, got %d", len(CouncilOptions))
 	}
 }
 
 func TestVernHoleOptionsCount(t *testing.T) {
-	if len(VernHoleOptions) != 8 {
-		t.Errorf("expected 8 VernHole options (7 councils + none), got %d", len(VernHoleOptions))
+	if len(VernHoleOptions) != 9 {
+		t.Errorf("expected 9 VernHole options (8 councils + none), got %d", len(VernHoleOptions))
 	}
 }
 
@@ -154,3 +154,24 @@ func TestExpectedSingleLLMValues(t *testing.T) {
 		}
 	}
 }
+
+func TestWithCustomCouncils(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+	os.MkdirAll(".vern/councils", 0755)
+	os.WriteFile(".vern/councils/frontend.json", []byte(`{"display": "Frontend Crew", "core": ["ux"], "min": 3, "max": 4}`), 0644)
+
+	opts := withCustomCouncils(VernHoleOptions, "")
+	if len(opts) != len(VernHoleOptions)+1 {
+		t.Fatalf("expected %d options, got %d", len(VernHoleOptions)+1, len(opts))
+	}
+	custom := opts[len(opts)-2]
+	if custom.Value != "frontend" || custom.Key != "Frontend Crew (3-4) (custom)" {
+		t.Errorf("custom option = %q -> %q", custom.Key, custom.Value)
+	}
+	if opts[len(opts)-1].Value != "" {
+		t.Error("\"No VernHole\" should stay last")
+	}
+	noDuplicateValues(t, "withCustomCouncils", opts)
+}
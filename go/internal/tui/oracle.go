@@ -52,7 +52,7 @@ type oracleVals struct {
 	singleLLM   string
 	confirm     bool
 	cancel      context.CancelFunc
-	logCh       chan string
+	events      chan pipeline.Event
 
 	// Path picker state
 	outputPath string // "default" or "custom"
@@ -241,6 +241,7 @@ func (m *OracleModel) projectSelectDescription() string {
 func (m *OracleModel) buildOracleConfigForm() *huh.Form {
 	w := contentWidth(m.width)
 	v := m.vals
+	councilOpts := withCustomCouncils(CouncilOptions, m.projectRoot)
 
 	var groups []*huh.Group
 
@@ -288,8 +289,8 @@ func (m *OracleModel) buildOracleConfigForm() *huh.Form {
 		groups = append(groups, huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Which council do you want to summon?").
-				Options(CouncilOptions...).
-				Height(len(CouncilOptions)+1).
+				Options(councilOpts...).
+				Height(len(councilOpts)+1).
 				Value(&v.council),
 		))
 	}
@@ -541,10 +542,9 @@ func (m OracleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.initDoneViewport()
 		return m, tea.Batch(tea.DisableMouse, celebCmd)
 
-	case oracleLogMsg:
-		m.stepLog = append(m.stepLog, msg.line)
-		m.updateProgress(msg.line)
-		return m, m.waitForLog()
+	case oracleEventMsg:
+		m.handleEvent(msg.ev)
+		return m, m.waitForEvent()
 
 	case progress.FrameMsg:
 		progressModel, cmd := m.progress.Update(msg)
@@ -636,8 +636,8 @@ func (m OracleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.state = oracleStateRunning
 			m.running = true
-			m.vals.logCh = make(chan string, 100)
-			return m, tea.Batch(m.spinner.Tick, m.startOracle(), m.waitForLog())
+			m.vals.events = make(chan pipeline.Event, 100)
+			return m, tea.Batch(m.spinner.Tick, m.startOracle(), m.waitForEvent())
 		}
 		if m.configForm.State == huh.StateAborted {
 			// Go back to project select
@@ -683,14 +683,16 @@ func (m OracleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *OracleModel) updateProgress(line string) {
-	upper := strings.ToUpper(line)
-	if strings.HasPrefix(line, ">>> Vern ") {
+// handleEvent tracks Vern progress and appends the event's text to the
+// activity log.
+func (m *OracleModel) handleEvent(ev pipeline.Event) {
+	switch ev.Data.(type) {
+	case pipeline.VernStartData:
 		m.totalVerns++
-	}
-	if strings.Contains(upper, "OK (") || strings.Contains(upper, "FALLBACK SUCCEEDED") {
+	case pipeline.VernCompleteData:
 		m.vernsCompleted++
 	}
+	m.stepLog = append(m.stepLog, eventLogLines(ev)...)
 }
 
 func (m OracleModel) resultContent() string {
@@ -851,8 +853,8 @@ type oracleDoneMsg struct {
 	err error
 }
 
-type oracleLogMsg struct {
-	line string
+type oracleEventMsg struct {
+	ev pipeline.Event
 }
 
 type oracleStatusClearMsg struct{}
@@ -860,7 +862,7 @@ type oracleStatusClearMsg struct{}
 func (m OracleModel) startOracle() tea.Cmd {
 	return func() tea.Msg {
 		v := m.vals
-		defer close(v.logCh)
+		defer close(v.events)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		v.cancel = cancel
@@ -880,12 +882,7 @@ func (m OracleModel) startOracle() tea.Cmd {
 			overrideLLM = cfg.GetOverrideLLM()
 		}
 
-		onLog := func(line string) {
-			select {
-			case v.logCh <- line:
-			default:
-			}
-		}
+		events := pipeline.NewChannelHandler(v.events, ctx.Done())
 
 		var err error
 
@@ -899,7 +896,7 @@ func (m OracleModel) startOracle() tea.Cmd {
 				AgentsDir:    m.agentsDir,
 				SynthesisLLM: synthesisLLM,
 				Timeout:      cfg.GetOracleTimeout(),
-				OnLog:        onLog,
+				Events:       events,
 			})
 
 		case "apply":
@@ -910,7 +907,7 @@ func (m OracleModel) startOracle() tea.Cmd {
 				AgentsDir:    m.agentsDir,
 				SynthesisLLM: synthesisLLM,
 				Timeout:      cfg.GetOracleApplyTimeout(),
-				OnLog:        onLog,
+				Events:       events,
 			})
 
 		case "vernhole":
@@ -924,12 +921,13 @@ func (m OracleModel) startOracle() tea.Cmd {
 				Idea:         v.idea,
 				OutputDir:    vernDir,
 				Council:      v.council,
+				Councils:     cfg.VernHole.Councils,
 				Context:      expandHome(v.contextFile),
 				AgentsDir:    m.agentsDir,
 				Timeout:      cfg.GetPipelineStepTimeout(),
 				SynthesisLLM: synthesisLLM,
 				OverrideLLM:  overrideLLM,
-				OnLog:        onLog,
+				Events:       events,
 			})
 		}
 
@@ -937,17 +935,10 @@ func (m OracleModel) startOracle() tea.Cmd {
 	}
 }
 
-func (m OracleModel) waitForLog() tea.Cmd {
-	return func() tea.Msg {
-		if m.vals.logCh == nil {
-			return nil
-		}
-		line, ok := <-m.vals.logCh
-		if !ok {
-			return nil
-		}
-		return oracleLogMsg{line: line}
-	}
+func (m OracleModel) waitForEvent() tea.Cmd {
+	return waitForEvent(m.vals.events, func(ev pipeline.Event) tea.Msg {
+		return oracleEventMsg{ev: ev}
+	})
 }
 
 // Cancel aborts any running oracle goroutine.
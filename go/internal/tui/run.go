@@ -366,6 +366,7 @@ func (m RunModel) startRun() tea.Cmd {
 				OutputFile: outputFile,
 				Timeout:    20 * time.Minute,
 				AgentsDir:  m.agentsDir,
+				Step:       "run",
 			})
 
 			if err == nil && result != nil && result.ExitCode == 0 && result.Output != "" {
@@ -374,12 +375,12 @@ func (m RunModel) startRun() tea.Cmd {
 				case v.logCh <- okLine:
 				default:
 				}
-				return runDoneMsg{output: result.Output}
+				return runDoneMsg{output: result.Text()}
 			}
 
 			lastErr = err
 			if result != nil {
-				lastOutput = result.Output
+				lastOutput = result.Text()
 				if result.Stderr != "" {
 					lastStderr = result.Stderr
 				}
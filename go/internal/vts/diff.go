@@ -0,0 +1,320 @@
+package vts
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ChangelogFile is written next to the vts/ directory when oracle-apply
+// rewrites the task set.
+const ChangelogFile = "vts-changelog.md"
+
+// titleMatchThreshold is the minimum TitleSimilarity for two tasks to be
+// considered the same task when their IDs don't line up.
+const titleMatchThreshold = 0.6
+
+// FieldChange is one changed field on a matched task.
+type FieldChange struct {
+	Field   string   // "title", "complexity", "dependencies", "criteria"
+	Old     string   // scalar fields
+	New     string   // scalar fields
+	Added   []string // list fields
+	Removed []string // list fields
+}
+
+// TaskChange pairs an old task with its counterpart in the new set.
+type TaskChange struct {
+	Old     Task
+	New     Task
+	ByTitle bool // matched by title similarity rather than ID
+	Fields  []FieldChange
+}
+
+// Renumbered reports whether the task's ID differs between the two sets.
+func (c TaskChange) Renumbered() bool {
+	return c.Old.ID != c.New.ID
+}
+
+// Diff is the comparison of two VTS task sets.
+type Diff struct {
+	Added     []Task
+	Removed   []Task
+	Changed   []TaskChange
+	Unchanged int
+}
+
+// Empty reports whether the two sets are equivalent.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffTasks compares two task sets. Tasks are matched by ID when their titles
+// still look alike; otherwise by title similarity, so a renumbered task (e.g.
+// after a task above it was removed) is reported as moved rather than as a
+// remove + add. Same-ID pairs with dissimilar titles that found no better
+// match are reported as retitled. Dependencies are compared after mapping
+// old IDs onto the new numbering.
+func DiffTasks(oldTasks, newTasks []Task) *Diff {
+	type pair struct{ o, n int }
+	var pairs []pair
+	byTitle := map[int]bool{} // new index -> matched by title
+	oldUsed := make([]bool, len(oldTasks))
+	newUsed := make([]bool, len(newTasks))
+
+	newByID := map[string]int{}
+	for i, t := range newTasks {
+		newByID[t.ID] = i
+	}
+
+	// Pass 1: same ID, similar title
+	for oi, o := range oldTasks {
+		if ni, ok := newByID[o.ID]; ok && TitleSimilarity(o.Title, newTasks[ni].Title) >= titleMatchThreshold {
+			pairs = append(pairs, pair{oi, ni})
+			oldUsed[oi], newUsed[ni] = true, true
+		}
+	}
+
+	// Pass 2: best title match among the rest, highest similarity first
+	type candidate struct {
+		o, n  int
+		score float64
+	}
+	var cands []candidate
+	for oi, o := range oldTasks {
+		if oldUsed[oi] {
+			continue
+		}
+		for ni, n := range newTasks {
+			if newUsed[ni] {
+				continue
+			}
+			if s := TitleSimilarity(o.Title, n.Title); s >= titleMatchThreshold {
+				cands = append(cands, candidate{oi, ni, s})
+			}
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].score > cands[j].score })
+	for _, c := range cands {
+		if oldUsed[c.o] || newUsed[c.n] {
+			continue
+		}
+		pairs = append(pairs, pair{c.o, c.n})
+		oldUsed[c.o], newUsed[c.n] = true, true
+		byTitle[c.n] = oldTasks[c.o].ID != newTasks[c.n].ID
+	}
+
+	// Pass 3: same ID, retitled beyond recognition
+	for oi, o := range oldTasks {
+		if oldUsed[oi] {
+			continue
+		}
+		if ni, ok := newByID[o.ID]; ok && !newUsed[ni] {
+			pairs = append(pairs, pair{oi, ni})
+			oldUsed[oi], newUsed[ni] = true, true
+		}
+	}
+
+	// Old ID -> new ID, for translating dependency references
+	idMap := map[string]string{}
+	for _, p := range pairs {
+		idMap[oldTasks[p.o].ID] = newTasks[p.n].ID
+	}
+
+	d := &Diff{}
+	for oi, o := range oldTasks {
+		if !oldUsed[oi] {
+			d.Removed = append(d.Removed, o)
+		}
+	}
+	for ni, n := range newTasks {
+		if !newUsed[ni] {
+			d.Added = append(d.Added, n)
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return newTasks[pairs[i].n].ID < newTasks[pairs[j].n].ID })
+	for _, p := range pairs {
+		o, n := oldTasks[p.o], newTasks[p.n]
+		change := TaskChange{Old: o, New: n, ByTitle: byTitle[p.n], Fields: compareFields(o, n, idMap)}
+		if len(change.Fields) == 0 && !change.Renumbered() {
+			d.Unchanged++
+			continue
+		}
+		d.Changed = append(d.Changed, change)
+	}
+	return d
+}
+
+func compareFields(o, n Task, idMap map[string]string) []FieldChange {
+	var fields []FieldChange
+	if strings.TrimSpace(o.Title) != strings.TrimSpace(n.Title) {
+		fields = append(fields, FieldChange{Field: "title", Old: o.Title, New: n.Title})
+	}
+	if !strings.EqualFold(strings.TrimSpace(o.Complexity), strings.TrimSpace(n.Complexity)) {
+		fields = append(fields, FieldChange{Field: "complexity", Old: o.Complexity, New: n.Complexity})
+	}
+
+	var oldDeps []string
+	for _, dep := range o.Dependencies {
+		if mapped, ok := idMap[dep]; ok {
+			dep = mapped
+		}
+		oldDeps = append(oldDeps, dep)
+	}
+	if added, removed := diffLists(oldDeps, n.Dependencies); len(added)+len(removed) > 0 {
+		fields = append(fields, FieldChange{Field: "dependencies", Added: added, Removed: removed})
+	}
+	if added, removed := diffLists(o.Criteria, n.Criteria); len(added)+len(removed) > 0 {
+		fields = append(fields, FieldChange{Field: "criteria", Added: added, Removed: removed})
+	}
+	return fields
+}
+
+// diffLists returns items in b but not a, and items in a but not b,
+// compared after whitespace trimming.
+func diffLists(a, b []string) (added, removed []string) {
+	inA := map[string]bool{}
+	for _, s := range a {
+		inA[strings.TrimSpace(s)] = true
+	}
+	inB := map[string]bool{}
+	for _, s := range b {
+		inB[strings.TrimSpace(s)] = true
+		if !inA[strings.TrimSpace(s)] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[strings.TrimSpace(s)] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+// TitleSimilarity returns the Dice coefficient of the character bigrams of
+// two titles (case- and punctuation-insensitive), from 0 (disjoint) to 1.
+func TitleSimilarity(a, b string) float64 {
+	ba, bb := bigrams(a), bigrams(b)
+	if len(ba) == 0 || len(bb) == 0 {
+		if Slugify(a) == Slugify(b) {
+			return 1
+		}
+		return 0
+	}
+	counts := map[string]int{}
+	for _, g := range ba {
+		counts[g]++
+	}
+	shared := 0
+	for _, g := range bb {
+		if counts[g] > 0 {
+			counts[g]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ba)+len(bb))
+}
+
+func bigrams(s string) []string {
+	r := []rune(strings.ReplaceAll(Slugify(s), "-", " "))
+	var grams []string
+	for i := 0; i+1 < len(r); i++ {
+		grams = append(grams, string(r[i:i+2]))
+	}
+	return grams
+}
+
+// Markdown renders the diff as a changelog document. oldLabel and newLabel
+// name the two sides (directory paths or "before"/"after").
+func (d *Diff) Markdown(oldLabel, newLabel string) string {
+	var b strings.Builder
+	b.WriteString("# VTS Changelog\n\n")
+	fmt.Fprintf(&b, "Compared `%s` → `%s`: %d added, %d removed, %d changed, %d unchanged.\n",
+		oldLabel, newLabel, len(d.Added), len(d.Removed), len(d.Changed), d.Unchanged)
+
+	if d.Empty() {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+
+	if len(d.Added) > 0 {
+		b.WriteString("\n## Added\n\n")
+		for _, t := range d.Added {
+			fmt.Fprintf(&b, "- **%s**: %s%s\n", t.ID, t.Title, taskMeta(t))
+		}
+	}
+
+	if len(d.Removed) > 0 {
+		b.WriteString("\n## Removed\n\n")
+		for _, t := range d.Removed {
+			fmt.Fprintf(&b, "- **%s**: %s%s\n", t.ID, t.Title, taskMeta(t))
+		}
+	}
+
+	if len(d.Changed) > 0 {
+		b.WriteString("\n## Changed\n")
+		for _, c := range d.Changed {
+			fmt.Fprintf(&b, "\n### %s: %s\n\n", c.New.ID, c.New.Title)
+			if c.Renumbered() {
+				how := "by ID"
+				if c.ByTitle {
+					how = "by title"
+				}
+				fmt.Fprintf(&b, "- Renumbered from %s (matched %s)\n", c.Old.ID, how)
+			}
+			for _, f := range c.Fields {
+				switch f.Field {
+				case "title":
+					fmt.Fprintf(&b, "- Retitled: %q → %q\n", f.Old, f.New)
+				case "complexity":
+					fmt.Fprintf(&b, "- Complexity: %s → %s\n", orNone(f.Old), orNone(f.New))
+				case "dependencies":
+					var parts []string
+					for _, dep := range f.Added {
+						parts = append(parts, "+"+dep)
+					}
+					for _, dep := range f.Removed {
+						parts = append(parts, "-"+dep)
+					}
+					fmt.Fprintf(&b, "- Dependencies: %s\n", strings.Join(parts, ", "))
+				case "criteria":
+					b.WriteString("- Criteria:\n")
+					for _, s := range f.Added {
+						fmt.Fprintf(&b, "  - added: %s\n", s)
+					}
+					for _, s := range f.Removed {
+						fmt.Fprintf(&b, "  - removed: %s\n", s)
+					}
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+func taskMeta(t Task) string {
+	meta := " (" + orNone(t.Complexity)
+	if len(t.Dependencies) > 0 {
+		meta += "; deps: " + strings.Join(t.Dependencies, ", ")
+	}
+	return meta + ")"
+}
+
+func orNone(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "none"
+	}
+	return s
+}
+
+// WriteChangelog diffs two task sets and writes the Markdown changelog to path.
+func WriteChangelog(oldTasks, newTasks []Task, path, oldLabel, newLabel string) (*Diff, error) {
+	d := DiffTasks(oldTasks, newTasks)
+	if err := os.WriteFile(path, []byte(d.Markdown(oldLabel, newLabel)), 0644); err != nil {
+		return nil, fmt.Errorf("write changelog: %w", err)
+	}
+	return d, nil
+}
@@ -0,0 +1,162 @@
+package vts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffTasks_MatchByID(t *testing.T) {
+	oldTasks := []Task{
+		{ID: "VTS-001", Title: "Setup config loader", Complexity: "S", Criteria: []string{"Loads defaults"}},
+		{ID: "VTS-002", Title: "Build parser", Complexity: "M", Dependencies: []string{"VTS-001"}},
+	}
+	newTasks := []Task{
+		{ID: "VTS-001", Title: "Setup config loader", Complexity: "S", Criteria: []string{"Loads defaults", "Reports bad keys"}},
+		{ID: "VTS-002", Title: "Build VTS parser", Complexity: "L"},
+		{ID: "VTS-003", Title: "Write docs", Complexity: "XS", Dependencies: []string{"VTS-002"}},
+	}
+
+	d := DiffTasks(oldTasks, newTasks)
+	if len(d.Added) != 1 || d.Added[0].ID != "VTS-003" {
+		t.Errorf("added = %v, want [VTS-003]", d.Added)
+	}
+	if len(d.Removed) != 0 {
+		t.Errorf("removed = %v, want none", d.Removed)
+	}
+	if len(d.Changed) != 2 {
+		t.Fatalf("changed = %d, want 2", len(d.Changed))
+	}
+
+	crit := d.Changed[0]
+	if crit.New.ID != "VTS-001" || len(crit.Fields) != 1 || crit.Fields[0].Field != "criteria" {
+		t.Errorf("VTS-001 change = %+v", crit)
+	}
+	if crit.Fields[0].Added[0] != "Reports bad keys" {
+		t.Errorf("criteria added = %v", crit.Fields[0].Added)
+	}
+
+	parser := d.Changed[1]
+	var fields []string
+	for _, f := range parser.Fields {
+		fields = append(fields, f.Field)
+	}
+	if strings.Join(fields, ",") != "title,complexity,dependencies" {
+		t.Errorf("VTS-002 fields = %v, want title,complexity,dependencies", fields)
+	}
+	if parser.Renumbered() {
+		t.Error("VTS-002 should not be renumbered")
+	}
+}
+
+func TestDiffTasks_ShiftedIDs(t *testing.T) {
+	// VTS-002 removed; everything after it shifted up by one
+	oldTasks := []Task{
+		{ID: "VTS-001", Title: "Setup config loader", Complexity: "S"},
+		{ID: "VTS-002", Title: "Legacy migration shim", Complexity: "M", Dependencies: []string{"VTS-001"}},
+		{ID: "VTS-003", Title: "Build parser", Complexity: "M", Dependencies: []string{"VTS-001"}},
+		{ID: "VTS-004", Title: "Wire executor", Complexity: "L", Dependencies: []string{"VTS-003"}},
+	}
+	newTasks := []Task{
+		{ID: "VTS-001", Title: "Setup config loader", Complexity: "S"},
+		{ID: "VTS-002", Title: "Build parser", Complexity: "M", Dependencies: []string{"VTS-001"}},
+		{ID: "VTS-003", Title: "Wire executor", Complexity: "L", Dependencies: []string{"VTS-002"}},
+	}
+
+	d := DiffTasks(oldTasks, newTasks)
+	if len(d.Removed) != 1 || d.Removed[0].Title != "Legacy migration shim" {
+		t.Errorf("removed = %v, want the migration shim", d.Removed)
+	}
+	if len(d.Added) != 0 {
+		t.Errorf("added = %v, want none", d.Added)
+	}
+	if d.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", d.Unchanged)
+	}
+	if len(d.Changed) != 2 {
+		t.Fatalf("changed = %+v, want 2 renumbered", d.Changed)
+	}
+	for _, c := range d.Changed {
+		if !c.Renumbered() || !c.ByTitle {
+			t.Errorf("%s: renumbered=%v byTitle=%v, want both", c.New.ID, c.Renumbered(), c.ByTitle)
+		}
+		// Dependencies are compared in the new numbering, so a shift alone is not a change
+		if len(c.Fields) != 0 {
+			t.Errorf("%s: fields = %+v, want none", c.New.ID, c.Fields)
+		}
+	}
+}
+
+func TestDiffTasks_RetitledBeyondRecognition(t *testing.T) {
+	oldTasks := []Task{{ID: "VTS-001", Title: "Setup config"}}
+	newTasks := []Task{{ID: "VTS-001", Title: "Bootstrap runtime environment"}}
+
+	d := DiffTasks(oldTasks, newTasks)
+	if len(d.Added)+len(d.Removed) != 0 || len(d.Changed) != 1 {
+		t.Fatalf("diff = %+v, want a single retitle", d)
+	}
+	if d.Changed[0].Fields[0].Field != "title" {
+		t.Errorf("fields = %+v", d.Changed[0].Fields)
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"Build parser", "Build parser", 1, 1},
+		{"Build parser", "build Parser!", 1, 1},
+		{"Build parser", "Build VTS parser", 0.6, 0.99},
+		{"Build parser", "Wire executor", 0, 0.3},
+		{"", "", 1, 1},
+	}
+	for _, tt := range tests {
+		got := TitleSimilarity(tt.a, tt.b)
+		if got < tt.min || got > tt.max {
+			t.Errorf("TitleSimilarity(%q, %q) = %.2f, want [%.2f, %.2f]", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestWriteChangelog(t *testing.T) {
+	oldTasks := []Task{
+		{ID: "VTS-001", Title: "Setup", Complexity: "S"},
+		{ID: "VTS-002", Title: "Obsolete task", Complexity: "M"},
+	}
+	newTasks := []Task{
+		{ID: "VTS-001", Title: "Setup", Complexity: "M", Dependencies: []string{"VTS-002"}},
+		{ID: "VTS-002", Title: "Brand new work", Complexity: "S"},
+	}
+	path := filepath.Join(t.TempDir(), ChangelogFile)
+	if _, err := WriteChangelog(oldTasks, newTasks, path, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	for _, want := range []string{
+		"# VTS Changelog",
+		"Compared `a` → `b`",
+		"## Changed",
+		"- Complexity: S → M",
+		"- Dependencies: +VTS-002",
+		"- Retitled: \"Obsolete task\" → \"Brand new work\"",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("changelog missing %q\n%s", want, md)
+		}
+	}
+}
+
+func TestDiff_MarkdownNoChanges(t *testing.T) {
+	tasks := []Task{{ID: "VTS-001", Title: "Setup"}}
+	md := DiffTasks(tasks, tasks).Markdown("a", "b")
+	if !strings.Contains(md, "No changes.") {
+		t.Errorf("expected no-changes note, got:\n%s", md)
+	}
+}
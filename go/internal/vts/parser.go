@@ -21,6 +21,7 @@ type Task struct {
 	Owner        string
 	Source       string
 	SourceRef    string
+	Path         string // source file path (set by ReadFile)
 }
 
 var taskPattern = regexp.MustCompile(`(?im)^#{2,3}\s+Task\s+(\d+)\s*[:\.—]\s*(.+)`)
@@ -14,7 +14,84 @@ func ReadFile(path string) (*Task, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read VTS file: %w", err)
 	}
-	return ParseVTSFile(string(data))
+	task, err := ParseVTSFile(string(data))
+	if err != nil {
+		return nil, err
+	}
+	task.Path = path
+	return task, nil
+}
+
+// UpdateFrontmatter sets scalar frontmatter fields in a VTS file in place.
+// Existing keys are replaced where they are; missing keys are appended
+// before the closing "---". The body and all other fields are preserved.
+// Values are written quoted when the key is one the writer quotes (title, owner, source_ref).
+func UpdateFrontmatter(path string, fields map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read VTS file: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	state := 0 // 0=before frontmatter, 1=in frontmatter
+	end := -1  // index of the closing "---"
+	seen := map[string]bool{}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" {
+			if state == 1 {
+				end = i
+				break
+			}
+			state = 1
+			continue
+		}
+		if state != 1 {
+			continue
+		}
+		idx := strings.Index(line, ":")
+		if idx <= 0 || strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		val, ok := fields[key]
+		if !ok {
+			continue
+		}
+		lines[i] = frontmatterLine(key, val)
+		seen[key] = true
+	}
+	if end < 0 {
+		return fmt.Errorf("%s: no frontmatter block", filepath.Base(path))
+	}
+
+	var missing []string
+	for key := range fields {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	added := make([]string, len(missing))
+	for i, key := range missing {
+		added[i] = frontmatterLine(key, fields[key])
+	}
+	lines = append(lines[:end], append(added, lines[end:]...)...)
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("write VTS file: %w", err)
+	}
+	return nil
+}
+
+// frontmatterLine formats one scalar frontmatter field.
+func frontmatterLine(key, val string) string {
+	switch key {
+	case "title", "owner", "source_ref":
+		return fmt.Sprintf("%s: %q", key, val)
+	default:
+		return fmt.Sprintf("%s: %s", key, val)
+	}
 }
 
 // ReadDir reads all .md files in a directory as VTS tasks, sorted by ID.
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -254,3 +255,80 @@ func TestReadDir_EmptyDir(t *testing.T) {
 		t.Fatal("expected error for empty dir")
 	}
 }
+
+func TestUpdateFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vts-001-task.md")
+	content := `---
+id: VTS-001
+title: "Task One"
+status: pending
+owner: ""
+dependencies:
+  - VTS-002
+---
+
+# Task One
+
+status: this line is body text and must not change.
+`
+	os.WriteFile(path, []byte(content), 0644)
+
+	if err := UpdateFrontmatter(path, map[string]string{"status": "done", "owner": "alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("reparse: %v", err)
+	}
+	if task.Status != "done" {
+		t.Errorf("Status = %q, want done", task.Status)
+	}
+	if task.Owner != "alice" {
+		t.Errorf("Owner = %q, want alice", task.Owner)
+	}
+	if len(task.Dependencies) != 1 {
+		t.Errorf("Dependencies = %v, want 1", task.Dependencies)
+	}
+	if task.Path != path {
+		t.Errorf("Path = %q, want %q", task.Path, path)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "status: this line is body text") {
+		t.Error("body line was modified")
+	}
+}
+
+func TestUpdateFrontmatterAddsMissingKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vts-001-task.md")
+	content := `---
+id: VTS-001
+title: "Task One"
+status: pending
+---
+
+# Task One
+`
+	os.WriteFile(path, []byte(content), 0644)
+
+	if err := UpdateFrontmatter(path, map[string]string{"status": "active", "owner": "bob"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	task, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("reparse: %v", err)
+	}
+	if task.Status != "active" || task.Owner != "bob" {
+		t.Errorf("status/owner = %q/%q, want active/bob", task.Status, task.Owner)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "status: active\nowner: \"bob\"\n---\n\n# Task One") {
+		t.Errorf("owner should be appended before the closing ---:\n%s", data)
+	}
+
+	os.WriteFile(path, []byte("# No frontmatter\n"), 0644)
+	if err := UpdateFrontmatter(path, map[string]string{"owner": "bob"}); err == nil {
+		t.Error("file without frontmatter should be an error")
+	}
+}
@@ -12,9 +12,10 @@ import (
 func vtsOutput(onLog func(string), format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	if onLog != nil {
-		trimmed := strings.TrimSpace(msg)
-		if trimmed != "" {
-			onLog(trimmed)
+		// Keep the indentation; only the trailing newline belongs to stdout
+		line := strings.TrimRight(msg, "\n")
+		if strings.TrimSpace(line) != "" {
+			onLog(line)
 		}
 	} else {
 		fmt.Print(msg)